@@ -47,18 +47,24 @@ func (c *Client) DeployWithWebhook(
 }
 
 // UpdateBatchImage updates all releases that use a certain image with a new tag,
-// within a single namespace
+// within a single namespace. Protected releases are not updated: a deploy request
+// is returned for each of them instead. Releases which could not be updated are
+// listed in the errors of the response.
 func (c *Client) UpdateBatchImage(
 	ctx context.Context,
 	projID, clusterID uint,
 	namespace string,
 	req *types.UpdateImageBatchRequest,
-) error {
-	return c.postRequest(
+) (*types.UpdateImageBatchResponse, error) {
+	resp := &types.UpdateImageBatchResponse{}
+
+	err := c.postRequest(
 		fmt.Sprintf("/projects/%d/clusters/%d/namespaces/%s/releases/image/batch", projID, clusterID, namespace),
 		req,
-		nil,
+		resp,
 	)
+
+	return resp, err
 }
 
 func (c *Client) DeployTemplate(
@@ -87,23 +93,182 @@ func (c *Client) DeployAddon(
 	)
 }
 
-// UpgradeRelease upgrades a specific release with new values or chart version
+// UpgradeRelease upgrades a specific release with new values or chart version. If the
+// release is protected, the upgrade does not run and a pending deploy request is
+// returned instead.
 func (c *Client) UpgradeRelease(
 	ctx context.Context,
 	projID, clusterID uint,
 	namespace, name string,
 	req *types.UpgradeReleaseRequest,
-) error {
-	return c.postRequest(
+) (*types.UpgradeReleaseResponse, error) {
+	resp := &types.UpgradeReleaseResponse{}
+
+	err := c.postRequest(
 		fmt.Sprintf(
 			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade",
 			projID, clusterID,
 			namespace, name,
 		),
 		req,
-		nil,
+		resp,
 		postRequestOpts{
 			retryCount: 3,
 		},
 	)
+
+	return resp, err
+}
+
+// PromoteRelease copies the latest revision of a release to another cluster or namespace
+func (c *Client) PromoteRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.PromoteReleaseRequest,
+) (*types.PromoteReleaseResponse, error) {
+	resp := &types.PromoteReleaseResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/promote",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetReleaseDrift compares the manifest of the latest revision of a release against
+// the live objects in the cluster
+func (c *Client) GetReleaseDrift(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+) (*types.GetReleaseDriftResponse, error) {
+	resp := &types.GetReleaseDriftResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/drift",
+			projID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ReconcileReleaseDrift re-applies the latest revision of a release if its live
+// objects have drifted from its manifest
+func (c *Client) ReconcileReleaseDrift(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+) (*types.ReconcileReleaseDriftResponse, error) {
+	resp := &types.ReconcileReleaseDriftResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/drift/reconcile",
+			projID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetRolloutConfig gets how upgrades of a release are rolled out
+func (c *Client) GetRolloutConfig(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+) (*types.GetRolloutConfigResponse, error) {
+	resp := &types.GetRolloutConfigResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/rollout_config",
+			projID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetReleaseOverlays gets the manifest overlays of a release
+func (c *Client) GetReleaseOverlays(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+) (*types.ListManifestOverlaysResponse, error) {
+	resp := &types.ListManifestOverlaysResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/overlays",
+			projID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateReleaseOverlays replaces the manifest overlays of a release, which are applied
+// the next time the release is installed or upgraded
+func (c *Client) UpdateReleaseOverlays(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpdateManifestOverlaysRequest,
+) (*types.ListManifestOverlaysResponse, error) {
+	resp := &types.ListManifestOverlaysResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/overlays",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateRolloutConfig updates how upgrades of a release are rolled out
+func (c *Client) UpdateRolloutConfig(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpdateRolloutConfigRequest,
+) (*types.UpdateRolloutConfigResponse, error) {
+	resp := &types.UpdateRolloutConfigResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/rollout_config",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
 }
@@ -132,6 +132,72 @@ func (c *Client) GetRelease(
 	return resp, err
 }
 
+// GetPorterYAML returns a porter.yaml resource group which creates or updates the
+// release with "porter apply"
+func (c *Client) GetPorterYAML(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) (*types.GetPorterYAMLResponse, error) {
+	resp := &types.GetPorterYAMLResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/porter_yaml",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetReleaseHistory returns the stored revisions of a release
+func (c *Client) GetReleaseHistory(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) (types.GetReleaseHistoryResponse, error) {
+	resp := types.GetReleaseHistoryResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/history",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// GetReleaseDiff returns the diff of the values, chart version and rendered manifest
+// between two revisions of a release
+func (c *Client) GetReleaseDiff(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.GetReleaseDiffRequest,
+) (*types.GetReleaseDiffResponse, error) {
+	resp := &types.GetReleaseDiffResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/history/diff",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) GetJobs(
 	ctx context.Context,
 	projectID, clusterID uint,
@@ -146,6 +146,28 @@ func (c *Client) CreateProjectCluster(
 	return resp, err
 }
 
+// UpdateClusterHelmStorage switches where the Helm releases of a cluster are stored,
+// migrating the existing releases to the new storage
+func (c *Client) UpdateClusterHelmStorage(
+	ctx context.Context,
+	projectID uint,
+	clusterID uint,
+	req *types.UpdateClusterHelmStorageRequest,
+) (*types.UpdateClusterHelmStorageResponse, error) {
+	resp := &types.UpdateClusterHelmStorageResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/helm_storage",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // DeleteProjectCluster deletes a cluster given a project id and cluster id
 func (c *Client) DeleteProjectCluster(
 	ctx context.Context,
@@ -177,3 +199,533 @@ func (c *Client) DeleteProject(
 		nil,
 	)
 }
+
+// CreateAPIToken creates a named API token for a project
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateAPITokenRequest,
+) (*types.CreateAPITokenResponse, error) {
+	resp := &types.CreateAPITokenResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListAPITokens lists the API tokens for a project, including revoked tokens
+func (c *Client) ListAPITokens(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListAPITokensResponse, error) {
+	resp := &types.ListAPITokensResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RevokeAPIToken revokes an API token, after which it can no longer be used
+func (c *Client) RevokeAPIToken(
+	ctx context.Context,
+	projectID, tokenID uint,
+) (*types.GetAPITokenResponse, error) {
+	resp := &types.GetAPITokenResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token/%d/revoke",
+			projectID, tokenID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CanI evaluates whether the current user or token, or the user or token given in the
+// request, can perform a verb on a scope path in the project
+func (c *Client) CanI(
+	ctx context.Context,
+	projectID uint,
+	req *types.CanIRequest,
+) (*types.CanIResponse, error) {
+	resp := &types.CanIResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/policy/can_i",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListServiceAccounts lists the service accounts of a project
+func (c *Client) ListServiceAccounts(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListServiceAccountsResponse, error) {
+	resp := &types.ListServiceAccountsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/service_accounts",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateServiceAccount creates a service account with the given role in a project
+func (c *Client) CreateServiceAccount(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateServiceAccountRequest,
+) (*types.CreateServiceAccountResponse, error) {
+	resp := &types.CreateServiceAccountResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/service_accounts",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteServiceAccount deletes a service account and revokes its API tokens
+func (c *Client) DeleteServiceAccount(
+	ctx context.Context,
+	projectID, serviceAccountID uint,
+) (*types.DeleteServiceAccountResponse, error) {
+	resp := &types.DeleteServiceAccountResponse{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/service_accounts/%d",
+			projectID, serviceAccountID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateServiceAccountAPIToken creates an API token which is owned by a service
+// account, and has the access of the service account's role
+func (c *Client) CreateServiceAccountAPIToken(
+	ctx context.Context,
+	projectID, serviceAccountID uint,
+	req *types.CreateServiceAccountAPITokenRequest,
+) (*types.CreateAPITokenResponse, error) {
+	resp := &types.CreateAPITokenResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/service_accounts/%d/api_token",
+			projectID, serviceAccountID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListTeams lists the teams of a project along with their members
+func (c *Client) ListTeams(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListTeamsResponse, error) {
+	resp := &types.ListTeamsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateTeam creates a team with the given role in a project
+func (c *Client) CreateTeam(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateTeamRequest,
+) (*types.CreateTeamResponse, error) {
+	resp := &types.CreateTeamResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateTeam updates the name or role of a team
+func (c *Client) UpdateTeam(
+	ctx context.Context,
+	projectID, teamID uint,
+	req *types.UpdateTeamRequest,
+) (*types.UpdateTeamResponse, error) {
+	resp := &types.UpdateTeamResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams/%d",
+			projectID, teamID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteTeam deletes a team, which removes the access granted to its members
+func (c *Client) DeleteTeam(
+	ctx context.Context,
+	projectID, teamID uint,
+) (*types.DeleteTeamResponse, error) {
+	resp := &types.DeleteTeamResponse{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams/%d",
+			projectID, teamID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// AddTeamMember adds a collaborator in the project to a team
+func (c *Client) AddTeamMember(
+	ctx context.Context,
+	projectID, teamID uint,
+	req *types.AddTeamMemberRequest,
+) (*types.AddTeamMemberResponse, error) {
+	resp := &types.AddTeamMemberResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams/%d/members",
+			projectID, teamID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RemoveTeamMember removes a user from a team
+func (c *Client) RemoveTeamMember(
+	ctx context.Context,
+	projectID, teamID, userID uint,
+) (*types.RemoveTeamMemberResponse, error) {
+	resp := &types.RemoveTeamMemberResponse{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/teams/%d/members/%d",
+			projectID, teamID, userID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListProtectedResources lists the namespaces and releases of a project whose
+// upgrades must be approved
+func (c *Client) ListProtectedResources(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListProtectedResourcesResponse, error) {
+	resp := &types.ListProtectedResourcesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/protected_resources",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateProtectedResource protects a namespace, or a release in a namespace, so that
+// its upgrades must be approved
+func (c *Client) CreateProtectedResource(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateProtectedResourceRequest,
+) (*types.CreateProtectedResourceResponse, error) {
+	resp := &types.CreateProtectedResourceResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/protected_resources",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteProtectedResource removes the protection of a namespace or release
+func (c *Client) DeleteProtectedResource(
+	ctx context.Context,
+	projectID, protectedResourceID uint,
+) (*types.DeleteProtectedResourceResponse, error) {
+	resp := &types.DeleteProtectedResourceResponse{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/protected_resources/%d",
+			projectID, protectedResourceID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetDeployPolicy gets the deploy policy which the releases of a project are checked
+// against on deploy
+func (c *Client) GetDeployPolicy(
+	ctx context.Context,
+	projectID uint,
+) (*types.DeployPolicy, error) {
+	resp := &types.DeployPolicy{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/deploy_policy",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateDeployPolicy replaces the deploy policy of a project
+func (c *Client) UpdateDeployPolicy(
+	ctx context.Context,
+	projectID uint,
+	req *types.UpdateDeployPolicyRequest,
+) (*types.DeployPolicy, error) {
+	resp := &types.DeployPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/deploy_policy",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListDeployRequests lists the deploy requests of a project, most recent first
+func (c *Client) ListDeployRequests(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListDeployRequestsRequest,
+) (*types.ListDeployRequestsResponse, error) {
+	resp := &types.ListDeployRequestsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/deploy_requests",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ApproveDeployRequest approves a pending deploy request, which runs the upgrade
+func (c *Client) ApproveDeployRequest(
+	ctx context.Context,
+	projectID, deployRequestID uint,
+	req *types.ReviewDeployRequestRequest,
+) (*types.ReviewDeployRequestResponse, error) {
+	resp := &types.ReviewDeployRequestResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/deploy_requests/%d/approve",
+			projectID, deployRequestID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RejectDeployRequest rejects a pending deploy request
+func (c *Client) RejectDeployRequest(
+	ctx context.Context,
+	projectID, deployRequestID uint,
+	req *types.ReviewDeployRequestRequest,
+) (*types.ReviewDeployRequestResponse, error) {
+	resp := &types.ReviewDeployRequestResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/deploy_requests/%d/reject",
+			projectID, deployRequestID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListAuditLogs lists the audit logs of a project which match the filters in the
+// request, most recent first
+func (c *Client) ListAuditLogs(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListAuditLogsRequest,
+) (*types.ListAuditLogsResponse, error) {
+	resp := &types.ListAuditLogsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/audit_logs",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListCustomRoles lists the custom roles defined in a project
+func (c *Client) ListCustomRoles(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListCustomRolesResponse, error) {
+	resp := &types.ListCustomRolesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/roles/custom",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateCustomRole creates a custom role from a list of policy documents
+func (c *Client) CreateCustomRole(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateCustomRoleRequest,
+) (*types.CreateCustomRoleResponse, error) {
+	resp := &types.CreateCustomRoleResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/roles/custom",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateCustomRole updates the name or policy of a custom role
+func (c *Client) UpdateCustomRole(
+	ctx context.Context,
+	projectID, customRoleID uint,
+	req *types.UpdateCustomRoleRequest,
+) (*types.UpdateCustomRoleResponse, error) {
+	resp := &types.UpdateCustomRoleResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/roles/custom/%d",
+			projectID, customRoleID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteCustomRole deletes a custom role which is not assigned to any collaborators
+func (c *Client) DeleteCustomRole(
+	ctx context.Context,
+	projectID, customRoleID uint,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/roles/custom/%d",
+			projectID, customRoleID,
+		),
+		nil,
+		nil,
+	)
+}
@@ -47,6 +47,27 @@ func (c *Client) CreateHelmRepo(
 	return resp, err
 }
 
+// UpdateHelmRepoProvenance sets the keyring which charts from a helm repo are verified
+// against, and whether charts must be verified before they are installed
+func (c *Client) UpdateHelmRepoProvenance(
+	ctx context.Context,
+	projectID, helmRepoID uint,
+	req *types.UpdateHelmRepoProvenanceRequest,
+) (*types.HelmRepo, error) {
+	resp := &types.HelmRepo{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/helmrepos/%d/provenance",
+			projectID, helmRepoID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // ListRegistries returns a list of registries for a project
 func (c *Client) ListRegistries(
 	ctx context.Context,
@@ -42,3 +42,23 @@ func (c *Client) GetTemplate(
 
 	return resp, err
 }
+
+func (c *Client) GetProjectTemplate(
+	ctx context.Context,
+	projectID uint,
+	name, version string,
+	req *types.GetTemplateRequest,
+) (*types.GetTemplateResponse, error) {
+	resp := &types.GetTemplateResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/templates/%s/%s",
+			projectID, name, version,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -100,3 +100,53 @@ func (c *Client) DeleteUser(
 		nil,
 	)
 }
+
+// ListSessions lists the active sessions for the current user
+func (c *Client) ListSessions(
+	ctx context.Context,
+) (*types.ListSessionsResponse, error) {
+	resp := &types.ListSessionsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/users/current/sessions",
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RevokeSession revokes a single session for the current user
+func (c *Client) RevokeSession(
+	ctx context.Context,
+	sessionID uint,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/users/current/sessions/%d",
+			sessionID,
+		),
+		nil,
+		nil,
+	)
+}
+
+// RevokeAllSessions revokes every session for the current user other than the
+// session making the request
+func (c *Client) RevokeAllSessions(
+	ctx context.Context,
+) (*types.RevokeSessionsResponse, error) {
+	resp := &types.RevokeSessionsResponse{}
+
+	err := c.deleteRequest(
+		fmt.Sprintf(
+			"/users/current/sessions",
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
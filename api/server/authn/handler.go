@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/models"
 )
 
 // AuthNFactory generates a middleware handler `AuthN`
@@ -29,21 +31,29 @@ func NewAuthNFactory(
 // NewAuthenticated creates a new instance of `AuthN` that implements the http.Handler
 // interface.
 func (f *AuthNFactory) NewAuthenticated(next http.Handler) http.Handler {
-	return &AuthN{next, f.config, false}
+	return &AuthN{next, f.config, false, false}
 }
 
 // NewAuthenticatedWithRedirect creates a new instance of `AuthN` that implements the http.Handler
 // interface. This handler redirects the user to login if the user is not attached, and stores a
 // redirect URI in the session, if the session exists.
 func (f *AuthNFactory) NewAuthenticatedWithRedirect(next http.Handler) http.Handler {
-	return &AuthN{next, f.config, true}
+	return &AuthN{next, f.config, true, false}
+}
+
+// NewAuthenticatedWithoutTOTPEnforcement creates a new instance of `AuthN` that implements the
+// http.Handler interface. This handler lets users that have not yet enrolled in TOTP through,
+// even if TOTP is enforced on the Porter instance, so that they are able to enroll.
+func (f *AuthNFactory) NewAuthenticatedWithoutTOTPEnforcement(next http.Handler) http.Handler {
+	return &AuthN{next, f.config, false, true}
 }
 
 // AuthN implements the authentication middleware
 type AuthN struct {
-	next     http.Handler
-	config   *config.Config
-	redirect bool
+	next                http.Handler
+	config              *config.Config
+	redirect            bool
+	skipTOTPEnforcement bool
 }
 
 // ServeHTTP attaches an authenticated subject to the request context,
@@ -93,7 +103,40 @@ func (authn *AuthN) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authn.nextWithUserID(w, r, userID)
+	// search for the user
+	user, err := authn.config.Repo.User().ReadUser(userID)
+
+	if err != nil {
+		authn.sendForbiddenError(fmt.Errorf("user with id %d not found in database", userID), w, r)
+		return
+	}
+
+	if err := authn.verifySessionTOTP(session, user); err != nil {
+		authn.sendForbiddenError(err, w, r)
+		return
+	}
+
+	authn.nextWithUser(w, r, user)
+}
+
+// verifySessionTOTP checks that cookie-based sessions for users with basic (email/password)
+// login have passed TOTP verification if the user has enabled it, and that the user has
+// enrolled in TOTP if it is enforced on the instance.
+func (authn *AuthN) verifySessionTOTP(session *sessions.Session, user *models.User) error {
+	// users that log in through an external identity provider never have a password set
+	if user.Password == "" {
+		return nil
+	}
+
+	if user.TOTPEnabled {
+		if verified, ok := session.Values["totp_verified"].(bool); !ok || !verified {
+			return fmt.Errorf("session has not passed two-factor authentication")
+		}
+	} else if authn.config.ServerConf.TOTPEnforced && !authn.skipTOTPEnforcement {
+		return fmt.Errorf("two-factor authentication must be enabled for user %d", user.ID)
+	}
+
+	return nil
 }
 
 func (authn *AuthN) handleForbiddenForSession(
@@ -125,6 +168,42 @@ func (authn *AuthN) handleForbiddenForSession(
 func (authn *AuthN) nextWithToken(w http.ResponseWriter, r *http.Request, tok *token.Token) {
 	// TODO: add section to get service account for server-side token
 
+	// stored API tokens can be revoked, so they are checked against the database on
+	// every request and attached to the context for the policy loader
+	if tok.IsStored() {
+		apiToken, err := authn.config.Repo.APIToken().ReadAPIToken(tok.ProjectID, tok.TokenID)
+
+		if err != nil {
+			authn.sendForbiddenError(fmt.Errorf("api token %s not found in project %d", tok.TokenID, tok.ProjectID), w, r)
+			return
+		}
+
+		if apiToken.Revoked {
+			authn.sendForbiddenError(fmt.Errorf("api token %d has been revoked", apiToken.ID), w, r)
+			return
+		}
+
+		if apiToken.IsExpired() {
+			authn.sendForbiddenError(fmt.Errorf("api token %d has expired", apiToken.ID), w, r)
+			return
+		}
+
+		// the last use of the token is only written once per interval, so that each
+		// request doesn't write to the database
+		if now := time.Now(); apiToken.LastUsed == nil || now.Sub(*apiToken.LastUsed) > apiTokenLastUsedInterval {
+			apiToken.LastUsed = &now
+
+			apiToken, err = authn.config.Repo.APIToken().UpdateAPIToken(apiToken)
+
+			if err != nil {
+				apierrors.HandleAPIError(authn.config.Logger, authn.config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+				return
+			}
+		}
+
+		r = r.Clone(context.WithValue(r.Context(), types.APITokenCtxKey, apiToken))
+	}
+
 	// for now, we just use nextWithUser using the `iby` field for the token
 	authn.nextWithUserID(w, r, tok.IBy)
 }
@@ -140,6 +219,12 @@ func (authn *AuthN) nextWithUserID(w http.ResponseWriter, r *http.Request, userI
 		return
 	}
 
+	authn.nextWithUser(w, r, user)
+}
+
+// nextWithUser calls the next handler with the user set in the context with key
+// `types.UserScope`.
+func (authn *AuthN) nextWithUser(w http.ResponseWriter, r *http.Request, user *models.User) {
 	// add the user to the context
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, types.UserScope, user)
@@ -156,6 +241,10 @@ func (authn *AuthN) sendForbiddenError(err error, w http.ResponseWriter, r *http
 	apierrors.HandleAPIError(authn.config.Logger, authn.config.Alerter, w, r, reqErr, true)
 }
 
+// apiTokenLastUsedInterval is the minimum interval between writes of the last use of
+// an API token
+const apiTokenLastUsedInterval = time.Minute
+
 var errInvalidToken = fmt.Errorf("authorization header exists, but token is not valid")
 var errInvalidAuthHeader = fmt.Errorf("invalid authorization header in request")
 
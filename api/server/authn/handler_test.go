@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apitest"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -119,6 +120,80 @@ func TestUnauthenticatedUserWithToken(t *testing.T) {
 	assertForbiddenError(t, next, rr)
 }
 
+func TestAuthenticatedUserWithStoredToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	apiToken := createTestAPIToken(t, config, user.ID, false)
+	tokenStr := encodeTestAPIToken(t, config, user.ID, apiToken)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertNextHandlerCalled(t, next, rr, user)
+
+	// last used time should be recorded
+	gotToken, err := config.Repo.APIToken().ReadAPIToken(apiToken.ProjectID, apiToken.UniqueID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.NotNil(t, gotToken.LastUsed, "last used time should be set") {
+		return
+	}
+
+	lastUsed := *gotToken.LastUsed
+
+	// the last used time should not be written again within the interval
+	req, err = http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	gotToken, err = config.Repo.APIToken().ReadAPIToken(apiToken.ProjectID, apiToken.UniqueID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, lastUsed, *gotToken.LastUsed, "last used time should not be updated within the interval")
+}
+
+func TestUnauthenticatedUserWithRevokedToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	apiToken := createTestAPIToken(t, config, user.ID, true)
+	tokenStr := encodeTestAPIToken(t, config, user.ID, apiToken)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertForbiddenError(t, next, rr)
+}
+
 func TestAuthBadDatabaseRead(t *testing.T) {
 	config, handler, next := loadHandlers(t)
 
@@ -233,6 +308,39 @@ func loadHandlersWithRedirect(t *testing.T) (*config.Config, http.Handler, *test
 	return config, handler, next
 }
 
+func createTestAPIToken(t *testing.T, config *config.Config, userID uint, revoked bool) *models.APIToken {
+	apiToken, err := config.Repo.APIToken().CreateAPIToken(&models.APIToken{
+		UniqueID:        "abcdefgh",
+		ProjectID:       1,
+		CreatedByUserID: userID,
+		Name:            "test-token",
+		Role:            types.RoleDeveloper,
+		Revoked:         revoked,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return apiToken
+}
+
+func encodeTestAPIToken(t *testing.T, config *config.Config, userID uint, apiToken *models.APIToken) string {
+	issToken, err := token.GetStoredTokenForAPI(userID, apiToken.ProjectID, apiToken.UniqueID, apiToken.Expiry)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := issToken.EncodeToken(config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
 func assertForbiddenError(t *testing.T, next *testHandler, rr *httptest.ResponseRecorder) {
 	assert := assert.New(t)
 
@@ -12,6 +12,27 @@ func SaveUserAuthenticated(
 	r *http.Request,
 	config *config.Config,
 	user *models.User,
+) (string, error) {
+	return saveUserAuthenticated(w, r, config, user, false)
+}
+
+// SaveUserAuthenticatedWithTOTP saves the user as authenticated in the session, after
+// the user has passed TOTP verification during login
+func SaveUserAuthenticatedWithTOTP(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+) (string, error) {
+	return saveUserAuthenticated(w, r, config, user, true)
+}
+
+func saveUserAuthenticated(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+	totpVerified bool,
 ) (string, error) {
 	session, err := config.Store.Get(r, config.ServerConf.CookieName)
 
@@ -29,6 +50,9 @@ func SaveUserAuthenticated(
 	session.Values["user_id"] = user.ID
 	session.Values["email"] = user.Email
 
+	// a new login must pass TOTP verification again if the user has enabled it
+	session.Values["totp_verified"] = totpVerified
+
 	// we unset the redirect uri after login
 	session.Values["redirect_uri"] = ""
 
@@ -49,5 +73,61 @@ func SaveUserUnauthenticated(
 	session.Values["authenticated"] = false
 	session.Values["user_id"] = nil
 	session.Values["email"] = nil
+	session.Values["totp_verified"] = nil
 	return session.Save(r, w)
 }
+
+// SaveUserTOTPVerified marks the session as having passed TOTP verification. Sessions
+// for users with TOTP enabled are rejected by the authn middleware until this is set.
+func SaveUserTOTPVerified(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+) error {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil {
+		return err
+	}
+
+	session.Values["totp_verified"] = true
+	return session.Save(r, w)
+}
+
+// GetCurrentSessionKey returns the key of the session that the request was made with,
+// or the empty string if the request did not use a session cookie
+func GetCurrentSessionKey(r *http.Request, config *config.Config) string {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil || session.IsNew {
+		return ""
+	}
+
+	return session.ID
+}
+
+// RevokeUserSessions deletes all of a user's sessions except for the session with the
+// key exceptKey, and returns the number of revoked sessions
+func RevokeUserSessions(config *config.Config, userID uint, exceptKey string) (int, error) {
+	sessions, err := config.Repo.Session().ListSessionsByUserID(userID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, session := range sessions {
+		if exceptKey != "" && session.Key == exceptKey {
+			continue
+		}
+
+		if _, err := config.Repo.Session().DeleteSession(&models.Session{Key: session.Key}); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
@@ -146,7 +146,19 @@ func (d *OutOfClusterAgentGetter) GetHelmAgent(r *http.Request, cluster *models.
 		namespace = getNamespaceFromRequest(r)
 	}
 
-	helmAgent, err := helm.GetAgentFromK8sAgent("secret", namespace, d.config.Logger, k8sAgent)
+	var helmAgent *helm.Agent
+
+	if cluster.GetHelmStorage() == types.HelmStorageSQL {
+		helmAgent, err = helm.GetAgentFromK8sAgentWithSQLStorage(
+			d.config.Repo.HelmRelease(),
+			cluster.ID,
+			namespace,
+			d.config.Logger,
+			k8sAgent,
+		)
+	} else {
+		helmAgent, err = helm.GetAgentFromK8sAgent("secret", namespace, d.config.Logger, k8sAgent)
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Helm agent: %s", err.Error())
@@ -40,7 +40,7 @@ type PolicyHandler struct {
 
 func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// get the full map of scopes to resource actions
-	reqScopes, reqErr := getRequestActionForEndpoint(r, h.endpointMeta)
+	reqScopes, reqErr := GetRequestActionForEndpoint(r, h.endpointMeta)
 
 	if reqErr != nil {
 		apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, reqErr, true)
@@ -51,7 +51,16 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	projID := reqScopes[types.ProjectScope].Resource.UInt
 	user, _ := r.Context().Value(types.UserScope).(*models.User)
 
-	policyDocs, reqErr := h.loader.LoadPolicyDocuments(user.ID, projID)
+	loaderOpts := &policy.PolicyLoaderOpts{
+		ProjectID: projID,
+		UserID:    user.ID,
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		loaderOpts.APIToken = apiToken
+	}
+
+	policyDocs, reqErr := h.loader.LoadPolicyDocuments(loaderOpts)
 
 	if reqErr != nil {
 		apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, reqErr, true)
@@ -74,17 +83,56 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// add the set of resource ids to the request context
+	// add the set of resource ids and the policy to the request context
 	ctx := NewRequestScopeCtx(r.Context(), reqScopes)
+	ctx = context.WithValue(ctx, types.PolicyDocsCtxKey, policyDocs)
 	r = r.Clone(ctx)
 	h.next.ServeHTTP(w, r)
 }
 
+// HasResourceAccess checks that the policy the request was authorized with allows
+// reading each of the given resources, in addition to the resources in the request's
+// scopes. This is used by list endpoints to filter out resources which the policy
+// does not grant access to. If the request was not authorized with a policy, access
+// is allowed.
+func HasResourceAccess(r *http.Request, resources map[types.PermissionScope]types.NameOrUInt) bool {
+	policyDocs, ok := r.Context().Value(types.PolicyDocsCtxKey).([]*types.PolicyDocument)
+
+	if !ok {
+		return true
+	}
+
+	reqScopes, _ := r.Context().Value(types.RequestScopeCtxKey).(map[types.PermissionScope]*types.RequestAction)
+
+	return policy.HasResourceAccess(policyDocs, reqScopes, resources)
+}
+
+// HasResourceVerbAccess checks that the policy the request was authorized with allows
+// the verb on each of the given resources. If the request was not authorized with a
+// policy, access is allowed.
+func HasResourceVerbAccess(
+	r *http.Request,
+	verb types.APIVerb,
+	resources map[types.PermissionScope]types.NameOrUInt,
+) bool {
+	policyDocs, ok := r.Context().Value(types.PolicyDocsCtxKey).([]*types.PolicyDocument)
+
+	if !ok {
+		return true
+	}
+
+	reqScopes, _ := r.Context().Value(types.RequestScopeCtxKey).(map[types.PermissionScope]*types.RequestAction)
+
+	return policy.HasResourceVerbAccess(policyDocs, reqScopes, resources, verb)
+}
+
 func NewRequestScopeCtx(ctx context.Context, reqScopes map[types.PermissionScope]*types.RequestAction) context.Context {
 	return context.WithValue(ctx, types.RequestScopeCtxKey, reqScopes)
 }
 
-func getRequestActionForEndpoint(
+// GetRequestActionForEndpoint returns the verb and resource of each scope of an endpoint,
+// reading the resources from the URL parameters of the request
+func GetRequestActionForEndpoint(
 	r *http.Request,
 	endpointMeta types.APIRequestMetadata,
 ) (res map[types.PermissionScope]*types.RequestAction, reqErr apierrors.RequestError) {
@@ -1,30 +1,68 @@
 package policy
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
+// PolicyLoaderOpts are the options for loading the policy documents of the subject
+// of a request within a project
+type PolicyLoaderOpts struct {
+	ProjectID uint
+	UserID    uint
+
+	// APIToken is set if the request was authenticated with a stored API token, in
+	// which case the policy is loaded from the token instead of the user's role. If
+	// the token is owned by a service account, UserID is the service account's user.
+	APIToken *models.APIToken
+}
+
 type PolicyDocumentLoader interface {
-	LoadPolicyDocuments(userID, projectID uint) ([]*types.PolicyDocument, apierrors.RequestError)
+	LoadPolicyDocuments(opts *PolicyLoaderOpts) ([]*types.PolicyDocument, apierrors.RequestError)
 }
 
-// BasicPolicyDocumentLoader loads policy documents simply depending on the
+// BasicPolicyDocumentLoader loads policy documents simply depending on the role kind,
+// or from the database if the user has been assigned a custom role. The policies of
+// the teams which the user is a member of are merged with the policy of the user's role.
 type BasicPolicyDocumentLoader struct {
-	projRepo repository.ProjectRepository
+	projRepo       repository.ProjectRepository
+	customRoleRepo repository.CustomRoleRepository
+	teamRepo       repository.TeamRepository
 }
 
-func NewBasicPolicyDocumentLoader(projRepo repository.ProjectRepository) *BasicPolicyDocumentLoader {
-	return &BasicPolicyDocumentLoader{projRepo}
+func NewBasicPolicyDocumentLoader(
+	projRepo repository.ProjectRepository,
+	customRoleRepo repository.CustomRoleRepository,
+	teamRepo repository.TeamRepository,
+) *BasicPolicyDocumentLoader {
+	return &BasicPolicyDocumentLoader{projRepo, customRoleRepo, teamRepo}
 }
 
 func (b *BasicPolicyDocumentLoader) LoadPolicyDocuments(
-	userID, projectID uint,
+	opts *PolicyLoaderOpts,
 ) ([]*types.PolicyDocument, apierrors.RequestError) {
+	userID, projectID := opts.UserID, opts.ProjectID
+
+	if apiToken := opts.APIToken; apiToken != nil {
+		if apiToken.ProjectID != projectID {
+			return nil, apierrors.NewErrForbidden(
+				fmt.Errorf("api token %d is not valid for project %d", apiToken.ID, projectID),
+			)
+		}
+
+		// tokens owned by a service account are issued by the service account's user,
+		// so the policy is loaded from that user's role below
+		if apiToken.ServiceAccountID == 0 {
+			return b.loadAPITokenPolicy(apiToken)
+		}
+	}
+
 	// read role and case on role "kind"
 	role, err := b.projRepo.ReadProjectRole(projectID, userID)
 
@@ -36,18 +74,175 @@ func (b *BasicPolicyDocumentLoader) LoadPolicyDocuments(
 		return nil, apierrors.NewErrInternal(err)
 	}
 
-	// load role based on role kind
-	switch role.Kind {
+	policy, reqErr := b.loadRolePolicy(projectID, role.Kind, role.CustomRoleID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	} else if policy == nil {
+		return nil, apierrors.NewErrForbidden(
+			fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
+		)
+	}
+
+	// tokens are scoped to the role of the user who issued them, so team policies are
+	// only merged for requests made by the user directly
+	if opts.APIToken != nil {
+		return policy, nil
+	}
+
+	teams, err := b.teamRepo.ListTeamsByUserID(projectID, userID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if len(teams) == 0 {
+		return policy, nil
+	}
+
+	// a request is allowed if any policy document allows it, so the merged policy
+	// is the union of the documents of the user's role and teams
+	merged := make([]*types.PolicyDocument, 0, len(policy))
+	merged = append(merged, policy...)
+
+	for _, team := range teams {
+		teamPolicy, reqErr := b.loadRolePolicy(projectID, team.Kind, team.CustomRoleID)
+
+		if reqErr != nil {
+			return nil, reqErr
+		} else if teamPolicy == nil {
+			return nil, apierrors.NewErrForbidden(
+				fmt.Errorf("%s role not supported for team %d, project %d", string(team.Kind), team.ID, projectID),
+			)
+		}
+
+		merged = append(merged, teamPolicy...)
+	}
+
+	return merged, nil
+}
+
+// loadAPITokenPolicy loads the policy of an API token, which is capped at the current
+// role of the user who created it, so that a token can't keep access that its creator
+// has lost. If the creator has a custom role, only admin tokens are allowed, and they
+// are given the creator's custom policy, since a custom policy can't be compared with
+// the token's role.
+func (b *BasicPolicyDocumentLoader) loadAPITokenPolicy(
+	apiToken *models.APIToken,
+) ([]*types.PolicyDocument, apierrors.RequestError) {
+	if getPolicyForRoleKind(apiToken.Role) == nil {
+		return nil, apierrors.NewErrForbidden(
+			fmt.Errorf("%s role not supported for api token %d, project %d", string(apiToken.Role), apiToken.ID, apiToken.ProjectID),
+		)
+	}
+
+	creatorRole, err := b.projRepo.ReadProjectRole(apiToken.ProjectID, apiToken.CreatedByUserID)
+
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apierrors.NewErrForbidden(
+			fmt.Errorf("creator %d of api token %d is no longer a collaborator in project %d", apiToken.CreatedByUserID, apiToken.ID, apiToken.ProjectID),
+		)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if creatorRole.Kind == types.RoleCustom {
+		if apiToken.Role != types.RoleAdmin {
+			return nil, apierrors.NewErrForbidden(
+				fmt.Errorf("%s role of api token %d cannot be capped at the custom role of its creator %d", string(apiToken.Role), apiToken.ID, apiToken.CreatedByUserID),
+			)
+		}
+
+		policy, reqErr := b.loadRolePolicy(apiToken.ProjectID, creatorRole.Kind, creatorRole.CustomRoleID)
+
+		if reqErr != nil {
+			return nil, reqErr
+		} else if policy == nil {
+			return nil, apierrors.NewErrForbidden(
+				fmt.Errorf("custom role not supported for creator %d of api token %d", apiToken.CreatedByUserID, apiToken.ID),
+			)
+		}
+
+		return policy, nil
+	}
+
+	kind := apiToken.Role
+
+	if roleKindRank(creatorRole.Kind) < roleKindRank(kind) {
+		kind = creatorRole.Kind
+	}
+
+	if policy := getPolicyForRoleKind(kind); policy != nil {
+		return policy, nil
+	}
+
+	return nil, apierrors.NewErrForbidden(
+		fmt.Errorf("%s role not supported for creator %d of api token %d", string(creatorRole.Kind), apiToken.CreatedByUserID, apiToken.ID),
+	)
+}
+
+// roleKindRank orders the built-in role kinds by their access, and returns 0 for
+// other role kinds
+func roleKindRank(kind types.RoleKind) int {
+	switch kind {
 	case types.RoleAdmin:
-		return AdminPolicy, nil
+		return 3
 	case types.RoleDeveloper:
-		return DeveloperPolicy, nil
+		return 2
 	case types.RoleViewer:
-		return ViewerPolicy, nil
+		return 1
 	default:
+		return 0
+	}
+}
+
+// loadRolePolicy returns the policy of a role kind, or nil if the role kind is not
+// supported
+func (b *BasicPolicyDocumentLoader) loadRolePolicy(
+	projectID uint,
+	kind types.RoleKind,
+	customRoleID uint,
+) ([]*types.PolicyDocument, apierrors.RequestError) {
+	if kind == types.RoleCustom && customRoleID != 0 {
+		return b.loadCustomRolePolicy(projectID, customRoleID)
+	}
+
+	// load role based on role kind
+	return getPolicyForRoleKind(kind), nil
+}
+
+func (b *BasicPolicyDocumentLoader) loadCustomRolePolicy(
+	projectID, customRoleID uint,
+) ([]*types.PolicyDocument, apierrors.RequestError) {
+	customRole, err := b.customRoleRepo.ReadCustomRole(projectID, customRoleID)
+
+	if err != nil && err == gorm.ErrRecordNotFound {
 		return nil, apierrors.NewErrForbidden(
-			fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
+			fmt.Errorf("custom role %d does not exist in project %d", customRoleID, projectID),
 		)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	policy, err := customRole.GetPolicy()
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return policy, nil
+}
+
+func getPolicyForRoleKind(kind types.RoleKind) []*types.PolicyDocument {
+	switch kind {
+	case types.RoleAdmin:
+		return AdminPolicy
+	case types.RoleDeveloper:
+		return DeveloperPolicy
+	case types.RoleViewer:
+		return ViewerPolicy
+	default:
+		return nil
 	}
 }
 
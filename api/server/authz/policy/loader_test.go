@@ -53,7 +53,7 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 	for _, basicTest := range basicLoaderTests {
 		// use the in-memory project repo
 		projRepo := test.NewProjectRepository(true)
-		loader := policy.NewBasicPolicyDocumentLoader(projRepo)
+		loader := policy.NewBasicPolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true), test.NewTeamRepository(true))
 
 		project := &models.Project{
 			Name: "test-project",
@@ -79,7 +79,10 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 			t.Fatalf("%v", err)
 		}
 
-		docs, reqErr := loader.LoadPolicyDocuments(1, 1)
+		docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+			UserID:    1,
+			ProjectID: 1,
+		})
 
 		assert.Equal(
 			reqErr != nil,
@@ -123,7 +126,7 @@ func TestErrorForbiddenInvalidRole(t *testing.T) {
 
 	// use the in-memory project repo
 	projRepo := test.NewProjectRepository(true)
-	loader := policy.NewBasicPolicyDocumentLoader(projRepo)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true), test.NewTeamRepository(true))
 
 	project := &models.Project{
 		Name: "test-project",
@@ -149,7 +152,10 @@ func TestErrorForbiddenInvalidRole(t *testing.T) {
 		t.Fatalf("%v", err)
 	}
 
-	_, reqErr := loader.LoadPolicyDocuments(2, 1)
+	_, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    2,
+		ProjectID: 1,
+	})
 
 	if reqErr == nil {
 		t.Fatalf("Expected forbidden error for invalid project role")
@@ -174,9 +180,12 @@ func TestErrorCannotQuery(t *testing.T) {
 
 	// use the in-memory project repo
 	projRepo := test.NewProjectRepository(false)
-	loader := policy.NewBasicPolicyDocumentLoader(projRepo)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true), test.NewTeamRepository(true))
 
-	_, reqErr := loader.LoadPolicyDocuments(2, 1)
+	_, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    2,
+		ProjectID: 1,
+	})
 
 	if reqErr == nil {
 		t.Fatalf("Expected internal error for failing to query")
@@ -189,3 +198,357 @@ func TestErrorCannotQuery(t *testing.T) {
 		"status is not status internal",
 	)
 }
+
+func TestLoadPolicyFromAPIToken(t *testing.T) {
+	assert := assert.New(t)
+
+	// the user's own role should not be used when the request uses an API token
+	projRepo := test.NewProjectRepository(true)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true), test.NewTeamRepository(true))
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    1,
+			ProjectID: project.ID,
+			Kind:      types.RoleAdmin,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	apiToken := &models.APIToken{
+		ProjectID:       1,
+		CreatedByUserID: 1,
+		Role:            types.RoleViewer,
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: 1,
+		APIToken:  apiToken,
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(policy.ViewerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// tokens for a different project should be rejected
+	_, reqErr = loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: 2,
+		APIToken:  apiToken,
+	})
+
+	if reqErr == nil {
+		t.Fatalf("Expected forbidden error for token in different project")
+	}
+
+	assert.Equal(
+		http.StatusForbidden,
+		reqErr.GetStatusCode(),
+		"status is not status forbidden",
+	)
+}
+
+func TestLoadPolicyFromAPITokenCappedAtCreator(t *testing.T) {
+	assert := assert.New(t)
+
+	projRepo := test.NewProjectRepository(true)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true), test.NewTeamRepository(true))
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// another admin stays in the project after the creator is removed
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    2,
+			ProjectID: project.ID,
+			Kind:      types.RoleAdmin,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	creatorRole, err := projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    1,
+			ProjectID: project.ID,
+			Kind:      types.RoleAdmin,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	opts := &policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: project.ID,
+		APIToken: &models.APIToken{
+			ProjectID:       project.ID,
+			CreatedByUserID: 1,
+			Role:            types.RoleAdmin,
+		},
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(opts)
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(policy.AdminPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// the token should lose access when its creator is demoted
+	creatorRole.Kind = types.RoleViewer
+
+	if _, err := projRepo.UpdateProjectRole(project.ID, creatorRole); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	docs, reqErr = loader.LoadPolicyDocuments(opts)
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(policy.ViewerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// the token should be rejected when its creator is removed from the project
+	if _, err := projRepo.DeleteProjectRole(project.ID, 1); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, reqErr = loader.LoadPolicyDocuments(opts)
+
+	if reqErr == nil {
+		t.Fatalf("Expected forbidden error for token of a removed creator")
+	}
+
+	assert.Equal(
+		http.StatusForbidden,
+		reqErr.GetStatusCode(),
+		"status is not status forbidden",
+	)
+}
+
+func TestLoadPolicyFromCustomRole(t *testing.T) {
+	assert := assert.New(t)
+
+	projRepo := test.NewProjectRepository(true)
+	customRoleRepo := test.NewCustomRoleRepository(true)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, customRoleRepo, test.NewTeamRepository(true))
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	customRole := &models.CustomRole{
+		ProjectID: project.ID,
+		Name:      "cluster-viewer",
+	}
+
+	if err := customRole.SetPolicy(testCustomRolePolicy); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err := customRoleRepo.CreateCustomRole(customRole); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:       1,
+			ProjectID:    1,
+			Kind:         types.RoleCustom,
+			CustomRoleID: customRole.ID,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: 1,
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(testCustomRolePolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// a deleted custom role should not grant access
+	if _, err := customRoleRepo.DeleteCustomRole(customRole); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, reqErr = loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: 1,
+	})
+
+	if reqErr == nil {
+		t.Fatalf("Expected forbidden error for deleted custom role")
+	}
+
+	assert.Equal(
+		http.StatusForbidden,
+		reqErr.GetStatusCode(),
+		"status is not status forbidden",
+	)
+}
+
+func TestLoadPolicyFromTeams(t *testing.T) {
+	projRepo := test.NewProjectRepository(true)
+	customRoleRepo := test.NewCustomRoleRepository(true)
+	teamRepo := test.NewTeamRepository(true)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, customRoleRepo, teamRepo)
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	customRole := &models.CustomRole{
+		ProjectID: project.ID,
+		Name:      "cluster-viewer",
+	}
+
+	if err := customRole.SetPolicy(testCustomRolePolicy); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err := customRoleRepo.CreateCustomRole(customRole); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    1,
+			ProjectID: project.ID,
+			Kind:      types.RoleViewer,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	teams := []*models.Team{
+		{ProjectID: project.ID, Name: "developers", Kind: types.RoleDeveloper},
+		{ProjectID: project.ID, Name: "cluster-viewers", Kind: types.RoleCustom, CustomRoleID: customRole.ID},
+		{ProjectID: project.ID, Name: "admins", Kind: types.RoleAdmin},
+	}
+
+	for _, team := range teams {
+		if _, err := teamRepo.CreateTeam(team); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	// the user is not a member of the admins team
+	for _, team := range teams[:2] {
+		if _, err := teamRepo.AddTeamMember(team, 1); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: project.ID,
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	expDocs := make([]*types.PolicyDocument, 0)
+	expDocs = append(expDocs, policy.ViewerPolicy...)
+	expDocs = append(expDocs, policy.DeveloperPolicy...)
+	expDocs = append(expDocs, testCustomRolePolicy...)
+
+	if diff := deep.Equal(expDocs, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// team policies should not be merged into the policy of an API token
+	docs, reqErr = loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		UserID:    1,
+		ProjectID: project.ID,
+		APIToken: &models.APIToken{
+			ProjectID:       project.ID,
+			CreatedByUserID: 1,
+			Role:            types.RoleViewer,
+		},
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(policy.ViewerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+}
+
+var testCustomRolePolicy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadWriteVerbGroup(),
+				Resources: []types.NameOrUInt{
+					{
+						UInt: 1,
+					},
+				},
+			},
+		},
+	},
+}
@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"fmt"
+
 	"github.com/porter-dev/porter/api/types"
 )
 
@@ -10,6 +12,15 @@ func HasScopeAccess(
 	policy []*types.PolicyDocument,
 	reqScopes map[types.PermissionScope]*types.RequestAction,
 ) bool {
+	return FindMatchingPolicyDocument(policy, reqScopes) != nil
+}
+
+// FindMatchingPolicyDocument returns the first document in a `policy` which permits
+// the action, or nil if the policy does not permit the action.
+func FindMatchingPolicyDocument(
+	policy []*types.PolicyDocument,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) *types.PolicyDocument {
 	// iterate through policy documents until a match is found
 	for _, policyDoc := range policy {
 		// check that policy document is valid for current API server
@@ -26,10 +37,13 @@ func HasScopeAccess(
 			continue
 		}
 
+		listedScope := getListedScope(reqScopes)
+
 		for matchScope, matchDoc := range matchDocs {
 			// for the matching scope, make sure it matches the allowed resources if the
-			// resource list is explicitly set
-			if len(matchDoc.Resources) > 0 && reqScopes[matchScope].Verb != types.APIVerbList {
+			// resource list is explicitly set. The scope being listed by a list request is
+			// skipped, since the handler filters the listed resources by their grants.
+			if len(matchDoc.Resources) > 0 && matchScope != listedScope {
 				if !isResourceAllowed(matchDoc, reqScopes[matchScope].Resource) {
 					isValid = false
 				}
@@ -42,11 +56,181 @@ func HasScopeAccess(
 		}
 
 		if isValid {
-			return true
+			return policyDoc
+		}
+	}
+
+	return nil
+}
+
+// HasResourceAccess checks that a policy allows reading each of the given resources,
+// which are children of the scopes in reqScopes. This is used by list endpoints to
+// filter the resources they return by the grants of the policy.
+func HasResourceAccess(
+	policy []*types.PolicyDocument,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+	resources map[types.PermissionScope]types.NameOrUInt,
+) bool {
+	return HasResourceVerbAccess(policy, reqScopes, resources, types.APIVerbGet)
+}
+
+// HasResourceVerbAccess checks that a policy allows the verb on each of the given
+// resources, which are children of the scopes in reqScopes. This is used by endpoints
+// which act on a resource that is not loaded into the request's scopes.
+func HasResourceVerbAccess(
+	policy []*types.PolicyDocument,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+	resources map[types.PermissionScope]types.NameOrUInt,
+	verb types.APIVerb,
+) bool {
+	getScopes := make(map[types.PermissionScope]*types.RequestAction)
+
+	for scope, action := range reqScopes {
+		// the project and settings scopes don't have resource grants, so the verb
+		// of the original request is kept
+		if scope == types.ProjectScope || scope == types.SettingsScope {
+			getScopes[scope] = action
+			continue
+		}
+
+		getScopes[scope] = &types.RequestAction{
+			Verb:     verb,
+			Resource: action.Resource,
+		}
+	}
+
+	for scope, resource := range resources {
+		getScopes[scope] = &types.RequestAction{
+			Verb:     verb,
+			Resource: resource,
+		}
+	}
+
+	return HasScopeAccess(policy, getScopes)
+}
+
+// ValidatePolicy checks that every policy document in a policy starts at the project
+// scope, only contains children that exist in the scope hierarchy, and only uses
+// known verbs. It is used to validate user-defined policies before they're stored.
+func ValidatePolicy(policy []*types.PolicyDocument) error {
+	if len(policy) == 0 {
+		return fmt.Errorf("policy must contain at least one policy document")
+	}
+
+	for i, policyDoc := range policy {
+		if policyDoc == nil {
+			return fmt.Errorf("policy document %d is empty", i)
+		}
+
+		if policyDoc.Scope != types.ProjectScope {
+			return fmt.Errorf("policy document %d must have scope %s", i, types.ProjectScope)
+		}
+
+		isValid, _ := populateAndVerifyPolicyDocument(
+			policyDoc,
+			types.ScopeHeirarchy,
+			types.ProjectScope,
+			types.ReadWriteVerbGroup(),
+			map[types.PermissionScope]*types.RequestAction{},
+			nil,
+		)
+
+		if !isValid {
+			return fmt.Errorf("policy document %d contains a scope which is not valid for its parent", i)
+		}
+
+		if err := validatePolicyDocumentContents(policyDoc); err != nil {
+			return fmt.Errorf("policy document %d is invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// scopes whose resources are identified by name, rather than by id
+var namedResourceScopes = map[types.PermissionScope]bool{
+	types.NamespaceScope: true,
+	types.ReleaseScope:   true,
+	types.OperationScope: true,
+}
+
+// validatePolicyDocumentContents checks that the verbs of the document and its
+// children are known, and that resource grants identify resources in the same way as
+// the API, i.e. by name or by id depending on the scope
+func validatePolicyDocumentContents(policyDoc *types.PolicyDocument) error {
+	for _, verb := range policyDoc.Verbs {
+		if !isVerbAllowed(&types.PolicyDocument{Verbs: types.ReadWriteVerbGroup()}, verb) {
+			return fmt.Errorf("unknown verb %s for scope %s", verb, policyDoc.Scope)
+		}
+	}
+
+	if len(policyDoc.Resources) > 0 && (policyDoc.Scope == types.ProjectScope || policyDoc.Scope == types.SettingsScope) {
+		return fmt.Errorf("resources cannot be granted for scope %s", policyDoc.Scope)
+	}
+
+	for _, resource := range policyDoc.Resources {
+		if namedResourceScopes[policyDoc.Scope] && (resource.Name == "" || resource.UInt != 0) {
+			return fmt.Errorf("resources for scope %s must be identified by name", policyDoc.Scope)
+		} else if !namedResourceScopes[policyDoc.Scope] && (resource.UInt == 0 || resource.Name != "") {
+			return fmt.Errorf("resources for scope %s must be identified by id", policyDoc.Scope)
+		}
+	}
+
+	for _, child := range policyDoc.Children {
+		if child == nil {
+			continue
+		}
+
+		if err := validatePolicyDocumentContents(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getListedScope returns the leaf scope of a list request if it doesn't identify a
+// resource, i.e. if the request lists the resources of that scope. The resource
+// grants of every parent scope are still checked, so a list request can't read the
+// children of a cluster or namespace which isn't granted.
+func getListedScope(reqScopes map[types.PermissionScope]*types.RequestAction) types.PermissionScope {
+	var leafScope types.PermissionScope
+	leafDepth := -1
+
+	for scope := range reqScopes {
+		if depth := getScopeDepth(types.ScopeHeirarchy, scope, 0); depth > leafDepth {
+			leafScope = scope
+			leafDepth = depth
+		}
+	}
+
+	if leafDepth < 0 {
+		return ""
+	}
+
+	leafAction := reqScopes[leafScope]
+
+	if leafAction.Verb != types.APIVerbList || leafAction.Resource != (types.NameOrUInt{}) {
+		return ""
+	}
+
+	return leafScope
+}
+
+// getScopeDepth returns the depth of a scope in the scope tree, or -1 if the scope is
+// not in the tree
+func getScopeDepth(tree types.ScopeTree, scope types.PermissionScope, depth int) int {
+	for currScope, subTree := range tree {
+		if currScope == scope {
+			return depth
+		}
+
+		if subDepth := getScopeDepth(subTree, scope, depth+1); subDepth >= 0 {
+			return subDepth
 		}
 	}
 
-	return false
+	return -1
 }
 
 func isResourceAllowed(
@@ -138,6 +138,73 @@ var hasScopeAccessTests = []testHasScopeAccess{
 		},
 		expRes: false,
 	},
+	{
+		description: "list request in a namespace which isn't granted",
+		policy:      testPolicyNamespaceSpecific,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					Name: "default",
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "list request in a granted namespace",
+		policy:      testPolicyNamespaceSpecific,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					Name: "abelanger",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "list request in a cluster which isn't granted",
+		policy:      testPolicyNamespaceSpecific,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 2,
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "list request for the namespaces of a granted cluster",
+		policy:      testPolicyNamespaceSpecific,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbList,
+			},
+		},
+		expRes: true,
+	},
 	{
 		description: "test invalid policy document",
 		policy:      testInvalidPolicyDocument,
@@ -179,6 +246,202 @@ func TestHasScopeAccess(t *testing.T) {
 	}
 }
 
+var validatePolicyTests = []struct {
+	description string
+	policy      []*types.PolicyDocument
+	expErr      bool
+}{
+	{
+		description: "built-in policies should be valid",
+		policy:      policy.DeveloperPolicy,
+	},
+	{
+		description: "resource-specific nested policy should be valid",
+		policy:      testPolicyNamespaceSpecific,
+	},
+	{
+		description: "empty policy should be invalid",
+		policy:      []*types.PolicyDocument{},
+		expErr:      true,
+	},
+	{
+		description: "policy not starting at project scope should be invalid",
+		policy:      testInvalidPolicyDocument,
+		expErr:      true,
+	},
+	{
+		description: "policy with misplaced child scope should be invalid",
+		policy:      testInvalidPolicyDocumentNested,
+		expErr:      true,
+	},
+	{
+		description: "policy with unknown verb should be invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope: types.ProjectScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.ClusterScope: {
+						Scope: types.ClusterScope,
+						Verbs: []types.APIVerb{"impersonate"},
+					},
+				},
+			},
+		},
+		expErr: true,
+	},
+	{
+		description: "policy granting a namespace by id should be invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope: types.ProjectScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.ClusterScope: {
+						Scope: types.ClusterScope,
+						Verbs: types.ReadVerbGroup(),
+						Children: map[types.PermissionScope]*types.PolicyDocument{
+							types.NamespaceScope: {
+								Scope:     types.NamespaceScope,
+								Verbs:     types.ReadVerbGroup(),
+								Resources: []types.NameOrUInt{{UInt: 1}},
+							},
+						},
+					},
+				},
+			},
+		},
+		expErr: true,
+	},
+	{
+		description: "policy granting a cluster by name should be invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope: types.ProjectScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.ClusterScope: {
+						Scope:     types.ClusterScope,
+						Verbs:     types.ReadVerbGroup(),
+						Resources: []types.NameOrUInt{{Name: "cluster"}},
+					},
+				},
+			},
+		},
+		expErr: true,
+	},
+	{
+		description: "policy granting resources at the project scope should be invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope:     types.ProjectScope,
+				Verbs:     types.ReadVerbGroup(),
+				Resources: []types.NameOrUInt{{UInt: 1}},
+			},
+		},
+		expErr: true,
+	},
+}
+
+func TestValidatePolicy(t *testing.T) {
+	for _, test := range validatePolicyTests {
+		err := policy.ValidatePolicy(test.policy)
+
+		if test.expErr && err == nil {
+			t.Errorf("[ %s ]: expected error, got nil", test.description)
+		} else if !test.expErr && err != nil {
+			t.Errorf("[ %s ]: expected no error, got %v", test.description, err)
+		}
+	}
+}
+
+var hasResourceAccessTests = []struct {
+	description string
+	resources   map[types.PermissionScope]types.NameOrUInt
+	expRes      bool
+}{
+	{
+		description: "granted namespace in cluster should be allowed",
+		resources: map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: "abelanger"},
+		},
+		expRes: true,
+	},
+	{
+		description: "namespace granted in another cluster should not be allowed",
+		resources: map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: "default"},
+		},
+		expRes: false,
+	},
+	{
+		description: "release in granted namespace should be allowed",
+		resources: map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: "abelanger"},
+			types.ReleaseScope:   {Name: "web"},
+		},
+		expRes: true,
+	},
+	{
+		description: "release in other namespace should not be allowed",
+		resources: map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: "kube-system"},
+			types.ReleaseScope:   {Name: "web"},
+		},
+		expRes: false,
+	},
+}
+
+func TestHasResourceAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	// a list request against cluster 500, which is allowed regardless of the
+	// namespace grants
+	reqScopes := map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb:     types.APIVerbGet,
+			Resource: types.NameOrUInt{UInt: 1},
+		},
+		types.ClusterScope: {
+			Verb:     types.APIVerbList,
+			Resource: types.NameOrUInt{UInt: 500},
+		},
+	}
+
+	for _, test := range hasResourceAccessTests {
+		res := policy.HasResourceAccess(testPolicyNamespaceSpecific, reqScopes, test.resources)
+
+		assert.Equal(test.expRes, res, test.description)
+	}
+}
+
+func TestHasResourceVerbAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	reqScopes := map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{UInt: 1},
+		},
+		types.ClusterScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{UInt: 500},
+		},
+		types.NamespaceScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{Name: "abelanger"},
+		},
+	}
+
+	resources := map[types.PermissionScope]types.NameOrUInt{
+		types.ReleaseScope: {Name: "web"},
+	}
+
+	// the namespace is only granted read access
+	assert.True(policy.HasResourceVerbAccess(testPolicyNamespaceSpecific, reqScopes, resources, types.APIVerbGet))
+	assert.False(policy.HasResourceVerbAccess(testPolicyNamespaceSpecific, reqScopes, resources, types.APIVerbUpdate))
+}
+
 func BenchmarkSimpleHasScopeAccess(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		res := policy.HasScopeAccess(
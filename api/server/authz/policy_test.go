@@ -233,6 +233,55 @@ func TestPolicyMiddlewareFailBadParam(t *testing.T) {
 	})
 }
 
+func TestPolicyMiddlewareListUngrantedNamespace(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	// a list endpoint in a namespace, such as the pods of a job
+	mwFactory := authz.NewPolicyMiddleware(config, types.APIRequestMetadata{
+		Verb:   types.APIVerbList,
+		Method: types.HTTPVerbGet,
+		Scopes: []types.PermissionScope{
+			types.ProjectScope,
+			types.ClusterScope,
+			types.NamespaceScope,
+		},
+	}, &namespaceDocLoader{})
+
+	user := apitest.CreateTestUser(t, config, true)
+
+	for namespace, expAllowed := range map[string]bool{
+		"staging":    true,
+		"production": false,
+	} {
+		next := &testHandler{}
+		handler := mwFactory.Middleware(next)
+
+		req, rr := apitest.GetRequestAndRecorder(
+			t,
+			string(types.HTTPVerbGet),
+			fmt.Sprintf("/api/projects/1/clusters/1/namespaces/%s/jobs/cron/pods", namespace),
+			nil,
+		)
+
+		req = apitest.WithURLParams(t, req, map[string]string{
+			"project_id": "1",
+			"cluster_id": "1",
+			"namespace":  namespace,
+		})
+
+		req = apitest.WithAuthenticatedUser(t, req, user)
+
+		handler.ServeHTTP(rr, req)
+
+		if expAllowed {
+			assert.True(t, next.WasCalled, "next handler should have been called for namespace %s", namespace)
+		} else {
+			assert.False(t, next.WasCalled, "next handler should not have been called for namespace %s", namespace)
+			apitest.AssertResponseForbidden(t, rr)
+		}
+	}
+}
+
 func loadHandlers(
 	t *testing.T,
 	endpointMeta types.APIRequestMetadata,
@@ -240,7 +289,7 @@ func loadHandlers(
 	shouldLoaderLoadViewer bool,
 ) (*config.Config, http.Handler, *testHandler) {
 	config := apitest.LoadConfig(t)
-	var loader policy.PolicyDocumentLoader = policy.NewBasicPolicyDocumentLoader(config.Repo.Project())
+	var loader policy.PolicyDocumentLoader = policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole(), config.Repo.Team())
 
 	if shouldLoaderFail {
 		loader = &failingDocLoader{}
@@ -260,16 +309,41 @@ func loadHandlers(
 
 type failingDocLoader struct{}
 
-func (f *failingDocLoader) LoadPolicyDocuments(userID, projectID uint) ([]*types.PolicyDocument, apierrors.RequestError) {
+func (f *failingDocLoader) LoadPolicyDocuments(opts *policy.PolicyLoaderOpts) ([]*types.PolicyDocument, apierrors.RequestError) {
 	return nil, apierrors.NewErrInternal(fmt.Errorf("new error internal"))
 }
 
 type viewerDocLoader struct{}
 
-func (f *viewerDocLoader) LoadPolicyDocuments(userID, projectID uint) ([]*types.PolicyDocument, apierrors.RequestError) {
+func (f *viewerDocLoader) LoadPolicyDocuments(opts *policy.PolicyLoaderOpts) ([]*types.PolicyDocument, apierrors.RequestError) {
 	return policy.ViewerPolicy, nil
 }
 
+// namespaceDocLoader only grants access to the "staging" namespace
+type namespaceDocLoader struct{}
+
+func (f *namespaceDocLoader) LoadPolicyDocuments(opts *policy.PolicyLoaderOpts) ([]*types.PolicyDocument, apierrors.RequestError) {
+	return []*types.PolicyDocument{
+		{
+			Scope: types.ProjectScope,
+			Verbs: types.ReadWriteVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope: types.ClusterScope,
+					Verbs: types.ReadWriteVerbGroup(),
+					Children: map[types.PermissionScope]*types.PolicyDocument{
+						types.NamespaceScope: {
+							Scope:     types.NamespaceScope,
+							Verbs:     types.ReadWriteVerbGroup(),
+							Resources: []types.NameOrUInt{{Name: "staging"}},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 type testHandler struct {
 	WasCalled bool
 	ReqScopes map[types.PermissionScope]*types.RequestAction
@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	v1 "k8s.io/api/core/v1"
 )
 
 type ListNamespacesHandler struct {
@@ -44,6 +45,19 @@ func (c *ListNamespacesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// only return namespaces that the request's policy grants access to
+	allowedItems := make([]v1.Namespace, 0)
+
+	for _, namespace := range namespaceList.Items {
+		if authz.HasResourceAccess(r, map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: namespace.Name},
+		}) {
+			allowedItems = append(allowedItems, namespace)
+		}
+	}
+
+	namespaceList.Items = allowedItems
+
 	res := types.ListNamespacesResponse{
 		NamespaceList: namespaceList,
 	}
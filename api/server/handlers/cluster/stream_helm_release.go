@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -39,6 +40,17 @@ func (c *StreamHelmReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
+	// releases stored in the database are polled, since there are no secrets to watch
+	if cluster.GetHelmStorage() == types.HelmStorageSQL {
+		sqlDriver := helm.NewSQLDriver(c.Repo().HelmRelease(), cluster.ID, request.Namespace)
+
+		if err := sqlDriver.StreamReleases(request.Charts, request.Selectors, safeRW); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		}
+
+		return
+	}
+
 	agent, err := c.GetAgent(r, cluster, "")
 
 	if err != nil {
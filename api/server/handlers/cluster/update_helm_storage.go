@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+type UpdateHelmStorageHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUpdateHelmStorageHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateHelmStorageHandler {
+	return &UpdateHelmStorageHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP switches the storage of the Helm releases of a cluster, copying the
+// existing release revisions to the new storage
+func (c *UpdateHelmStorageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdateClusterHelmStorageRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if cluster.GetHelmStorage() == request.Storage {
+		c.WriteResult(w, r, &types.UpdateClusterHelmStorageResponse{
+			Cluster: cluster.ToClusterType(),
+		})
+
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	secretsDriver := func(namespace string) driver.Driver {
+		return driver.NewSecrets(agent.Clientset.CoreV1().Secrets(namespace))
+	}
+
+	sqlDriver := func(namespace string) driver.Driver {
+		return helm.NewSQLDriver(c.Repo().HelmRelease(), cluster.ID, namespace)
+	}
+
+	from, to := secretsDriver, sqlDriver
+
+	if request.Storage == types.HelmStorageSecret {
+		from, to = sqlDriver, secretsDriver
+	}
+
+	// the releases are migrated before the storage is switched, so that releases are
+	// never missing. Revisions left in the new storage by a previous switch are
+	// overwritten, since they may be out of date.
+	migrated, err := helm.MigrateReleases(from(""), to, true)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	cluster.HelmStorage = request.Storage
+
+	cluster, err = c.Repo().Cluster().UpdateCluster(cluster)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// revisions which were created in the previous storage while the storage was
+	// being switched are copied, without overwriting revisions which were since
+	// updated in the new storage
+	newlyMigrated, err := helm.MigrateReleases(from(""), to, false)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.UpdateClusterHelmStorageResponse{
+		Cluster:           cluster.ToClusterType(),
+		MigratedRevisions: migrated + newlyMigrated,
+	})
+}
@@ -0,0 +1,202 @@
+package deploy_request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeployRequestApproveHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewDeployRequestApproveHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeployRequestApproveHandler {
+	return &DeployRequestApproveHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *DeployRequestApproveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ReviewDeployRequestRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	dr, reqErr := getPendingDeployRequestFromURL(c.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if reqErr := checkReviewer(c.Config(), r, dr); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(proj.ID, dr.ClusterID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cluster %d of deploy request %d not found", dr.ClusterID, dr.ID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, dr.Namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the deploy request is marked as approved before the upgrade runs, and only if it
+	// is still pending, so that concurrent approvals can't run the upgrade twice
+	reviewed, err := c.Repo().DeployRequest().ReviewDeployRequest(dr, types.DeployRequestStatusApproved, user.ID, request.Comment)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !reviewed {
+		c.HandleAPIError(w, r, newErrAlreadyReviewed(dr))
+		return
+	}
+
+	helmRelease, reqErr := release.UpgradeFromDeployRequest(c.Config(), helmAgent, cluster, dr)
+
+	if reqErr != nil {
+		dr.Status = types.DeployRequestStatusFailed
+		dr.Error = reqErr.Error()
+
+		if _, err := c.Repo().DeployRequest().UpdateDeployRequest(dr); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// upgrades which are rolled out as a canary are watched once they are promoted
+	if helmRelease != nil {
+		release.WatchRevisionHealth(c.Config(), cluster, helmAgent, helmRelease)
+	}
+
+	c.WriteResult(w, r, (*types.ReviewDeployRequestResponse)(dr.ToDeployRequestType()))
+}
+
+// getPendingDeployRequestFromURL reads the deploy request in the project from the
+// deploy request id URL param, and checks that it has not been reviewed yet
+func getPendingDeployRequestFromURL(
+	config *config.Config,
+	r *http.Request,
+	projectID uint,
+) (*models.DeployRequest, apierrors.RequestError) {
+	drID, reqErr := requestutils.GetURLParamUint(r, types.URLParamDeployRequestID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	dr, err := config.Repo.DeployRequest().ReadDeployRequest(projectID, drID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if dr.Status != types.DeployRequestStatusPending {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("deploy request %d has already been reviewed: status is %s", dr.ID, dr.Status),
+			http.StatusBadRequest,
+		)
+	}
+
+	return dr, nil
+}
+
+// newErrAlreadyReviewed returns a conflict error for a deploy request which was
+// reviewed by another request after it was read
+func newErrAlreadyReviewed(dr *models.DeployRequest) apierrors.RequestError {
+	return apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("deploy request %d has already been reviewed", dr.ID),
+		http.StatusConflict,
+	)
+}
+
+// checkReviewer returns a forbidden error if the user of the request cannot review
+// the deploy request. Reviewers must be able to upgrade the release themselves, and
+// cannot review their own deploy requests.
+func checkReviewer(config *config.Config, r *http.Request, dr *models.DeployRequest) apierrors.RequestError {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if dr.RequestedByUserID == user.ID {
+		return apierrors.NewErrForbidden(
+			fmt.Errorf("user %d cannot review their own deploy request %d", user.ID, dr.ID),
+		)
+	}
+
+	opts := &policy.PolicyLoaderOpts{
+		ProjectID: dr.ProjectID,
+		UserID:    user.ID,
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		opts.APIToken = apiToken
+	}
+
+	policyDocs, reqErr := policy.NewBasicPolicyDocumentLoader(
+		config.Repo.Project(),
+		config.Repo.CustomRole(),
+		config.Repo.Team(),
+	).LoadPolicyDocuments(opts)
+
+	if reqErr != nil {
+		return reqErr
+	}
+
+	if !policy.HasScopeAccess(policyDocs, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope:   {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{UInt: dr.ProjectID}},
+		types.ClusterScope:   {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{UInt: dr.ClusterID}},
+		types.NamespaceScope: {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{Name: dr.Namespace}},
+		types.ReleaseScope:   {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{Name: dr.Name}},
+	}) {
+		return apierrors.NewErrForbidden(
+			fmt.Errorf("user %d cannot upgrade release %s in namespace %s, so cannot review deploy request %d", user.ID, dr.Name, dr.Namespace, dr.ID),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package deploy_request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ProtectedResourceCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewProtectedResourceCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ProtectedResourceCreateHandler {
+	return &ProtectedResourceCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ProtectedResourceCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateProtectedResourceRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if _, err := c.Repo().Cluster().ReadCluster(proj.ID, request.ClusterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("cluster %d does not exist in project %d", request.ClusterID, proj.ID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// a release which is already protected, directly or through its namespace, does
+	// not need to be protected again
+	existing, err := c.Repo().ProtectedResource().ReadProtectedResourceForRelease(
+		proj.ID, request.ClusterID, request.Namespace, request.ReleaseName,
+	)
+
+	if err == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("already protected by protected resource %d", existing.ID),
+			http.StatusBadRequest,
+		))
+
+		return
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	resource, err := c.Repo().ProtectedResource().CreateProtectedResource(&models.ProtectedResource{
+		ProjectID:       proj.ID,
+		CreatedByUserID: user.ID,
+		ClusterID:       request.ClusterID,
+		Namespace:       request.Namespace,
+		ReleaseName:     request.ReleaseName,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.CreateProtectedResourceResponse)(resource.ToProtectedResourceType()))
+}
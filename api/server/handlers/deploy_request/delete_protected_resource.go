@@ -0,0 +1,59 @@
+package deploy_request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ProtectedResourceDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewProtectedResourceDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ProtectedResourceDeleteHandler {
+	return &ProtectedResourceDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ProtectedResourceDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	resourceID, reqErr := requestutils.GetURLParamUint(r, types.URLParamProtectedResourceID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	resource, err := c.Repo().ProtectedResource().ReadProtectedResource(proj.ID, resourceID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// pending deploy requests are kept, and can still be approved or rejected
+	if _, err := c.Repo().ProtectedResource().DeleteProtectedResource(resource); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.DeleteProtectedResourceResponse)(resource.ToProtectedResourceType()))
+}
@@ -0,0 +1,253 @@
+package deploy_request_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/deploy_request"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+)
+
+// setupProtectedNamespace creates a project with an admin and a developer, and a
+// cluster with the "production" namespace protected
+func setupProtectedNamespace(t *testing.T) (*config.Config, *models.User, *models.User, *models.Project, *models.Cluster) {
+	config := apitest.LoadConfig(t)
+	admin := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, admin)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	developer, err := config.Repo.User().CreateUser(&models.User{
+		Email:         "developer@test.it",
+		EmailVerified: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:    developer.ID,
+			ProjectID: proj.ID,
+			Kind:      types.RoleDeveloper,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{
+		ProjectID: proj.ID,
+		Name:      "cluster-test",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/protected_resources", &types.CreateProtectedResourceRequest{
+		ClusterID: cluster.ID,
+		Namespace: "production",
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, admin)
+	req = apitest.WithProject(t, req, proj)
+
+	deploy_request.NewProtectedResourceCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("could not protect namespace: status %d", rr.Code)
+	}
+
+	return config, admin, developer, proj, cluster
+}
+
+func upgradeRelease(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	cluster *models.Cluster,
+	namespace string,
+) *types.UpgradeReleaseResponse {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/clusters/1/namespaces/"+namespace+"/releases/web/0/upgrade",
+		&types.UpgradeReleaseRequest{
+			Values: "replicaCount: 2",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	// the helm agent is never used to upgrade a protected release
+	helmAgent := helm.GetAgentTesting(&helm.Form{Namespace: namespace}, nil, logger.NewConsole(true), nil)
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, types.ReleaseScope, &helmrelease.Release{Name: "web", Namespace: namespace})
+	ctx = context.WithValue(ctx, authz.HelmAgentCtxKey, helmAgent)
+	req = req.WithContext(ctx)
+
+	release.NewUpgradeReleaseHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+
+	res := &types.UpgradeReleaseResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func reviewDeployRequest(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	handler http.Handler,
+	action string,
+) int {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/deploy_requests/1/"+action, &types.ReviewDeployRequestRequest{
+		Comment: "not during the freeze",
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamDeployRequestID): "1",
+	})
+
+	handler.ServeHTTP(rr, req)
+
+	return rr.Code
+}
+
+func TestUpgradeProtectedRelease(t *testing.T) {
+	assert := assert.New(t)
+
+	config, _, developer, proj, cluster := setupProtectedNamespace(t)
+
+	res := upgradeRelease(t, config, developer, proj, cluster, "production")
+
+	if assert.NotNil(res.DeployRequest) {
+		assert.Equal(types.DeployRequestStatusPending, res.DeployRequest.Status)
+		assert.Equal(types.DeployRequestSourceUpgrade, res.DeployRequest.Source)
+		assert.Equal(developer.ID, res.DeployRequest.RequestedByUserID)
+		assert.Equal("production", res.DeployRequest.Namespace)
+		assert.Equal("web", res.DeployRequest.Name)
+	}
+
+	dr, err := config.Repo.DeployRequest().ReadDeployRequest(proj.ID, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal("replicaCount: 2", string(dr.Values))
+}
+
+func TestRejectDeployRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	config, admin, developer, proj, cluster := setupProtectedNamespace(t)
+
+	upgradeRelease(t, config, developer, proj, cluster, "production")
+
+	handler := deploy_request.NewDeployRequestRejectHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	// deploy requests must be reviewed by a second person
+	assert.Equal(http.StatusForbidden, reviewDeployRequest(t, config, developer, proj, handler, "reject"))
+	assert.Equal(http.StatusOK, reviewDeployRequest(t, config, admin, proj, handler, "reject"))
+
+	dr, err := config.Repo.DeployRequest().ReadDeployRequest(proj.ID, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(types.DeployRequestStatusRejected, dr.Status)
+	assert.Equal(admin.ID, dr.ReviewedByUserID)
+	assert.Equal("not during the freeze", dr.ReviewComment)
+
+	// a reviewed deploy request cannot be approved
+	approveHandler := deploy_request.NewDeployRequestApproveHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	assert.Equal(http.StatusBadRequest, reviewDeployRequest(t, config, admin, proj, approveHandler, "approve"))
+}
+
+func TestApproveDeployRequestViewer(t *testing.T) {
+	config, admin, _, proj, cluster := setupProtectedNamespace(t)
+
+	upgradeRelease(t, config, admin, proj, cluster, "production")
+
+	viewer, err := config.Repo.User().CreateUser(&models.User{
+		Email:         "viewer@test.it",
+		EmailVerified: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:    viewer.ID,
+			ProjectID: proj.ID,
+			Kind:      types.RoleViewer,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// viewers cannot upgrade the release, so they cannot approve upgrades of it
+	handler := deploy_request.NewDeployRequestApproveHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	assert.Equal(t, http.StatusForbidden, reviewDeployRequest(t, config, viewer, proj, handler, "approve"))
+}
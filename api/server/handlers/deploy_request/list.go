@@ -0,0 +1,61 @@
+package deploy_request
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DeployRequestsListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDeployRequestsListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeployRequestsListHandler {
+	return &DeployRequestsListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *DeployRequestsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListDeployRequestsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	drs, err := c.Repo().DeployRequest().ListDeployRequestsByProjectID(proj.ID, request.Status)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListDeployRequestsResponse = make([]*types.DeployRequest, 0)
+
+	for _, dr := range drs {
+		// only list the deploy requests of releases which the user can access
+		if !authz.HasResourceAccess(r, map[types.PermissionScope]types.NameOrUInt{
+			types.ClusterScope:   {UInt: dr.ClusterID},
+			types.NamespaceScope: {Name: dr.Namespace},
+			types.ReleaseScope:   {Name: dr.Name},
+		}) {
+			continue
+		}
+
+		res = append(res, dr.ToDeployRequestType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,44 @@
+package deploy_request
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ProtectedResourcesListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewProtectedResourcesListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ProtectedResourcesListHandler {
+	return &ProtectedResourcesListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ProtectedResourcesListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	resources, err := c.Repo().ProtectedResource().ListProtectedResourcesByProjectID(proj.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListProtectedResourcesResponse = make([]*types.ProtectedResource, 0)
+
+	for _, resource := range resources {
+		res = append(res, resource.ToProtectedResourceType())
+	}
+
+	c.WriteResult(w, r, res)
+}
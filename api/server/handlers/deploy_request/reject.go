@@ -0,0 +1,61 @@
+package deploy_request
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DeployRequestRejectHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDeployRequestRejectHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeployRequestRejectHandler {
+	return &DeployRequestRejectHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *DeployRequestRejectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ReviewDeployRequestRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	dr, reqErr := getPendingDeployRequestFromURL(c.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if reqErr := checkReviewer(c.Config(), r, dr); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	reviewed, err := c.Repo().DeployRequest().ReviewDeployRequest(dr, types.DeployRequestStatusRejected, user.ID, request.Comment)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !reviewed {
+		c.HandleAPIError(w, r, newErrAlreadyReviewed(dr))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.ReviewDeployRequestResponse)(dr.ToDeployRequestType()))
+}
@@ -57,11 +57,18 @@ func (p *HelmRepoCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if err := validateProvenanceConfig(&request.HelmRepoProvenanceConfig); err != nil {
+		p.HandleAPIError(w, r, err)
+		return
+	}
+
 	hr := &models.HelmRepo{
 		Name:                   request.Name,
 		ProjectID:              proj.ID,
 		RepoURL:                request.URL,
 		BasicAuthIntegrationID: request.BasicIntegrationID,
+		VerifyProvenance:       request.VerifyProvenance,
+		Keyring:                []byte(request.Keyring),
 	}
 
 	// handle write to the database
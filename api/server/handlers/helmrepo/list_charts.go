@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	porterrepo "github.com/porter-dev/porter/internal/helm/repo"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -35,7 +36,19 @@ func (t *ChartListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var repoIndex *repo.IndexFile
 	var err error
 
-	if helmRepo.BasicAuthIntegrationID != 0 {
+	if loader.IsOCIRepo(helmRepo.RepoURL) {
+		// OCI helm repos can also use the credentials of a registry in the project
+		var client *loader.BasicAuthClient
+
+		client, err = porterrepo.GetOCICredentials(t.Repo(), proj.ID, helmRepo.RepoURL, t.Config().DOConf)
+
+		if err != nil {
+			t.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		repoIndex, err = loader.LoadRepoIndex(client, helmRepo.RepoURL)
+	} else if helmRepo.BasicAuthIntegrationID != 0 {
 		// read the basic integration id
 		basic, err := t.Repo().BasicIntegration().ReadBasicIntegration(proj.ID, helmRepo.BasicAuthIntegrationID)
 
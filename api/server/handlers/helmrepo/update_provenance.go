@@ -0,0 +1,78 @@
+package helmrepo
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type HelmRepoUpdateProvenanceHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewHelmRepoUpdateProvenanceHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *HelmRepoUpdateProvenanceHandler {
+	return &HelmRepoUpdateProvenanceHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *HelmRepoUpdateProvenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRepo, _ := r.Context().Value(types.HelmRepoScope).(*models.HelmRepo)
+
+	request := &types.UpdateHelmRepoProvenanceRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	conf := types.HelmRepoProvenanceConfig(*request)
+
+	if err := validateProvenanceConfig(&conf); err != nil {
+		c.HandleAPIError(w, r, err)
+		return
+	}
+
+	helmRepo.Keyring = []byte(conf.Keyring)
+	helmRepo.VerifyProvenance = conf.VerifyProvenance
+
+	helmRepo, err := c.Repo().HelmRepo().UpdateHelmRepo(helmRepo)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, helmRepo.ToHelmRepoType())
+}
+
+// validateProvenanceConfig checks that the keyring can be parsed, and that a keyring
+// is set if provenance verification is enabled
+func validateProvenanceConfig(conf *types.HelmRepoProvenanceConfig) apierrors.RequestError {
+	if conf.Keyring == "" {
+		if conf.VerifyProvenance {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("a keyring is required to verify the provenance of charts"),
+				http.StatusBadRequest,
+			)
+		}
+
+		return nil
+	}
+
+	if _, err := loader.ParseKeyring([]byte(conf.Keyring)); err != nil {
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
 )
 
 type ListReleasesHandler struct {
@@ -52,7 +53,17 @@ func (c *ListReleasesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var res types.ListReleasesResponse = releases
+	// only return releases that the request's policy grants access to
+	var res types.ListReleasesResponse = make([]*release.Release, 0)
+
+	for _, rel := range releases {
+		if authz.HasResourceAccess(r, map[types.PermissionScope]types.NameOrUInt{
+			types.NamespaceScope: {Name: rel.Namespace},
+			types.ReleaseScope:   {Name: rel.Name},
+		}) {
+			res = append(res, rel)
+		}
+	}
 
 	c.WriteResult(w, r, res)
 }
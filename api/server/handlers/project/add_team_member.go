@@ -0,0 +1,108 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type TeamMemberAddHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTeamMemberAddHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TeamMemberAddHandler {
+	return &TeamMemberAddHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TeamMemberAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	team, reqErr := getTeamFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.AddTeamMemberRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// only collaborators can join a team, so that removing a user from the project
+	// also removes the access granted by their teams
+	if _, err := p.Repo().Project().ReadProjectRole(proj.ID, request.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("user %d is not a collaborator in project %d", request.UserID, proj.ID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// service accounts authenticate with API tokens, which are not granted the
+	// policies of teams
+	if _, err := p.Repo().ServiceAccount().ReadServiceAccountByUserID(request.UserID); err == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("user %d is a service account and cannot join a team", request.UserID),
+			http.StatusBadRequest,
+		))
+
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	members, err := p.Repo().Team().ListTeamMembers(team)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, member := range members {
+		if member.UserID == request.UserID {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("user %d is already a member of team %s", request.UserID, team.Name),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	if _, err := p.Repo().Team().AddTeamMember(team, request.UserID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, reqErr := getTeamType(p.Config(), team)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, (*types.AddTeamMemberResponse)(res))
+}
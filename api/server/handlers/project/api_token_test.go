@@ -0,0 +1,101 @@
+package project_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndRevokeAPIToken(t *testing.T) {
+	assert := assert.New(t)
+
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/api_token",
+		&types.CreateAPITokenRequest{
+			Name: "ci",
+			Role: types.RoleDeveloper,
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := project.NewAPITokenCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Result().StatusCode)
+
+	created := &types.CreateAPITokenResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(created); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal("ci", created.Name)
+	assert.Equal(types.RoleDeveloper, created.Role)
+
+	// the returned token should reference the stored token
+	tok, err := token.GetTokenFromEncoded(created.Token, config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := config.Repo.APIToken().ReadAPIToken(proj.ID, tok.TokenID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(created.ID, stored.ID)
+
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/api_token/1/revoke", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamAPITokenID): "1",
+	})
+
+	revokeHandler := project.NewAPITokenRevokeHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	revokeHandler.ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Result().StatusCode)
+
+	stored, err = config.Repo.APIToken().ReadAPIToken(proj.ID, tok.TokenID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(stored.Revoked, "token should be revoked")
+}
@@ -0,0 +1,195 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ProjectCanIHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewProjectCanIHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ProjectCanIHandler {
+	return &ProjectCanIHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ProjectCanIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CanIRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	reqScopes, err := getCanIRequestScopes(proj.ID, request)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	policyDocLoader := policy.NewBasicPolicyDocumentLoader(
+		p.Config().Repo.Project(),
+		p.Config().Repo.CustomRole(),
+		p.Config().Repo.Team(),
+	)
+
+	callerOpts := &policy.PolicyLoaderOpts{
+		ProjectID: proj.ID,
+		UserID:    user.ID,
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		callerOpts.APIToken = apiToken
+	}
+
+	subjectOpts := callerOpts
+
+	if request.UserID != 0 || request.APITokenID != 0 {
+		// evaluating the policy of another user or token reveals their access, so this
+		// requires the same access as viewing the project's collaborators
+		callerPolicy, reqErr := policyDocLoader.LoadPolicyDocuments(callerOpts)
+
+		if reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		if !policy.HasScopeAccess(callerPolicy, map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope:  {Verb: types.APIVerbGet, Resource: types.NameOrUInt{UInt: proj.ID}},
+			types.SettingsScope: {Verb: types.APIVerbGet},
+		}) {
+			p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+				fmt.Errorf("user %d cannot evaluate the policy of other subjects in project %d", user.ID, proj.ID),
+			))
+
+			return
+		}
+
+		subjectOpts, err = p.getSubjectOpts(proj.ID, request)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("api token %d not found in project %d", request.APITokenID, proj.ID),
+					http.StatusNotFound,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		// revoked and expired tokens cannot perform any action
+		if subjectOpts.APIToken != nil && (subjectOpts.APIToken.Revoked || subjectOpts.APIToken.IsExpired()) {
+			p.WriteResult(w, r, &types.CanIResponse{})
+			return
+		}
+	}
+
+	subjectPolicy, reqErr := policyDocLoader.LoadPolicyDocuments(subjectOpts)
+
+	if reqErr != nil {
+		// a forbidden error means the subject has no role in the project, so it cannot
+		// perform any action
+		if reqErr.GetStatusCode() == http.StatusForbidden {
+			p.WriteResult(w, r, &types.CanIResponse{})
+			return
+		}
+
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	res := &types.CanIResponse{
+		MatchingPolicyDocument: policy.FindMatchingPolicyDocument(subjectPolicy, reqScopes),
+	}
+
+	res.Allowed = res.MatchingPolicyDocument != nil
+
+	p.WriteResult(w, r, res)
+}
+
+func (p *ProjectCanIHandler) getSubjectOpts(projectID uint, request *types.CanIRequest) (*policy.PolicyLoaderOpts, error) {
+	opts := &policy.PolicyLoaderOpts{
+		ProjectID: projectID,
+		UserID:    request.UserID,
+	}
+
+	if request.APITokenID != 0 {
+		apiToken, err := p.Repo().APIToken().ReadAPITokenByID(projectID, request.APITokenID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.APIToken = apiToken
+	}
+
+	return opts, nil
+}
+
+// getCanIRequestScopes converts the scope path of a can-i request to the scopes that
+// the policy middleware would compute for a request against that path
+func getCanIRequestScopes(
+	projectID uint,
+	request *types.CanIRequest,
+) (map[types.PermissionScope]*types.RequestAction, error) {
+	if request.UserID != 0 && request.APITokenID != 0 {
+		return nil, fmt.Errorf("only one of user_id and api_token_id can be set")
+	}
+
+	if request.Settings && (request.ClusterID != 0 || request.Namespace != "" || request.Release != "") {
+		return nil, fmt.Errorf("settings cannot be combined with a cluster, namespace or release")
+	}
+
+	if request.Namespace != "" && request.ClusterID == 0 {
+		return nil, fmt.Errorf("a namespace requires a cluster_id")
+	}
+
+	if request.Release != "" && request.Namespace == "" {
+		return nil, fmt.Errorf("a release requires a namespace")
+	}
+
+	res := map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {Verb: request.Verb, Resource: types.NameOrUInt{UInt: projectID}},
+	}
+
+	if request.Settings {
+		res[types.SettingsScope] = &types.RequestAction{Verb: request.Verb}
+	}
+
+	if request.ClusterID != 0 {
+		res[types.ClusterScope] = &types.RequestAction{Verb: request.Verb, Resource: types.NameOrUInt{UInt: request.ClusterID}}
+	}
+
+	if request.Namespace != "" {
+		res[types.NamespaceScope] = &types.RequestAction{Verb: request.Verb, Resource: types.NameOrUInt{Name: request.Namespace}}
+	}
+
+	if request.Release != "" {
+		res[types.ReleaseScope] = &types.RequestAction{Verb: request.Verb, Resource: types.NameOrUInt{Name: request.Release}}
+	}
+
+	return res, nil
+}
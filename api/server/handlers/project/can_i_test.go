@@ -0,0 +1,108 @@
+package project_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCanI(t *testing.T, config *config.Config, user *models.User, proj *models.Project, query string) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1/policy/can_i?"+query, nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := project.NewProjectCanIHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func setupCanIProject(t *testing.T) (*config.Config, *models.User, *models.User, *models.Project) {
+	config := apitest.LoadConfig(t)
+	admin := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, admin)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewer, err := config.Repo.User().CreateUser(&models.User{
+		Email:         "viewer@test.it",
+		EmailVerified: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:    viewer.ID,
+			ProjectID: proj.ID,
+			Kind:      types.RoleViewer,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config, admin, viewer, proj
+}
+
+func TestCanI(t *testing.T) {
+	config, admin, _, proj := setupCanIProject(t)
+
+	rr := runCanI(t, config, admin, proj, "verb=update&cluster_id=1&namespace=default&release=web")
+
+	apitest.AssertResponseExpected(t, rr, &types.CanIResponse{
+		Allowed:                true,
+		MatchingPolicyDocument: policy.AdminPolicy[0],
+	}, &types.CanIResponse{})
+}
+
+func TestCanIOtherUser(t *testing.T) {
+	config, admin, viewer, proj := setupCanIProject(t)
+
+	rr := runCanI(t, config, admin, proj, "verb=update&cluster_id=1&user_id=2")
+
+	apitest.AssertResponseExpected(t, rr, &types.CanIResponse{Allowed: false}, &types.CanIResponse{})
+
+	rr = runCanI(t, config, admin, proj, "verb=list&cluster_id=1&user_id=2")
+
+	apitest.AssertResponseExpected(t, rr, &types.CanIResponse{
+		Allowed:                true,
+		MatchingPolicyDocument: policy.ViewerPolicy[0],
+	}, &types.CanIResponse{})
+
+	// viewers cannot read the project settings, so they can't evaluate the policy of
+	// other users
+	rr = runCanI(t, config, viewer, proj, "verb=get&user_id=1")
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCanIInvalidPath(t *testing.T) {
+	config, admin, _, proj := setupCanIProject(t)
+
+	rr := runCanI(t, config, admin, proj, "verb=get&namespace=default")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,117 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CollaboratorSessionsListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCollaboratorSessionsListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CollaboratorSessionsListHandler {
+	return &CollaboratorSessionsListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *CollaboratorSessionsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, reqErr := getCollaboratorUserID(p.Config(), r)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	sessions, err := p.Repo().Session().ListSessionsByUserID(userID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	currentKey := authn.GetCurrentSessionKey(r, p.Config())
+
+	var res types.ListSessionsResponse = make([]*types.Session, 0)
+
+	for _, session := range sessions {
+		res = append(res, session.ToSessionType(currentKey))
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+type CollaboratorSessionsRevokeHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCollaboratorSessionsRevokeHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CollaboratorSessionsRevokeHandler {
+	return &CollaboratorSessionsRevokeHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP revokes all sessions of a collaborator, except for the session of the
+// request. Sessions are not scoped to a project, so a project admin logs the
+// collaborator out of the dashboard everywhere, including in their other projects.
+func (p *CollaboratorSessionsRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, reqErr := getCollaboratorUserID(p.Config(), r)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	count, err := authn.RevokeUserSessions(p.Config(), userID, authn.GetCurrentSessionKey(r, p.Config()))
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.RevokeSessionsResponse{
+		NumRevoked: count,
+	})
+}
+
+// getCollaboratorUserID reads the user id from the URL, and checks that the user is
+// a collaborator in the project
+func getCollaboratorUserID(config *config.Config, r *http.Request) (uint, apierrors.RequestError) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	userID, reqErr := requestutils.GetURLParamUint(r, types.URLParamUserID)
+
+	if reqErr != nil {
+		return 0, reqErr
+	}
+
+	if _, err := config.Repo.Project().ReadProjectRole(proj.ID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("user %d is not a collaborator in project %d", userID, proj.ID),
+				http.StatusNotFound,
+			)
+		}
+
+		return 0, apierrors.NewErrInternal(err)
+	}
+
+	return userID, nil
+}
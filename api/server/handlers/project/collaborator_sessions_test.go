@@ -0,0 +1,160 @@
+package project_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeCollaboratorSessions(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apitest.AuthenticateUserWithCookie(t, config, user, false)
+	apitest.AuthenticateUserWithCookie(t, config, user, false)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/collaborators/1/sessions", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamUserID): "1",
+	})
+
+	handler := project.NewCollaboratorSessionsRevokeHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseExpected(t, rr, &types.RevokeSessionsResponse{NumRevoked: 2}, &types.RevokeSessionsResponse{})
+
+	sessions, err := config.Repo.Session().ListSessionsByUserID(user.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, sessions, 0, "all sessions should be revoked")
+}
+
+func TestRevokeCollaboratorSessionsInOtherProjects(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherUser, err := config.Repo.User().CreateUser(&models.User{
+		Email:    "other@example.com",
+		Password: "hello",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the collaborator is a developer in the project, and the admin of another project
+	_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:    otherUser.ID,
+			ProjectID: proj.ID,
+			Kind:      types.RoleDeveloper,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "other-project",
+	}, otherUser)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apitest.AuthenticateUserWithCookie(t, config, otherUser, false)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/collaborators/2/sessions", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamUserID): "2",
+	})
+
+	handler := project.NewCollaboratorSessionsRevokeHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseExpected(t, rr, &types.RevokeSessionsResponse{NumRevoked: 1}, &types.RevokeSessionsResponse{})
+
+	// sessions are not scoped to a project, so the collaborator is logged out of
+	// their other project as well
+	sessions, err := config.Repo.Session().ListSessionsByUserID(otherUser.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, sessions, 0, "all sessions of the collaborator should be revoked")
+}
+
+func TestRevokeNonCollaboratorSessions(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherUser, err := config.Repo.User().CreateUser(&models.User{
+		Email:    "other@example.com",
+		Password: "hello",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apitest.AuthenticateUserWithCookie(t, config, otherUser, false)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/collaborators/2/sessions", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamUserID): "2",
+	})
+
+	handler := project.NewCollaboratorSessionsRevokeHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code, "users outside of the project should return 404")
+
+	sessions, err := config.Repo.Session().ListSessionsByUserID(otherUser.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, sessions, 1, "sessions of users outside of the project should not be revoked")
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CustomRoleCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCustomRoleCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CustomRoleCreateHandler {
+	return &CustomRoleCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CustomRoleCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateCustomRoleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if reqErr := checkCustomRoleName(p.Config(), proj.ID, 0, request.Name); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := policy.ValidatePolicy(request.Policy); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	customRole := &models.CustomRole{
+		ProjectID: proj.ID,
+		Name:      request.Name,
+	}
+
+	if err := customRole.SetPolicy(request.Policy); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	customRole, err := p.Repo().CustomRole().CreateCustomRole(customRole)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := customRole.ToCustomRoleType()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, (*types.CreateCustomRoleResponse)(res))
+}
+
+// checkCustomRoleName returns a bad request error if the name is already used by a
+// custom role in the project other than the role with id currID, or by a built-in role
+func checkCustomRoleName(config *config.Config, projectID, currID uint, name string) apierrors.RequestError {
+	switch types.RoleKind(name) {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer, types.RoleCustom:
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("%s is a reserved role name", name),
+			http.StatusBadRequest,
+		)
+	}
+
+	existing, err := config.Repo.CustomRole().ReadCustomRoleByName(projectID, name)
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return apierrors.NewErrInternal(err)
+	} else if err == nil && existing.ID != currID {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a custom role named %s already exists", name),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// service account names are used in the email of the service account's user, so
+// they are restricted to lowercase alphanumeric characters and dashes
+var serviceAccountNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+type ServiceAccountCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewServiceAccountCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ServiceAccountCreateHandler {
+	return &ServiceAccountCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ServiceAccountCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateServiceAccountRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !serviceAccountNameRegex.MatchString(request.Name) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("service account name must consist of lowercase alphanumeric characters or '-'"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if _, err := p.Repo().ServiceAccount().ReadServiceAccountByName(proj.ID, request.Name); err == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a service account named %s already exists in this project", request.Name),
+			http.StatusBadRequest,
+		))
+
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	customRoleID, reqErr := getRoleCustomRoleID(p.Config(), proj.ID, request.Kind, request.CustomRoleID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// the service account's user has no credentials, so it can only authenticate
+	// with the API tokens issued for the service account
+	saUser, err := p.Repo().User().CreateUser(&models.User{
+		Email:          models.GetServiceAccountEmail(proj.ID, request.Name),
+		EmailVerified:  true,
+		ServiceAccount: true,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	role, err := p.Repo().Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:       saUser.ID,
+			ProjectID:    proj.ID,
+			Kind:         request.Kind,
+			CustomRoleID: customRoleID,
+		},
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sa, err := p.Repo().ServiceAccount().CreateServiceAccount(&models.ServiceAccount{
+		ProjectID:       proj.ID,
+		CreatedByUserID: user.ID,
+		Name:            request.Name,
+		UserID:          saUser.ID,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := types.CreateServiceAccountResponse(*sa.ToServiceAccountType(role))
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,101 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ServiceAccountAPITokenCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewServiceAccountAPITokenCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ServiceAccountAPITokenCreateHandler {
+	return &ServiceAccountAPITokenCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ServiceAccountAPITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sa, reqErr := getServiceAccountFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.CreateServiceAccountAPITokenRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.ExpiresAt != nil && request.ExpiresAt.Before(time.Now()) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("expiry must be in the future"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	uid, err := encryption.GenerateRandomBytes(16)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	apiToken, err := p.Repo().APIToken().CreateAPIToken(&models.APIToken{
+		UniqueID:         uid,
+		ProjectID:        proj.ID,
+		CreatedByUserID:  user.ID,
+		Name:             request.Name,
+		ServiceAccountID: sa.ID,
+		Expiry:           request.ExpiresAt,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the token is issued by the service account's user rather than the user who
+	// created it, so that it keeps working if that user leaves the project
+	jwt, err := token.GetStoredTokenForAPI(sa.UserID, proj.ID, apiToken.UniqueID, apiToken.Expiry)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	encoded, err := jwt.EncodeToken(p.Config().TokenConf)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.CreateAPITokenResponse{
+		APITokenMeta: apiToken.ToAPITokenMetaType(),
+		Token:        encoded,
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,113 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type TeamCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTeamCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TeamCreateHandler {
+	return &TeamCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TeamCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateTeamRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if reqErr := checkTeamName(p.Config(), proj.ID, 0, request.Name); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	customRoleID, reqErr := getRoleCustomRoleID(p.Config(), proj.ID, request.Kind, request.CustomRoleID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	team, err := p.Repo().Team().CreateTeam(&models.Team{
+		ProjectID:    proj.ID,
+		Name:         request.Name,
+		Kind:         request.Kind,
+		CustomRoleID: customRoleID,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, reqErr := getTeamType(p.Config(), team)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, (*types.CreateTeamResponse)(res))
+}
+
+// checkTeamName returns a bad request error if the name is already used by a team
+// in the project other than the team with id currID
+func checkTeamName(config *config.Config, projectID, currID uint, name string) apierrors.RequestError {
+	existing, err := config.Repo.Team().ReadTeamByName(projectID, name)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierrors.NewErrInternal(err)
+	} else if err == nil && existing.ID != currID {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a team named %s already exists", name),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
+
+// getTeamType converts a team to its external type, along with the emails of its
+// members
+func getTeamType(config *config.Config, team *models.Team) (*types.Team, apierrors.RequestError) {
+	members, err := config.Repo.Team().ListTeamMembers(team)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	users := make(map[uint]*models.User)
+
+	for _, member := range members {
+		user, err := config.Repo.User().ReadUser(member.UserID)
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrInternal(err)
+		} else if err == nil {
+			users[user.ID] = user
+		}
+	}
+
+	return team.ToTeamType(members, users), nil
+}
@@ -0,0 +1,219 @@
+package project_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClusterViewerPolicy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.SettingsScope: {
+				Scope: types.SettingsScope,
+				Verbs: []types.APIVerb{},
+			},
+		},
+	},
+}
+
+func TestCreateCustomRole(t *testing.T) {
+	config, user, proj := loadCustomRoleConfig(t)
+
+	rr := createTestCustomRole(t, config, user, proj, &types.CreateCustomRoleRequest{
+		Name:   "cluster-viewer",
+		Policy: testClusterViewerPolicy,
+	})
+
+	apitest.AssertResponseExpected(t, rr, &types.CreateCustomRoleResponse{
+		ID:        1,
+		ProjectID: proj.ID,
+		Name:      "cluster-viewer",
+		Policy:    testClusterViewerPolicy,
+	}, &types.CreateCustomRoleResponse{})
+
+	// names must be unique within a project
+	rr = createTestCustomRole(t, config, user, proj, &types.CreateCustomRoleRequest{
+		Name:   "cluster-viewer",
+		Policy: testClusterViewerPolicy,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "duplicate names should be rejected")
+
+	// names of built-in roles are reserved
+	rr = createTestCustomRole(t, config, user, proj, &types.CreateCustomRoleRequest{
+		Name:   "admin",
+		Policy: testClusterViewerPolicy,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "reserved names should be rejected")
+}
+
+func TestCreateCustomRoleInvalidPolicy(t *testing.T) {
+	config, user, proj := loadCustomRoleConfig(t)
+
+	rr := createTestCustomRole(t, config, user, proj, &types.CreateCustomRoleRequest{
+		Name: "invalid",
+		Policy: []*types.PolicyDocument{
+			{
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+			},
+		},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "invalid policies should be rejected")
+}
+
+func TestAssignAndDeleteCustomRole(t *testing.T) {
+	config, user, proj := loadCustomRoleConfig(t)
+
+	createTestCustomRole(t, config, user, proj, &types.CreateCustomRoleRequest{
+		Name:   "cluster-viewer",
+		Policy: testClusterViewerPolicy,
+	})
+
+	// assigning a custom role which doesn't exist should fail
+	rr := updateTestRole(t, config, user, proj, &types.UpdateRoleRequest{
+		UserID:       user.ID,
+		Kind:         string(types.RoleCustom),
+		CustomRoleID: 2,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "nonexistent custom role should be rejected")
+
+	rr = updateTestRole(t, config, user, proj, &types.UpdateRoleRequest{
+		UserID:       user.ID,
+		Kind:         string(types.RoleCustom),
+		CustomRoleID: 1,
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	role, err := config.Repo.Project().ReadProjectRole(proj.ID, user.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, types.RoleCustom, role.Kind)
+	assert.Equal(t, uint(1), role.CustomRoleID)
+
+	// the role can't be deleted while it is assigned
+	rr = deleteTestCustomRole(t, config, user, proj, 1)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "assigned custom roles should not be deleted")
+
+	updateTestRole(t, config, user, proj, &types.UpdateRoleRequest{
+		UserID: user.ID,
+		Kind:   string(types.RoleAdmin),
+	})
+
+	rr = deleteTestCustomRole(t, config, user, proj, 1)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	roles, err := config.Repo.CustomRole().ListCustomRolesByProjectID(proj.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, roles, 0)
+}
+
+func loadCustomRoleConfig(t *testing.T) (*config.Config, *models.User, *models.Project) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config, user, proj
+}
+
+func createTestCustomRole(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	request *types.CreateCustomRoleRequest,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/roles/custom", request)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := project.NewCustomRoleCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func updateTestRole(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	request *types.UpdateRoleRequest,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/roles", request)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := project.NewRoleUpdateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func deleteTestCustomRole(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	customRoleID uint,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/roles/custom/1", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamCustomRoleID): fmt.Sprintf("%d", customRoleID),
+	})
+
+	handler := project.NewCustomRoleDeleteHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
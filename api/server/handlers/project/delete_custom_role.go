@@ -0,0 +1,89 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CustomRoleDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCustomRoleDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CustomRoleDeleteHandler {
+	return &CustomRoleDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *CustomRoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRole, reqErr := getCustomRoleFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// a custom role can't be deleted while it is assigned, since the collaborators
+	// with the role would lose access to the project
+	roles, err := p.Repo().Project().ListProjectRoles(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, role := range roles {
+		if role.Kind == types.RoleCustom && role.CustomRoleID == customRole.ID {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %s is assigned to user %d", customRole.Name, role.UserID),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	teams, err := p.Repo().Team().ListTeamsByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, team := range teams {
+		if team.Kind == types.RoleCustom && team.CustomRoleID == customRole.ID {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %s is assigned to team %s", customRole.Name, team.Name),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	res, err := customRole.ToCustomRoleType()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, err := p.Repo().CustomRole().DeleteCustomRole(customRole); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, (*types.DeleteCustomRoleResponse)(res))
+}
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -9,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type RoleDeleteHandler struct {
@@ -43,6 +46,36 @@ func (p *RoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// service accounts can't be removed from the project without deleting them, since
+	// they are not members of any other project
+	if _, err := p.Repo().ServiceAccount().ReadServiceAccountByUserID(request.UserID); err == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("user %d is a service account: delete the service account instead", request.UserID),
+			http.StatusBadRequest,
+		))
+
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// remove the user from the project's teams, so that they don't regain the access
+	// of their teams if they are invited to the project again
+	teams, err := p.Repo().Team().ListTeamsByUserID(proj.ID, request.UserID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, team := range teams {
+		if err := p.Repo().Team().RemoveTeamMember(team, request.UserID); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
 	role, err = p.Repo().Project().DeleteProjectRole(proj.ID, request.UserID)
 
 	if err != nil {
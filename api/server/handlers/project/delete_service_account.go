@@ -0,0 +1,115 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ServiceAccountDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewServiceAccountDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ServiceAccountDeleteHandler {
+	return &ServiceAccountDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ServiceAccountDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sa, reqErr := getServiceAccountFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// revoke the service account's tokens, which are kept so that they are still
+	// visible in the list of tokens
+	apiTokens, err := p.Repo().APIToken().ListAPITokensByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, apiToken := range apiTokens {
+		if apiToken.ServiceAccountID != sa.ID || apiToken.Revoked {
+			continue
+		}
+
+		apiToken.Revoked = true
+
+		if _, err := p.Repo().APIToken().UpdateAPIToken(apiToken); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	role, err := p.Repo().Project().DeleteProjectRole(proj.ID, sa.UserID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if saUser, err := p.Repo().User().ReadUser(sa.UserID); err == nil {
+		if _, err := p.Repo().User().DeleteUser(saUser); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sa, err = p.Repo().ServiceAccount().DeleteServiceAccount(sa)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := types.DeleteServiceAccountResponse(*sa.ToServiceAccountType(role))
+
+	p.WriteResult(w, r, res)
+}
+
+// getServiceAccountFromURL reads the service account given by the URL parameter in
+// the project
+func getServiceAccountFromURL(
+	config *config.Config,
+	r *http.Request,
+	projectID uint,
+) (*models.ServiceAccount, apierrors.RequestError) {
+	saID, reqErr := requestutils.GetURLParamUint(r, types.URLParamServiceAccountID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	sa, err := config.Repo.ServiceAccount().ReadServiceAccount(projectID, saID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return sa, nil
+}
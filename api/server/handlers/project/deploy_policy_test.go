@@ -0,0 +1,97 @@
+package project_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDeployPolicy(t *testing.T, config *config.Config, user *models.User, proj *models.Project) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1/deploy_policy", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	project.NewDeployPolicyGetHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func updateDeployPolicy(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	request *types.UpdateDeployPolicyRequest,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/deploy_policy", request)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	project.NewDeployPolicyUpdateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestDeployPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	config, admin, _, proj := setupCanIProject(t)
+
+	// projects without a deploy policy have an empty policy
+	rr := getDeployPolicy(t, config, admin, proj)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	apitest.AssertResponseExpected(t, rr, &types.DeployPolicy{
+		RequiredLabels:    []string{},
+		AllowedRegistries: []string{},
+	}, &types.DeployPolicy{})
+
+	policy := &types.DeployPolicy{
+		RequireResourceLimits: true,
+		ForbidPrivileged:      true,
+		RequiredLabels:        []string{"team"},
+		AllowedRegistries:     []string{"gcr.io/project", "docker.io/library"},
+	}
+
+	rr = updateDeployPolicy(t, config, admin, proj, (*types.UpdateDeployPolicyRequest)(policy))
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	// updating the policy again should replace it
+	policy.ForbidHostPath = true
+	policy.PinImageDigests = true
+	policy.AllowedRegistries = []string{"gcr.io/project"}
+
+	rr = updateDeployPolicy(t, config, admin, proj, (*types.UpdateDeployPolicyRequest)(policy))
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	rr = getDeployPolicy(t, config, admin, proj)
+
+	apitest.AssertResponseExpected(t, rr, policy, &types.DeployPolicy{})
+
+	// list elements are stored as comma-separated lists, so cannot contain commas
+	rr = updateDeployPolicy(t, config, admin, proj, &types.UpdateDeployPolicyRequest{
+		RequiredLabels: []string{"team,app"},
+	})
+
+	assert.Equal(http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,45 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeployPolicyGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDeployPolicyGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DeployPolicyGetHandler {
+	return &DeployPolicyGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *DeployPolicyGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	policy, err := p.Repo().DeployPolicy().ReadDeployPolicy(proj.ID)
+
+	// projects without a deploy policy allow every deploy
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		policy = &models.DeployPolicy{
+			ProjectID: proj.ID,
+		}
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToDeployPolicyType())
+}
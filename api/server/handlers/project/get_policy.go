@@ -30,12 +30,26 @@ func (p *ProjectGetPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
-	policyDocLoader := policy.NewBasicPolicyDocumentLoader(p.Config().Repo.Project())
+	policyDocLoader := policy.NewBasicPolicyDocumentLoader(
+		p.Config().Repo.Project(),
+		p.Config().Repo.CustomRole(),
+		p.Config().Repo.Team(),
+	)
+
+	loaderOpts := &policy.PolicyLoaderOpts{
+		ProjectID: proj.ID,
+		UserID:    user.ID,
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		loaderOpts.APIToken = apiToken
+	}
 
-	policyDocs, err := policyDocLoader.LoadPolicyDocuments(user.ID, proj.ID)
+	policyDocs, err := policyDocLoader.LoadPolicyDocuments(loaderOpts)
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	var res types.GetProjectPolicyResponse = policyDocs
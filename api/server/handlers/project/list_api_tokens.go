@@ -0,0 +1,44 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type APITokensListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewAPITokensListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *APITokensListHandler {
+	return &APITokensListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *APITokensListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	apiTokens, err := p.Repo().APIToken().ListAPITokensByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListAPITokensResponse = make([]*types.APITokenMeta, 0)
+
+	for _, apiToken := range apiTokens {
+		res = append(res, apiToken.ToAPITokenMetaType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,56 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type AuditLogsListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewAuditLogsListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *AuditLogsListHandler {
+	return &AuditLogsListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *AuditLogsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditLogsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	auditLogs, count, err := p.Repo().AuditLog().ListAuditLogsByProjectID(proj.ID, request)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListAuditLogsResponse{
+		Count:     count,
+		Limit:     request.Limit,
+		Skip:      request.Skip,
+		AuditLogs: []*types.AuditLog{},
+	}
+
+	for _, auditLog := range auditLogs {
+		res.AuditLogs = append(res.AuditLogs, auditLog.ToAuditLogType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -50,16 +50,37 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	sas, err := p.Repo().ServiceAccount().ListServiceAccountsByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	saMap := make(map[uint]*models.ServiceAccount)
+
+	for _, sa := range sas {
+		saMap[sa.UserID] = sa
+	}
+
 	var res types.ListCollaboratorsResponse = make([]*types.Collaborator, 0)
 
 	for _, user := range users {
-		res = append(res, &types.Collaborator{
-			ID:        roleMap[user.ID].ID,
-			Kind:      string(roleMap[user.ID].Kind),
-			UserID:    roleMap[user.ID].UserID,
-			Email:     user.Email,
-			ProjectID: roleMap[user.ID].ProjectID,
-		})
+		collaborator := &types.Collaborator{
+			ID:           roleMap[user.ID].ID,
+			Kind:         string(roleMap[user.ID].Kind),
+			CustomRoleID: roleMap[user.ID].CustomRoleID,
+			UserID:       roleMap[user.ID].UserID,
+			Email:        user.Email,
+			ProjectID:    roleMap[user.ID].ProjectID,
+		}
+
+		if sa, ok := saMap[user.ID]; ok {
+			collaborator.ServiceAccountID = sa.ID
+			collaborator.ServiceAccountName = sa.Name
+		}
+
+		res = append(res, collaborator)
 	}
 
 	p.WriteResult(w, r, res)
@@ -0,0 +1,51 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CustomRolesListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCustomRolesListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CustomRolesListHandler {
+	return &CustomRolesListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *CustomRolesListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRoles, err := p.Repo().CustomRole().ListCustomRolesByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListCustomRolesResponse = make([]*types.CustomRole, 0)
+
+	for _, customRole := range customRoles {
+		customRoleType, err := customRole.ToCustomRoleType()
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res = append(res, customRoleType)
+	}
+
+	p.WriteResult(w, r, res)
+}
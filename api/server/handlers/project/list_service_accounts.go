@@ -0,0 +1,58 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ServiceAccountsListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewServiceAccountsListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ServiceAccountsListHandler {
+	return &ServiceAccountsListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ServiceAccountsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sas, err := p.Repo().ServiceAccount().ListServiceAccountsByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	roles, err := p.Repo().Project().ListProjectRoles(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	roleMap := make(map[uint]*models.Role)
+
+	for _, role := range roles {
+		roleCp := role
+		roleMap[role.UserID] = &roleCp
+	}
+
+	var res types.ListServiceAccountsResponse = make([]*types.ServiceAccount, 0)
+
+	for _, sa := range sas {
+		res = append(res, sa.ToServiceAccountType(roleMap[sa.UserID]))
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,51 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TeamsListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewTeamsListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *TeamsListHandler {
+	return &TeamsListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *TeamsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	teams, err := p.Repo().Team().ListTeamsByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	var res types.ListTeamsResponse = make([]*types.Team, 0)
+
+	for _, team := range teams {
+		teamType, reqErr := getTeamType(p.Config(), team)
+
+		if reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		res = append(res, teamType)
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,58 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TeamMemberRemoveHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewTeamMemberRemoveHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *TeamMemberRemoveHandler {
+	return &TeamMemberRemoveHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *TeamMemberRemoveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	team, reqErr := getTeamFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	userID, reqErr := requestutils.GetURLParamUint(r, types.URLParamUserID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := p.Repo().Team().RemoveTeamMember(team, userID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, reqErr := getTeamType(p.Config(), team)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, (*types.RemoveTeamMemberResponse)(res))
+}
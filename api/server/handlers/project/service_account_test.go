@@ -0,0 +1,233 @@
+package project_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestServiceAccount(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	request *types.CreateServiceAccountRequest,
+) (*types.CreateServiceAccountResponse, int) {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/service_accounts", request)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := project.NewServiceAccountCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		return nil, rr.Code
+	}
+
+	res := &types.CreateServiceAccountResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res, rr.Code
+}
+
+func TestCreateServiceAccount(t *testing.T) {
+	assert := assert.New(t)
+
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sa, code := createTestServiceAccount(t, config, user, proj, &types.CreateServiceAccountRequest{
+		Name: "ci",
+		Kind: types.RoleDeveloper,
+	})
+
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("ci", sa.Name)
+	assert.Equal(types.RoleDeveloper, sa.Role.Kind)
+
+	saUser, err := config.Repo.User().ReadUser(sa.UserID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(saUser.ServiceAccount)
+	assert.Empty(saUser.Password, "service account users should have no password")
+
+	// names must be unique within a project and usable in an email
+	_, code = createTestServiceAccount(t, config, user, proj, &types.CreateServiceAccountRequest{
+		Name: "ci",
+		Kind: types.RoleViewer,
+	})
+
+	assert.Equal(http.StatusBadRequest, code)
+
+	_, code = createTestServiceAccount(t, config, user, proj, &types.CreateServiceAccountRequest{
+		Name: "CI Pipeline",
+		Kind: types.RoleViewer,
+	})
+
+	assert.Equal(http.StatusBadRequest, code)
+
+	// service accounts should be listed as collaborators, but marked as service accounts
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1/collaborators", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	project.NewCollaboratorsListHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	collaborators := types.ListCollaboratorsResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(&collaborators); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(collaborators, 2)
+
+	for _, collaborator := range collaborators {
+		if collaborator.UserID == user.ID {
+			assert.Zero(collaborator.ServiceAccountID)
+		} else {
+			assert.Equal(sa.ID, collaborator.ServiceAccountID)
+			assert.Equal("ci", collaborator.ServiceAccountName)
+		}
+	}
+}
+
+func TestServiceAccountAPIToken(t *testing.T) {
+	assert := assert.New(t)
+
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sa, _ := createTestServiceAccount(t, config, user, proj, &types.CreateServiceAccountRequest{
+		Name: "ci",
+		Kind: types.RoleViewer,
+	})
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/service_accounts/1/api_token",
+		&types.CreateServiceAccountAPITokenRequest{
+			Name: "github-actions",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamServiceAccountID): "1",
+	})
+
+	project.NewServiceAccountAPITokenCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	created := &types.CreateAPITokenResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(created); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(sa.ID, created.ServiceAccountID)
+
+	// the token should be issued by the service account, not the user who created it
+	tok, err := token.GetTokenFromEncoded(created.Token, config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(sa.UserID, tok.IBy)
+
+	apiToken, err := config.Repo.APIToken().ReadAPIToken(proj.ID, tok.TokenID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token's policy should be loaded from the service account's role
+	loader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole(), config.Repo.Team())
+
+	docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		ProjectID: proj.ID,
+		UserID:    tok.IBy,
+		APIToken:  apiToken,
+	})
+
+	if reqErr != nil {
+		t.Fatal(reqErr)
+	}
+
+	assert.Equal(policy.ViewerPolicy, docs)
+
+	// deleting the service account should revoke its tokens and remove its role
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/service_accounts/1", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamServiceAccountID): "1",
+	})
+
+	project.NewServiceAccountDeleteHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	apiToken, err = config.Repo.APIToken().ReadAPIToken(proj.ID, tok.TokenID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(apiToken.Revoked, "token should be revoked")
+
+	_, err = config.Repo.Project().ReadProjectRole(proj.ID, sa.UserID)
+
+	assert.Error(err, "service account role should be deleted")
+}
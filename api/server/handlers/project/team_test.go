@@ -0,0 +1,126 @@
+package project_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamMembership(t *testing.T) {
+	assert := assert.New(t)
+
+	// the viewer is a collaborator with the viewer role
+	config, admin, viewer, proj := setupCanIProject(t)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/teams", &types.CreateTeamRequest{
+		Name: "developers",
+		Kind: types.RoleDeveloper,
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, admin)
+	req = apitest.WithProject(t, req, proj)
+
+	project.NewTeamCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/teams/1/members", &types.AddTeamMemberRequest{
+		UserID: viewer.ID,
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, admin)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamTeamID): "1",
+	})
+
+	project.NewTeamMemberAddHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	team := &types.AddTeamMemberResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(team); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(team.Members, 1)
+	assert.Equal("viewer@test.it", team.Members[0].Email)
+
+	// the viewer should now be able to update releases through the team
+	rr = runCanI(t, config, viewer, proj, "verb=update&cluster_id=1&namespace=default&release=web")
+
+	apitest.AssertResponseExpected(t, rr, &types.CanIResponse{
+		Allowed:                true,
+		MatchingPolicyDocument: policy.DeveloperPolicy[0],
+	}, &types.CanIResponse{})
+
+	// removing the viewer from the team should remove the access of the team
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/teams/1/members/2", nil)
+
+	req = apitest.WithAuthenticatedUser(t, req, admin)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamTeamID): "1",
+		string(types.URLParamUserID): "2",
+	})
+
+	project.NewTeamMemberRemoveHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	rr = runCanI(t, config, viewer, proj, "verb=update&cluster_id=1&namespace=default&release=web")
+
+	apitest.AssertResponseExpected(t, rr, &types.CanIResponse{Allowed: false}, &types.CanIResponse{})
+}
+
+func TestAddTeamMemberNotCollaborator(t *testing.T) {
+	config, admin, _, proj := setupCanIProject(t)
+
+	_, err := config.Repo.Team().CreateTeam(&models.Team{
+		ProjectID: proj.ID,
+		Name:      "developers",
+		Kind:      types.RoleDeveloper,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/teams/1/members", &types.AddTeamMemberRequest{
+		UserID: 3,
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, admin)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamTeamID): "1",
+	})
+
+	project.NewTeamMemberAddHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,112 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CustomRoleUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCustomRoleUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CustomRoleUpdateHandler {
+	return &CustomRoleUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CustomRoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRole, reqErr := getCustomRoleFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateCustomRoleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Name != "" && request.Name != customRole.Name {
+		if reqErr := checkCustomRoleName(p.Config(), proj.ID, customRole.ID, request.Name); reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		customRole.Name = request.Name
+	}
+
+	// since policies are loaded from the database on each request, updating the
+	// policy immediately changes the permissions of every collaborator with this role
+	if request.Policy != nil {
+		if err := policy.ValidatePolicy(request.Policy); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		if err := customRole.SetPolicy(request.Policy); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	customRole, err := p.Repo().CustomRole().UpdateCustomRole(customRole)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := customRole.ToCustomRoleType()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, (*types.UpdateCustomRoleResponse)(res))
+}
+
+// getCustomRoleFromURL reads the custom role in the project from the custom role
+// id URL param
+func getCustomRoleFromURL(
+	config *config.Config,
+	r *http.Request,
+	projectID uint,
+) (*models.CustomRole, apierrors.RequestError) {
+	customRoleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamCustomRoleID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	customRole, err := config.Repo.CustomRole().ReadCustomRole(projectID, customRoleID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return customRole, nil
+}
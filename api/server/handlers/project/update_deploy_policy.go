@@ -0,0 +1,76 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeployPolicyUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDeployPolicyUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeployPolicyUpdateHandler {
+	return &DeployPolicyUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces the deploy policy of a project. The policy is checked on the next
+// install or upgrade of each release, and does not affect releases which are already
+// deployed.
+func (p *DeployPolicyUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateDeployPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	policy, err := p.Repo().DeployPolicy().ReadDeployPolicy(proj.ID)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if isNotFound {
+		policy = &models.DeployPolicy{
+			ProjectID: proj.ID,
+		}
+	}
+
+	policy.RequireResourceLimits = request.RequireResourceLimits
+	policy.ForbidPrivileged = request.ForbidPrivileged
+	policy.ForbidHostPath = request.ForbidHostPath
+	policy.RequiredLabels = strings.Join(request.RequiredLabels, ",")
+	policy.AllowedRegistries = strings.Join(request.AllowedRegistries, ",")
+	policy.PinImageDigests = request.PinImageDigests
+
+	if isNotFound {
+		policy, err = p.Repo().DeployPolicy().CreateDeployPolicy(policy)
+	} else {
+		policy, err = p.Repo().DeployPolicy().UpdateDeployPolicy(policy)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToDeployPolicyType())
+}
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -9,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type RoleUpdateHandler struct {
@@ -43,7 +46,15 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var reqErr apierrors.RequestError
+
 	role.Kind = types.RoleKind(request.Kind)
+	role.CustomRoleID, reqErr = getRoleCustomRoleID(p.Config(), proj.ID, role.Kind, request.CustomRoleID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
@@ -66,3 +77,30 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
 	}
 }
+
+// getRoleCustomRoleID returns the custom role id to store for a role of the given
+// kind. Custom roles must refer to a custom role in the same project, while other
+// kinds never refer to a custom role.
+func getRoleCustomRoleID(
+	config *config.Config,
+	projectID uint,
+	kind types.RoleKind,
+	customRoleID uint,
+) (uint, apierrors.RequestError) {
+	if kind != types.RoleCustom {
+		return 0, nil
+	}
+
+	if _, err := config.Repo.CustomRole().ReadCustomRole(projectID, customRoleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %d does not exist in project %d", customRoleID, projectID),
+				http.StatusBadRequest,
+			)
+		}
+
+		return 0, apierrors.NewErrInternal(err)
+	}
+
+	return customRoleID, nil
+}
@@ -0,0 +1,110 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type TeamUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTeamUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TeamUpdateHandler {
+	return &TeamUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TeamUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	team, reqErr := getTeamFromURL(p.Config(), r, proj.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateTeamRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Name != "" && request.Name != team.Name {
+		if reqErr := checkTeamName(p.Config(), proj.ID, team.ID, request.Name); reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		team.Name = request.Name
+	}
+
+	// since policies are loaded on each request, changing the role of the team
+	// immediately changes the permissions of every member of the team
+	if request.Kind != "" {
+		customRoleID, reqErr := getRoleCustomRoleID(p.Config(), proj.ID, request.Kind, request.CustomRoleID)
+
+		if reqErr != nil {
+			p.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		team.Kind = request.Kind
+		team.CustomRoleID = customRoleID
+	}
+
+	team, err := p.Repo().Team().UpdateTeam(team)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, reqErr := getTeamType(p.Config(), team)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	p.WriteResult(w, r, (*types.UpdateTeamResponse)(res))
+}
+
+// getTeamFromURL reads the team in the project from the team id URL param
+func getTeamFromURL(
+	config *config.Config,
+	r *http.Request,
+	projectID uint,
+) (*models.Team, apierrors.RequestError) {
+	teamID, reqErr := requestutils.GetURLParamUint(r, types.URLParamTeamID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	team, err := config.Repo.Team().ReadTeam(projectID, teamID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return team, nil
+}
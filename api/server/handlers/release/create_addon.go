@@ -1,6 +1,7 @@
 package release
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/helm/repo"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"helm.sh/helm/v3/pkg/chart"
@@ -73,7 +75,7 @@ func (c *CreateAddonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		c.HandleAPIError(w, r, NewLoadChartError(err))
 		return
 	}
 
@@ -125,39 +127,139 @@ type LoadAddonChartOpts struct {
 	RepoURL, TemplateName, TemplateVersion string
 }
 
+// LoadChart loads a chart from a repo with the credentials of the project, and records
+// the repo in the chart's annotations so that it is stored with the release
 func LoadChart(config *config.Config, opts *LoadAddonChartOpts) (*chart.Chart, error) {
+	ch, err := loadChart(config, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loader.SetChartRepoURL(ch, repo.NormalizeURL(opts.RepoURL))
+
+	return ch, nil
+}
+
+func loadChart(config *config.Config, opts *LoadAddonChartOpts) (*chart.Chart, error) {
 	// if the chart repo url is one of the specified application/addon charts, just load public
 	if opts.RepoURL == config.ServerConf.DefaultAddonHelmRepoURL || opts.RepoURL == config.ServerConf.DefaultApplicationHelmRepoURL {
 		return loader.LoadChartPublic(opts.RepoURL, opts.TemplateName, opts.TemplateVersion)
-	} else {
-		// load the helm repos in the project
-		hrs, err := config.Repo.HelmRepo().ListHelmReposByProjectID(opts.ProjectID)
+	}
+
+	// load the helm repos in the project
+	hrs, err := config.Repo.HelmRepo().ListHelmReposByProjectID(opts.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hr := repo.MatchHelmRepo(hrs, opts.RepoURL)
+
+	// charts are never loaded without verification from a host which is served by a
+	// helm repo that requires provenance verification
+	if (hr == nil || !hr.VerifyProvenance) && repo.HostRequiresProvenance(hrs, opts.RepoURL) {
+		return nil, &loader.ProvenanceError{
+			Chart: opts.TemplateName,
+			Err:   fmt.Errorf("chart repo %s does not match a helm repo which verifies provenance", opts.RepoURL),
+		}
+	}
+
+	if hr != nil {
+		return loadHelmRepoChart(config, hr, opts)
+	}
+
+	// charts in OCI registries are loaded with the credentials of a matching registry
+	// in the project, if one exists
+	if loader.IsOCIRepo(opts.RepoURL) {
+		client, err := repo.GetOCICredentials(config.Repo, opts.ProjectID, opts.RepoURL, config.DOConf)
 
 		if err != nil {
 			return nil, err
 		}
 
-		for _, hr := range hrs {
-			if hr.RepoURL == opts.RepoURL {
-				if hr.BasicAuthIntegrationID != 0 {
-					// read the basic integration id
-					basic, err := config.Repo.BasicIntegration().ReadBasicIntegration(opts.ProjectID, hr.BasicAuthIntegrationID)
-
-					if err != nil {
-
-						return nil, err
-					}
-
-					return loader.LoadChart(&loader.BasicAuthClient{
-						Username: string(basic.Username),
-						Password: string(basic.Password),
-					}, hr.RepoURL, opts.TemplateName, opts.TemplateVersion)
-				} else {
-					return loader.LoadChartPublic(hr.RepoURL, opts.TemplateName, opts.TemplateVersion)
-				}
-			}
-		}
+		return loader.LoadChart(client, opts.RepoURL, opts.TemplateName, opts.TemplateVersion)
+	}
+
+	return nil, fmt.Errorf("chart repo not found")
+}
+
+// loadHelmRepoChart loads a chart from the chart repo URL with the credentials of a
+// matching helm repo in the project. If the helm repo requires provenance verification,
+// charts which are not signed by a key in the helm repo's keyring are not loaded.
+func loadHelmRepoChart(config *config.Config, hr *models.HelmRepo, opts *LoadAddonChartOpts) (*chart.Chart, error) {
+	repoURL := repo.NormalizeURL(opts.RepoURL)
+
+	client, err := getHelmRepoClient(config, opts.ProjectID, hr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hr.VerifyProvenance {
+		return loader.LoadVerifiedChart(client, repoURL, opts.TemplateName, opts.TemplateVersion, hr.Keyring)
+	}
+
+	return loader.LoadChart(client, repoURL, opts.TemplateName, opts.TemplateVersion)
+}
+
+// GetRepoClient returns the credentials for a chart repo in a project, in the same way
+// as LoadChart: the default repos are read anonymously, helm repos with the credentials
+// of the matching helm repo, and OCI registries with the credentials of a matching
+// registry.
+func GetRepoClient(config *config.Config, projectID uint, repoURL string) (*loader.BasicAuthClient, error) {
+	if repoURL == config.ServerConf.DefaultAddonHelmRepoURL || repoURL == config.ServerConf.DefaultApplicationHelmRepoURL {
+		return &loader.BasicAuthClient{}, nil
+	}
+
+	hrs, err := config.Repo.HelmRepo().ListHelmReposByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hr := repo.MatchHelmRepo(hrs, repoURL); hr != nil {
+		return getHelmRepoClient(config, projectID, hr)
+	}
+
+	if loader.IsOCIRepo(repoURL) {
+		return repo.GetOCICredentials(config.Repo, projectID, repoURL, config.DOConf)
 	}
 
 	return nil, fmt.Errorf("chart repo not found")
 }
+
+// getHelmRepoClient returns the credentials of a helm repo in the project
+func getHelmRepoClient(config *config.Config, projectID uint, hr *models.HelmRepo) (*loader.BasicAuthClient, error) {
+	if loader.IsOCIRepo(hr.RepoURL) {
+		return repo.GetOCICredentials(config.Repo, projectID, hr.RepoURL, config.DOConf)
+	}
+
+	if hr.BasicAuthIntegrationID == 0 {
+		return &loader.BasicAuthClient{}, nil
+	}
+
+	// read the basic integration id
+	basic, err := config.Repo.BasicIntegration().ReadBasicIntegration(projectID, hr.BasicAuthIntegrationID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &loader.BasicAuthClient{
+		Username: string(basic.Username),
+		Password: string(basic.Password),
+	}, nil
+}
+
+// NewLoadChartError returns the API error for an error from LoadChart. Charts which
+// fail provenance verification are reported to the client.
+func NewLoadChartError(err error) apierrors.RequestError {
+	var provErr *loader.ProvenanceError
+
+	if errors.As(err, &provErr) {
+		return apierrors.NewErrPassThroughToClient(provErr, http.StatusBadRequest)
+	}
+
+	return apierrors.NewErrInternal(err)
+}
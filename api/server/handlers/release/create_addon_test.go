@@ -0,0 +1,153 @@
+package release_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+// newUnsignedChartRepo serves an index containing a single unsigned chart with the
+// name under each of the paths
+func newUnsignedChartRepo(t *testing.T, name string, paths ...string) *httptest.Server {
+	chartPath, err := chartutil.Save(&chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       name,
+			Version:    "0.1.0",
+		},
+	}, t.TempDir())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(chartPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	for _, p := range paths {
+		index := repo.NewIndexFile()
+
+		if err := index.MustAdd(&chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       name,
+			Version:    "0.1.0",
+		}, fmt.Sprintf("%s-0.1.0.tgz", name), server.URL+p, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		indexBytes, err := yaml.Marshal(index)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mux.HandleFunc(p+"/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+			w.Write(indexBytes)
+		})
+
+		mux.HandleFunc(fmt.Sprintf("%s/%s-0.1.0.tgz", p, name), func(w http.ResponseWriter, r *http.Request) {
+			w.Write(data)
+		})
+	}
+
+	return server
+}
+
+func TestLoadChartRequiresProvenance(t *testing.T) {
+	assert := assert.New(t)
+
+	config := apitest.LoadConfig(t)
+	server := newUnsignedChartRepo(t, "web", "/charts", "/charts/sub", "/other")
+	defer server.Close()
+
+	_, err := config.Repo.HelmRepo().CreateHelmRepo(&models.HelmRepo{
+		ProjectID:        1,
+		Name:             "signed",
+		RepoURL:          server.URL + "/charts",
+		VerifyProvenance: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an unverified helm repo on the same host cannot be used to bypass verification
+	_, err = config.Repo.HelmRepo().CreateHelmRepo(&models.HelmRepo{
+		ProjectID: 1,
+		Name:      "unsigned",
+		RepoURL:   server.URL + "/other",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repoURLs := []string{
+		server.URL + "/charts",
+		server.URL + "/charts/",
+		server.URL + "/charts/sub",
+		server.URL + "/other",
+		server.URL + "/charts/../other",
+	}
+
+	for _, repoURL := range repoURLs {
+		_, err := release.LoadChart(config, &release.LoadAddonChartOpts{
+			ProjectID:       1,
+			RepoURL:         repoURL,
+			TemplateName:    "web",
+			TemplateVersion: "0.1.0",
+		})
+
+		var provErr *loader.ProvenanceError
+
+		if assert.Error(err, repoURL) {
+			assert.True(errors.As(err, &provErr), "%s: expected a provenance error, got %v", repoURL, err)
+		}
+	}
+}
+
+func TestLoadChartWithoutProvenance(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	server := newUnsignedChartRepo(t, "web", "/charts")
+	defer server.Close()
+
+	_, err := config.Repo.HelmRepo().CreateHelmRepo(&models.HelmRepo{
+		ProjectID: 1,
+		Name:      "unsigned",
+		RepoURL:   server.URL + "/charts",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := release.LoadChart(config, &release.LoadAddonChartOpts{
+		ProjectID:       1,
+		RepoURL:         server.URL + "/charts/",
+		TemplateName:    "web",
+		TemplateVersion: "0.1.0",
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "web", ch.Metadata.Name)
+	}
+}
@@ -0,0 +1,115 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+type GetReleaseDiffHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetReleaseDiffHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetReleaseDiffHandler {
+	return &GetReleaseDiffHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetReleaseDiffHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetReleaseDiffRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+
+	// a revision of 0 reads the latest revision
+	to, reqErr := getReleaseRevision(helmAgent, name, request.ToRevision)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	fromRevision := request.FromRevision
+
+	if fromRevision == 0 {
+		if to.Version <= 1 {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("revision %d of release %s has no previous revision", to.Version, name),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		fromRevision = to.Version - 1
+	}
+
+	from, reqErr := getReleaseRevision(helmAgent, name, fromRevision)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	res, err := helm.DiffReleases(from, to)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+func getReleaseRevision(helmAgent *helm.Agent, name string, revision int) (*release.Release, apierrors.RequestError) {
+	rel, err := helmAgent.GetRelease(name, revision, false)
+
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		if revision == 0 {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("release %s not found", name),
+				http.StatusNotFound,
+			)
+		}
+
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("revision %d of release %s not found", revision, name),
+			http.StatusNotFound,
+		)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return rel, nil
+}
@@ -0,0 +1,145 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+)
+
+func newRevision(version int, chartVersion, tag string) *helmrelease.Release {
+	return &helmrelease.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   version,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "web",
+				Version:    chartVersion,
+			},
+		},
+		Config: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "gcr.io/project/web",
+				"tag":        tag,
+			},
+		},
+		Manifest: "---\n# Source: web/templates/configmap.yaml\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\ndata:\n  image: \"gcr.io/project/web:" + tag + "\"\n",
+		Info: &helmrelease.Info{
+			Status: helmrelease.StatusSuperseded,
+		},
+	}
+}
+
+func getReleaseDiff(t *testing.T, helmAgent *helm.Agent, query string) *httptest.ResponseRecorder {
+	config, user, proj, cluster := setupClusterTest(t)
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbGet),
+		"/api/projects/1/clusters/1/namespaces/default/releases/web/history/diff"+query,
+		nil,
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamReleaseName): "web",
+	})
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, authz.HelmAgentCtxKey, helmAgent)
+	req = req.WithContext(ctx)
+
+	release.NewGetReleaseDiffHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestGetReleaseDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	helmAgent := newTargetHelmAgent("default")
+
+	for _, rel := range []*helmrelease.Release{
+		newRevision(1, "0.1.0", "abc123"),
+		newRevision(2, "0.1.0", "def456"),
+		newRevision(3, "0.2.0", "def456"),
+	} {
+		if err := helmAgent.ActionConfig.Releases.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the latest revision is diffed against the revision before it by default
+	rr := getReleaseDiff(t, helmAgent, "")
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	res := &types.GetReleaseDiffResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(2, res.FromRevision)
+	assert.Equal(3, res.ToRevision)
+	assert.Equal("0.1.0", res.FromChartVersion)
+	assert.Equal("0.2.0", res.ToChartVersion)
+	assert.Empty(res.ValuesDiff)
+
+	if assert.Len(res.ManifestDiff, 1) {
+		assert.Equal(types.ManifestDiffStatusUnchanged, res.ManifestDiff[0].Status)
+	}
+
+	// any two revisions can be diffed
+	rr = getReleaseDiff(t, helmAgent, "?from=1&to=3")
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	res = &types.GetReleaseDiffResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(1, res.FromRevision)
+	assert.Equal(3, res.ToRevision)
+	assert.Equal([]*types.ValuesDiff{{
+		Path:     "image.tag",
+		Status:   types.ManifestDiffStatusChanged,
+		OldValue: "abc123",
+		NewValue: "def456",
+	}}, res.ValuesDiff)
+
+	if assert.Len(res.ManifestDiff, 1) {
+		assert.Equal(types.ManifestDiffStatusChanged, res.ManifestDiff[0].Status)
+		assert.Contains(res.ManifestDiff[0].Diff, "+  image: \"gcr.io/project/web:def456\"")
+	}
+
+	// revisions which do not exist are not found
+	rr = getReleaseDiff(t, helmAgent, "?from=1&to=5")
+
+	assert.Equal(http.StatusNotFound, rr.Code)
+
+	// the first revision has no previous revision to diff against
+	rr = getReleaseDiff(t, helmAgent, "?to=1")
+
+	assert.Equal(http.StatusBadRequest, rr.Code)
+}
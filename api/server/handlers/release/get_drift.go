@@ -0,0 +1,73 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type GetReleaseDriftHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGetReleaseDriftHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetReleaseDriftHandler {
+	return &GetReleaseDriftHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetReleaseDriftHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	res, err := detectReleaseDrift(c.KubernetesAgentGetter, r, cluster, helmRelease)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// detectReleaseDrift compares the manifest of a release against the live objects in
+// the cluster
+func detectReleaseDrift(
+	agentGetter authz.KubernetesAgentGetter,
+	r *http.Request,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+) (*types.GetReleaseDriftResponse, error) {
+	dynClient, err := agentGetter.GetDynamicClient(r, cluster)
+
+	if err != nil {
+		return nil, err
+	}
+
+	k8sAgent, err := agentGetter.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	mapper, err := k8sAgent.RESTClientGetter.ToRESTMapper()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return helm.DetectDrift(helmRelease, dynClient, mapper)
+}
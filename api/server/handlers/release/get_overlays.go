@@ -0,0 +1,61 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type GetOverlaysHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetOverlaysHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetOverlaysHandler {
+	return &GetOverlaysHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetOverlaysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	// the route is not in the release scope, since overlays can be set before the
+	// release is installed, so access to the release is checked here
+	if !authz.HasResourceAccess(r, map[types.PermissionScope]types.NameOrUInt{
+		types.ReleaseScope: {Name: name},
+	}) {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user does not have access to release %s", name),
+		))
+
+		return
+	}
+
+	overlays, err := c.Repo().ManifestOverlay().ListManifestOverlays(cluster.ID, namespace, name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListManifestOverlaysResponse, 0, len(overlays))
+
+	for _, overlay := range overlays {
+		res = append(res, overlay.ToManifestOverlayType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,243 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type GetPorterYAMLHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetPorterYAMLHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetPorterYAMLHandler {
+	return &GetPorterYAMLHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetPorterYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	// releases which were not created through Porter are not stored in the database,
+	// and are exported without a build config
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		release = nil
+	}
+
+	resource, err := getPorterYAMLResource(c.Config(), cluster, helmRelease, release)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.GetPorterYAMLResponse{
+		Version:   "v1",
+		Resources: []*types.PorterYAMLResource{resource},
+	})
+}
+
+// getPorterYAMLResource generates the porter.yaml resource for a release, in the form
+// read by the porter.deploy driver of "porter apply"
+func getPorterYAMLResource(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	release *models.Release,
+) (*types.PorterYAMLResource, error) {
+	values := helmRelease.Config
+
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	res := &types.PorterYAMLResource{
+		Name:   helmRelease.Name,
+		Driver: "porter.deploy",
+		Source: &types.PorterYAMLSource{
+			Name:    helmRelease.Chart.Metadata.Name,
+			Version: helmRelease.Chart.Metadata.Version,
+		},
+		Target: &types.PorterYAMLTarget{
+			Project:   cluster.ProjectID,
+			Cluster:   cluster.ID,
+			Namespace: helmRelease.Namespace,
+		},
+	}
+
+	overlays, err := getPorterYAMLOverlays(config, cluster, helmRelease)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the config of addons is passed to the chart as values
+	if cName := helmRelease.Chart.Metadata.Name; cName != "job" && cName != "web" && cName != "worker" {
+		res.Source.Repo = getPorterYAMLRepoURL(config, helmRelease)
+		res.Config = values
+
+		if len(overlays) > 0 {
+			res.Config = make(map[string]interface{})
+
+			for key, val := range values {
+				res.Config[key] = val
+			}
+
+			res.Config["overlays"] = overlays
+		}
+
+		return res, nil
+	}
+
+	build, err := getPorterYAMLBuildConfig(config, values, release)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Config = map[string]interface{}{
+		"build":  build,
+		"values": values,
+	}
+
+	envGroups := make([]map[string]interface{}, 0)
+
+	for _, name := range helm.GetSyncedEnvGroups(values) {
+		envGroups = append(envGroups, map[string]interface{}{
+			"name":      name,
+			"namespace": helmRelease.Namespace,
+		})
+	}
+
+	if len(envGroups) > 0 {
+		res.Config["envGroups"] = envGroups
+	}
+
+	if len(overlays) > 0 {
+		res.Config["overlays"] = overlays
+	}
+
+	return res, nil
+}
+
+// getPorterYAMLRepoURL returns the repo of the chart of a release. Charts which were
+// loaded by Porter record their repo in their annotations. Otherwise, the chart is
+// looked up in the Porter chart repositories.
+func getPorterYAMLRepoURL(config *config.Config, helmRelease *release.Release) string {
+	if repoURL := loader.GetChartRepoURL(helmRelease.Chart); repoURL != "" {
+		return repoURL
+	}
+
+	if config.URLCache != nil {
+		repoURL, _ := config.URLCache.GetURL(helmRelease.Chart.Metadata.Name)
+
+		return repoURL
+	}
+
+	return ""
+}
+
+// getPorterYAMLOverlays returns the manifest overlays of a release, which are set
+// with the "overlays" key of the config of a resource
+func getPorterYAMLOverlays(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+) ([]*types.ManifestOverlay, error) {
+	overlays, err := config.Repo.ManifestOverlay().ListManifestOverlays(cluster.ID, helmRelease.Namespace, helmRelease.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.ManifestOverlay, 0, len(overlays))
+
+	for _, overlay := range overlays {
+		res = append(res, overlay.ToManifestOverlayType())
+	}
+
+	return res, nil
+}
+
+// getPorterYAMLBuildConfig returns the build config of an application. Releases
+// which are deployed from a git repository are built from source, while other
+// releases are deployed from the image in their values.
+func getPorterYAMLBuildConfig(
+	config *config.Config,
+	values map[string]interface{},
+	release *models.Release,
+) (map[string]interface{}, error) {
+	if release == nil || release.GitActionConfig == nil {
+		image, _ := values["image"].(map[string]interface{})
+		repository, _ := image["repository"].(string)
+
+		if repository == "" && release != nil {
+			repository = release.ImageRepoURI
+		}
+
+		if tag, _ := image["tag"].(string); tag != "" {
+			repository = fmt.Sprintf("%s:%s", repository, tag)
+		}
+
+		return map[string]interface{}{
+			"method": "registry",
+			"image":  repository,
+		}, nil
+	}
+
+	gaConfig := release.GitActionConfig
+
+	res := map[string]interface{}{
+		"method":  "pack",
+		"context": gaConfig.FolderPath,
+	}
+
+	if gaConfig.FolderPath == "" {
+		res["context"] = "./"
+	}
+
+	if gaConfig.DockerfilePath != "" {
+		res["method"] = "docker"
+		res["dockerfile"] = gaConfig.DockerfilePath
+	}
+
+	if release.BuildConfig != 0 {
+		bc, err := config.Repo.BuildConfig().GetBuildConfig(release.BuildConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if bc.Builder != "" {
+			res["builder"] = bc.Builder
+		}
+
+		if bc.Buildpacks != "" {
+			res["buildpacks"] = bc.ToBuildConfigType().Buildpacks
+		}
+	}
+
+	return res, nil
+}
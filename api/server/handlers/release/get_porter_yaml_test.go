@@ -0,0 +1,257 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/switchboard/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+func getPorterYAML(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	cluster *models.Cluster,
+	helmRelease *helmrelease.Release,
+) *types.GetPorterYAMLResponse {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbGet),
+		"/api/projects/1/clusters/1/namespaces/staging/releases/web/0/porter_yaml",
+		nil,
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, types.ReleaseScope, helmRelease)
+	req = req.WithContext(ctx)
+
+	release.NewGetPorterYAMLHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return decodePorterYAML(t, rr)
+}
+
+func decodePorterYAML(t *testing.T, rr *httptest.ResponseRecorder) *types.GetPorterYAMLResponse {
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	res := &types.GetPorterYAMLResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(res.Resources))
+	}
+
+	return res
+}
+
+func TestGetPorterYAMLFromRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+
+	res := getPorterYAML(t, config, user, proj, cluster, stagingRelease)
+	resource := res.Resources[0]
+
+	assert.Equal("v1", res.Version)
+	assert.Equal("web", resource.Name)
+	assert.Equal("porter.deploy", resource.Driver)
+	assert.Equal(&types.PorterYAMLSource{Name: "web", Version: "0.1.0"}, resource.Source)
+	assert.Equal(&types.PorterYAMLTarget{
+		Project:   proj.ID,
+		Cluster:   cluster.ID,
+		Namespace: "staging",
+	}, resource.Target)
+
+	// releases without a git action config are deployed from their image
+	assert.Equal(map[string]interface{}{
+		"method": "registry",
+		"image":  "gcr.io/project/web:abc123",
+	}, resource.Config["build"])
+
+	values := resource.Config["values"].(map[string]interface{})
+
+	assert.Equal("staging.example.com", values["host"])
+	assert.NotContains(resource.Config, "envGroups")
+}
+
+func TestGetPorterYAMLFromGit(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+
+	bc, err := config.Repo.BuildConfig().CreateBuildConfig(&models.BuildConfig{
+		Builder:    "paketobuildpacks/builder:full",
+		Buildpacks: "paketo-buildpacks/nodejs",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.Release().CreateRelease(&models.Release{
+		ClusterID:   cluster.ID,
+		ProjectID:   proj.ID,
+		Name:        "web",
+		Namespace:   "staging",
+		BuildConfig: bc.ID,
+		GitActionConfig: &models.GitActionConfig{
+			GitRepo:    "porter-dev/web",
+			GitBranch:  "main",
+			FolderPath: "./app",
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	helmRelease := *stagingRelease
+	helmRelease.Config = map[string]interface{}{
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"synced": []interface{}{
+					map[string]interface{}{"name": "shared", "version": 2},
+				},
+			},
+		},
+	}
+
+	res := getPorterYAML(t, config, user, proj, cluster, &helmRelease)
+	resource := res.Resources[0]
+
+	assert.Equal(map[string]interface{}{
+		"method":     "pack",
+		"context":    "./app",
+		"builder":    "paketobuildpacks/builder:full",
+		"buildpacks": []interface{}{"paketo-buildpacks/nodejs"},
+	}, resource.Config["build"])
+
+	assert.Equal([]interface{}{
+		map[string]interface{}{"name": "shared", "namespace": "staging"},
+	}, resource.Config["envGroups"])
+}
+
+func TestGetPorterYAMLAddon(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+
+	helmRelease := *stagingRelease
+	helmRelease.Name = "redis"
+	helmRelease.Chart = &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:    "redis",
+			Version: "14.6.2",
+		},
+	}
+	helmRelease.Config = map[string]interface{}{
+		"replicaCount": 2,
+	}
+
+	res := getPorterYAML(t, config, user, proj, cluster, &helmRelease)
+	resource := res.Resources[0]
+
+	// the config of addons is passed to the chart as values
+	assert.Equal(&types.PorterYAMLSource{Name: "redis", Version: "14.6.2"}, resource.Source)
+	assert.Equal(map[string]interface{}{"replicaCount": float64(2)}, resource.Config)
+}
+
+func TestGetPorterYAMLAddonRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+	server := newUnsignedChartRepo(t, "memcached", "/charts")
+	defer server.Close()
+
+	_, err := config.Repo.HelmRepo().CreateHelmRepo(&models.HelmRepo{
+		ProjectID: proj.ID,
+		Name:      "charts",
+		RepoURL:   server.URL + "/charts",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := release.LoadChart(config, &release.LoadAddonChartOpts{
+		ProjectID:       proj.ID,
+		RepoURL:         server.URL + "/charts/",
+		TemplateName:    "memcached",
+		TemplateVersion: "0.1.0",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	helmRelease := *stagingRelease
+	helmRelease.Name = "cache"
+	helmRelease.Chart = ch
+	helmRelease.Config = map[string]interface{}{
+		"replicaCount": 2,
+	}
+
+	res := getPorterYAML(t, config, user, proj, cluster, &helmRelease)
+
+	// the exported resource group is read by "porter apply" in the same way as a
+	// porter.yaml file
+	porterYAML, err := yaml.Marshal(res)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resGroup, err := parser.ParseRawBytes(porterYAML)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(resGroup.Resources, 1) {
+		return
+	}
+
+	resource := resGroup.Resources[0]
+
+	assert.Equal("cache", resource.Name)
+	assert.Equal(map[string]interface{}{
+		"name":    "memcached",
+		"repo":    server.URL + "/charts",
+		"version": "0.1.0",
+	}, resource.Source)
+	assert.Equal(map[string]interface{}{"replicaCount": float64(2)}, resource.Config)
+
+	// the chart can be loaded again from the exported source
+	_, err = release.LoadChart(config, &release.LoadAddonChartOpts{
+		ProjectID:       proj.ID,
+		RepoURL:         resource.Source["repo"].(string),
+		TemplateName:    resource.Source["name"].(string),
+		TemplateVersion: resource.Source["version"].(string),
+	})
+
+	assert.NoError(err)
+}
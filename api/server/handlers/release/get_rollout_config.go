@@ -0,0 +1,70 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetRolloutConfigHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetRolloutConfigHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetRolloutConfigHandler {
+	return &GetRolloutConfigHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetRolloutConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rolloutConfig, err := getReleaseRolloutConfig(c.Config(), release)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.GetRolloutConfigResponse)(rolloutConfig.ToRolloutConfigType()))
+}
+
+// getReleaseRolloutConfig reads the rollout config of a release. Releases without a
+// rollout config are upgraded in place, and use the default canary settings if they
+// are switched to a canary rollout.
+func getReleaseRolloutConfig(config *config.Config, release *models.Release) (*models.RolloutConfig, error) {
+	if release.RolloutConfig != 0 {
+		return config.Repo.RolloutConfig().ReadRolloutConfig(release.RolloutConfig)
+	}
+
+	return &models.RolloutConfig{
+		Strategy:            types.RolloutStrategyRolling,
+		Steps:               "10,25,50",
+		StepIntervalSeconds: 60,
+		MaxErrorRate:        1,
+	}, nil
+}
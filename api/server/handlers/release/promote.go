@@ -0,0 +1,436 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+type PromoteReleaseHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewPromoteReleaseHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PromoteReleaseHandler {
+	return &PromoteReleaseHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *PromoteReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.PromoteReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.TargetName == "" {
+		request.TargetName = helmRelease.Name
+	}
+
+	if request.TargetClusterID == cluster.ID && request.TargetNamespace == helmRelease.Namespace &&
+		request.TargetName == helmRelease.Name {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a release cannot be promoted to itself"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	targetCluster, err := c.Repo().Cluster().ReadCluster(cluster.ProjectID, request.TargetClusterID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("target cluster %d not found in project %d", request.TargetClusterID, cluster.ProjectID),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	overrides, err := chartutil.ReadValues([]byte(request.Values))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	targetHelmAgent, err := c.GetHelmAgent(r, targetCluster, request.TargetNamespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	targetRelease, err := getPromotionTargetRelease(targetHelmAgent, request.TargetName)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the policy middleware only checks the source release, so the caller's access to
+	// the target release is checked here
+	targetVerb := types.APIVerbCreate
+
+	if targetRelease != nil {
+		targetVerb = types.APIVerbUpdate
+	}
+
+	if reqErr := checkPromotionTargetAccess(c.Config(), r, user, targetCluster, request, targetVerb); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	values, err := helm.GetPromotedValues(helmRelease.Config, overrides, request.ImageTag)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroups, reqErr := resolvePromotedEnvGroups(targetHelmAgent, request.TargetNamespace, values)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// the chart of the source revision is used, unless another version is requested
+	ch := helmRelease.Chart
+
+	if request.ChartVersion != "" {
+		ch, reqErr = LoadUpgradeChart(c.Config(), cluster.ProjectID, helmRelease.Chart.Metadata.Name, request.ChartVersion)
+
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.PromoteReleaseResponse{
+		Created:   targetRelease == nil,
+		EnvGroups: envGroups,
+	}
+
+	if targetRelease == nil {
+		conf := &helm.InstallChartConfig{
+			Chart:      ch,
+			Name:       request.TargetName,
+			Namespace:  request.TargetNamespace,
+			Values:     values,
+			Cluster:    targetCluster,
+			Repo:       c.Repo(),
+			Registries: registries,
+			DryRun:     request.DryRun,
+		}
+
+		newRelease, err := targetHelmAgent.InstallChart(conf, c.Config().DOConf)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error installing the promoted release: %s", err.Error()),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		if request.DryRun {
+			res.ManifestDiff, err = helm.DiffManifests("", newRelease.Manifest)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			c.WriteResult(w, r, res)
+			return
+		}
+
+		if cName := ch.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
+			if _, err := createReleaseFromHelmRelease(c.Config(), targetCluster.ProjectID, targetCluster.ID, newRelease); err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+	} else {
+		conf := &helm.UpgradeReleaseConfig{
+			Name:       request.TargetName,
+			Values:     values,
+			Cluster:    targetCluster,
+			Repo:       c.Repo(),
+			Registries: registries,
+			Chart:      ch,
+			DryRun:     request.DryRun,
+		}
+
+		if request.DryRun {
+			dryRunRelease, err := targetHelmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+				return
+			}
+
+			res.ManifestDiff, err = helm.DiffManifests(targetRelease.Manifest, dryRunRelease.Manifest)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			c.WriteResult(w, r, res)
+			return
+		}
+
+		valuesBytes, err := json.Marshal(values)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		// approving a deploy request upgrades the latest chart of the target release,
+		// unless the deploy request has a chart version
+		chartVersion := request.ChartVersion
+
+		if chartVersion == "" && ch.Metadata.Version != targetRelease.Chart.Metadata.Version {
+			chartVersion = ch.Metadata.Version
+		}
+
+		deployRequest, reqErr := createDeployRequestIfProtected(c.Config(), &models.DeployRequest{
+			ProjectID:         targetCluster.ProjectID,
+			ClusterID:         targetCluster.ID,
+			Namespace:         request.TargetNamespace,
+			Name:              request.TargetName,
+			Source:            types.DeployRequestSourcePromote,
+			ChartVersion:      chartVersion,
+			Values:            valuesBytes,
+			RequestedByUserID: user.ID,
+		})
+
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		if deployRequest != nil {
+			res.DeployRequest = deployRequest.ToDeployRequestType()
+
+			w.WriteHeader(http.StatusAccepted)
+			c.WriteResult(w, r, res)
+
+			return
+		}
+
+		res.RolloutStrategy, reqErr = startRolloutIfConfigured(c.Config(), targetCluster, targetHelmAgent, targetRelease, conf)
+
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		if res.RolloutStrategy != "" {
+			if reqErr := addPromotedEnvGroupLinks(targetHelmAgent, request, envGroups); reqErr != nil {
+				c.HandleAPIError(w, r, reqErr)
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+			c.WriteResult(w, r, res)
+
+			return
+		}
+
+		newRelease, err := targetHelmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		if cName := newRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
+			if rel, err := c.Repo().Release().ReadRelease(targetCluster.ID, request.TargetName, request.TargetNamespace); err == nil {
+				if err := updateReleaseRepo(c.Config(), rel, newRelease); err != nil {
+					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+					return
+				}
+			}
+		}
+
+		WatchRevisionHealth(c.Config(), targetCluster, targetHelmAgent, newRelease)
+	}
+
+	if reqErr := addPromotedEnvGroupLinks(targetHelmAgent, request, envGroups); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// getPromotionTargetRelease reads the latest revision of the target release of a
+// promotion. It returns nil if the target release does not exist.
+func getPromotionTargetRelease(helmAgent *helm.Agent, name string) (*release.Release, error) {
+	if _, err := helmAgent.GetReleaseHistory(name); errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return helmAgent.GetRelease(name, 0, false)
+}
+
+// checkPromotionTargetAccess checks that the policy of the caller permits the verb on
+// the target release of a promotion
+func checkPromotionTargetAccess(
+	config *config.Config,
+	r *http.Request,
+	user *models.User,
+	targetCluster *models.Cluster,
+	request *types.PromoteReleaseRequest,
+	verb types.APIVerb,
+) apierrors.RequestError {
+	loader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole(), config.Repo.Team())
+
+	opts := &policy.PolicyLoaderOpts{
+		ProjectID: targetCluster.ProjectID,
+		UserID:    user.ID,
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		opts.APIToken = apiToken
+	}
+
+	policyDocs, reqErr := loader.LoadPolicyDocuments(opts)
+
+	if reqErr != nil {
+		return reqErr
+	}
+
+	reqScopes := map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope:   {Verb: verb, Resource: types.NameOrUInt{UInt: targetCluster.ProjectID}},
+		types.ClusterScope:   {Verb: verb, Resource: types.NameOrUInt{UInt: targetCluster.ID}},
+		types.NamespaceScope: {Verb: verb, Resource: types.NameOrUInt{Name: request.TargetNamespace}},
+	}
+
+	// releases are created in the namespace scope, like the create release endpoint
+	if verb != types.APIVerbCreate {
+		reqScopes[types.ReleaseScope] = &types.RequestAction{Verb: verb, Resource: types.NameOrUInt{Name: request.TargetName}}
+	}
+
+	if !policy.HasScopeAccess(policyDocs, reqScopes) {
+		return apierrors.NewErrForbidden(fmt.Errorf(
+			"policy forbids %s of release %s in cluster %d, namespace %s for user %d",
+			verb, request.TargetName, targetCluster.ID, request.TargetNamespace, user.ID,
+		))
+	}
+
+	return nil
+}
+
+// resolvePromotedEnvGroups checks that the env groups synced to a promoted release
+// exist in the target namespace, and sets the synced versions to their latest versions
+// in the target namespace. It returns the names of the env groups.
+func resolvePromotedEnvGroups(
+	targetHelmAgent *helm.Agent,
+	targetNamespace string,
+	values map[string]interface{},
+) ([]string, apierrors.RequestError) {
+	envGroups := helm.GetSyncedEnvGroups(values)
+
+	if len(envGroups) == 0 {
+		return envGroups, nil
+	}
+
+	if targetHelmAgent.K8sAgent == nil {
+		return nil, apierrors.NewErrInternal(fmt.Errorf("cannot read env groups without a kubernetes agent"))
+	}
+
+	versions := make(map[string]uint)
+
+	for _, name := range envGroups {
+		_, version, err := targetHelmAgent.K8sAgent.GetLatestVersionedConfigMap(name, targetNamespace)
+
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("env group %s does not exist in namespace %s", name, targetNamespace),
+				http.StatusBadRequest,
+			)
+		} else if err != nil {
+			return nil, apierrors.NewErrInternal(err)
+		}
+
+		versions[name] = version
+	}
+
+	helm.SetSyncedEnvGroupVersions(values, versions)
+
+	return envGroups, nil
+}
+
+// addPromotedEnvGroupLinks adds the target release of a promotion to the applications
+// of its env groups. Links are only added once the promoted release exists, so that
+// the env groups do not sync to releases which failed to install.
+func addPromotedEnvGroupLinks(
+	targetHelmAgent *helm.Agent,
+	request *types.PromoteReleaseRequest,
+	envGroups []string,
+) apierrors.RequestError {
+	for _, name := range envGroups {
+		cm, _, err := targetHelmAgent.K8sAgent.GetLatestVersionedConfigMap(name, request.TargetNamespace)
+
+		if err == nil {
+			_, err = targetHelmAgent.K8sAgent.AddApplicationToVersionedConfigMap(cm, request.TargetName)
+		}
+
+		if err != nil {
+			return apierrors.NewErrInternal(
+				fmt.Errorf("release was promoted, but env group %s could not be linked: %w", name, err),
+			)
+		}
+	}
+
+	return nil
+}
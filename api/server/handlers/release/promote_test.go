@@ -0,0 +1,240 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+var webChart = &chart.Chart{
+	Metadata: &chart.Metadata{
+		APIVersion: "v2",
+		Name:       "web",
+		Version:    "0.1.0",
+	},
+	Templates: []*chart.File{
+		{
+			Name: "templates/configmap.yaml",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\ndata:\n  image: \"{{ .Values.image.repository }}:{{ .Values.image.tag }}\"\n  host: \"{{ .Values.host }}\"\n"),
+		},
+	},
+}
+
+var stagingRelease = &helmrelease.Release{
+	Name:      "web",
+	Namespace: "staging",
+	Version:   3,
+	Chart:     webChart,
+	Config: map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "gcr.io/project/web",
+			"tag":        "abc123",
+		},
+		"host": "staging.example.com",
+	},
+	Info: &helmrelease.Info{
+		Status: helmrelease.StatusDeployed,
+	},
+}
+
+func setupClusterTest(t *testing.T) (*config.Config, *models.User, *models.Project, *models.Cluster) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{
+		ProjectID: proj.ID,
+		Name:      "cluster-test",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config, user, proj, cluster
+}
+
+func promoteRelease(
+	t *testing.T,
+	config *config.Config,
+	user *models.User,
+	proj *models.Project,
+	cluster *models.Cluster,
+	targetHelmAgent *helm.Agent,
+	request *types.PromoteReleaseRequest,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/clusters/1/namespaces/staging/releases/web/0/promote",
+		request,
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, types.ReleaseScope, stagingRelease)
+	ctx = context.WithValue(ctx, authz.HelmAgentCtxKey, targetHelmAgent)
+	req = req.WithContext(ctx)
+
+	release.NewPromoteReleaseHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func newTargetHelmAgent(namespace string) *helm.Agent {
+	agent := helm.GetAgentTesting(&helm.Form{Namespace: namespace}, nil, logger.NewConsole(true), nil)
+	agent.ActionConfig.Releases.Driver.(*driver.Memory).SetNamespace(namespace)
+
+	return agent
+}
+
+func TestPromoteReleaseDryRun(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+	targetHelmAgent := newTargetHelmAgent("production")
+
+	rr := promoteRelease(t, config, user, proj, cluster, targetHelmAgent, &types.PromoteReleaseRequest{
+		TargetClusterID: cluster.ID,
+		TargetNamespace: "production",
+		ImageTag:        "def456",
+		DryRun:          true,
+	})
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	res := &types.PromoteReleaseResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(res.Created)
+
+	if assert.Len(res.ManifestDiff, 1) {
+		assert.Equal(types.ManifestDiffStatusAdded, res.ManifestDiff[0].Status)
+		assert.Contains(res.ManifestDiff[0].Diff, "gcr.io/project/web:def456")
+	}
+
+	// a dry run should not install the release
+	_, err := targetHelmAgent.GetReleaseHistory("web")
+
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+}
+
+func TestPromoteRelease(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+	targetHelmAgent := newTargetHelmAgent("production")
+
+	rr := promoteRelease(t, config, user, proj, cluster, targetHelmAgent, &types.PromoteReleaseRequest{
+		TargetClusterID: cluster.ID,
+		TargetNamespace: "production",
+		ImageTag:        "def456",
+		Values:          "host: example.com",
+	})
+
+	assert.Equal(http.StatusOK, rr.Code)
+
+	rel, err := targetHelmAgent.GetRelease("web", 0, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal("production", rel.Namespace)
+	assert.Equal("0.1.0", rel.Chart.Metadata.Version)
+	assert.Contains(rel.Manifest, "gcr.io/project/web:def456")
+	assert.Contains(rel.Manifest, "host: example.com")
+
+	// the source values should not be modified by the overrides
+	assert.Equal("abc123", stagingRelease.Config["image"].(map[string]interface{})["tag"])
+
+	// the promoted release should be created in the database, so that it can be
+	// deployed with a webhook
+	dbRelease, err := config.Repo.Release().ReadRelease(cluster.ID, "web", "production")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal("gcr.io/project/web", dbRelease.ImageRepoURI)
+
+	// promoting to a cluster outside of the project, or to the source release, should fail
+	rr = promoteRelease(t, config, user, proj, cluster, targetHelmAgent, &types.PromoteReleaseRequest{
+		TargetClusterID: 2,
+		TargetNamespace: "production",
+	})
+
+	assert.Equal(http.StatusNotFound, rr.Code)
+
+	rr = promoteRelease(t, config, user, proj, cluster, targetHelmAgent, &types.PromoteReleaseRequest{
+		TargetClusterID: cluster.ID,
+		TargetNamespace: "staging",
+	})
+
+	assert.Equal(http.StatusBadRequest, rr.Code)
+}
+
+func TestPromoteReleaseViewer(t *testing.T) {
+	config, _, proj, cluster := setupClusterTest(t)
+
+	viewer, err := config.Repo.User().CreateUser(&models.User{
+		Email:         "viewer@test.it",
+		EmailVerified: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+		Role: types.Role{
+			UserID:    viewer.ID,
+			ProjectID: proj.ID,
+			Kind:      types.RoleViewer,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// viewers can read the source release, but cannot create the target release
+	rr := promoteRelease(t, config, viewer, proj, cluster, newTargetHelmAgent("production"), &types.PromoteReleaseRequest{
+		TargetClusterID: cluster.ID,
+		TargetNamespace: "production",
+	})
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
@@ -0,0 +1,109 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type ReconcileReleaseDriftHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewReconcileReleaseDriftHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ReconcileReleaseDriftHandler {
+	return &ReconcileReleaseDriftHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP re-applies the stored manifest of the latest revision of a release, which
+// reverts the drifted fields of the live objects. The manifest is not rendered again,
+// so overlays and image digests are not re-resolved, but it is checked against the
+// current deploy policy of the project. Since the deployed state of the release does
+// not change, this does not require a deploy request for protected releases.
+func (c *ReconcileReleaseDriftHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the release in the request scope may be an older revision, so the latest
+	// revision is re-applied
+	latestRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	drift, err := detectReleaseDrift(c.KubernetesAgentGetter, r, cluster, latestRelease)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !drift.Drifted {
+		c.WriteResult(w, r, &types.ReconcileReleaseDriftResponse{
+			GetReleaseDriftResponse: *drift,
+		})
+
+		return
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	newRelease, err := helmAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+		Name:       latestRelease.Name,
+		Values:     latestRelease.Config,
+		Manifest:   latestRelease.Manifest,
+		Cluster:    cluster,
+		Repo:       c.Repo(),
+		Registries: registries,
+	}, c.Config().DOConf)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error re-applying release: %s", err.Error()),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	drift, err = detectReleaseDrift(c.KubernetesAgentGetter, r, cluster, newRelease)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ReconcileReleaseDriftResponse{
+		GetReleaseDriftResponse: *drift,
+		Reconciled:              true,
+	})
+}
@@ -1,9 +1,11 @@
 package release
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 
@@ -15,7 +17,11 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -57,6 +63,47 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// dry runs do not change the release, so they are allowed for protected releases
+	if request.DryRun {
+		diff, reqErr := getUpgradeManifestDiff(c.Config(), helmAgent, cluster, helmRelease, request)
+
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		c.WriteResult(w, r, &types.UpgradeReleaseResponse{
+			ManifestDiff: diff,
+		})
+
+		return
+	}
+
+	deployRequest, reqErr := createDeployRequestIfProtected(c.Config(), &models.DeployRequest{
+		ProjectID:         cluster.ProjectID,
+		ClusterID:         cluster.ID,
+		Namespace:         helmRelease.Namespace,
+		Name:              helmRelease.Name,
+		Source:            types.DeployRequestSourceUpgrade,
+		ChartVersion:      request.ChartVersion,
+		Values:            []byte(request.Values),
+		RequestedByUserID: user.ID,
+	})
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if deployRequest != nil {
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, &types.UpgradeReleaseResponse{
+			DeployRequest: deployRequest.ToDeployRequestType(),
+		})
+
+		return
+	}
+
 	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
 
 	if err != nil {
@@ -73,48 +120,43 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	// if the chart version is set, load a chart from the repo
 	if request.ChartVersion != "" {
-		cache := c.Config().URLCache
-		chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
+		ch, reqErr := LoadUpgradeChart(c.Config(), cluster.ProjectID, helmRelease.Chart.Metadata.Name, request.ChartVersion)
 
-		if !foundFirst {
-			cache.Update()
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
 
-			var found bool
+		conf.Chart = ch
+	}
 
-			chartRepoURL, found = cache.GetURL(helmRelease.Chart.Metadata.Name)
+	valuesYaml, err := chartutil.ReadValues([]byte(request.Values))
 
-			if !found {
-				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-					fmt.Errorf("chart not found"),
-					http.StatusBadRequest,
-				))
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		))
 
-				return
-			}
-		}
+		return
+	}
 
-		chart, err := LoadChart(c.Config(), &LoadAddonChartOpts{
-			ProjectID:       cluster.ProjectID,
-			RepoURL:         chartRepoURL,
-			TemplateName:    helmRelease.Chart.Metadata.Name,
-			TemplateVersion: request.ChartVersion,
-		})
+	conf.Values = valuesYaml
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
+	strategy, reqErr := startRolloutIfConfigured(c.Config(), cluster, helmAgent, helmRelease, conf)
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("chart not found"),
-				http.StatusBadRequest,
-			))
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
 
-			return
-		}
+	if strategy != "" {
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, &types.UpgradeReleaseResponse{
+			RolloutStrategy: strategy,
+		})
 
-		conf.Chart = chart
+		return
 	}
 
 	newHelmRelease, upgradeErr := helmAgent.UpgradeRelease(conf, request.Values, c.Config().DOConf)
@@ -174,6 +216,8 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	WatchRevisionHealth(c.Config(), cluster, helmAgent, helmRelease)
+
 	if helmRelease.Chart != nil && helmRelease.Chart.Metadata.Name != "job" {
 		notifyOpts.Status = slack.StatusHelmDeployed
 		notifyOpts.Version = helmRelease.Version
@@ -229,4 +273,310 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			}
 		}
 	}
+
+	c.WriteResult(w, r, &types.UpgradeReleaseResponse{})
+}
+
+// LoadUpgradeChart loads a version of a release's chart from the chart repository
+// which the chart was installed from
+func LoadUpgradeChart(
+	config *config.Config,
+	projectID uint,
+	chartName, chartVersion string,
+) (*chart.Chart, apierrors.RequestError) {
+	cache := config.URLCache
+	chartRepoURL, foundFirst := cache.GetURL(chartName)
+
+	if !foundFirst {
+		cache.Update()
+
+		var found bool
+
+		chartRepoURL, found = cache.GetURL(chartName)
+
+		if !found {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("chart not found"),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	ch, err := LoadChart(config, &LoadAddonChartOpts{
+		ProjectID:       projectID,
+		RepoURL:         chartRepoURL,
+		TemplateName:    chartName,
+		TemplateVersion: chartVersion,
+	})
+
+	if err != nil {
+		return nil, NewLoadChartError(err)
+	}
+
+	return ch, nil
+}
+
+// getUpgradeManifestDiff renders the upgrade of a release without applying it, and
+// diffs the rendered manifest against the manifest of the latest revision
+func getUpgradeManifestDiff(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	helmRelease *release.Release,
+	request *types.UpgradeReleaseRequest,
+) ([]*types.ManifestDiff, apierrors.RequestError) {
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       helmRelease.Name,
+		Cluster:    cluster,
+		Repo:       config.Repo,
+		Registries: registries,
+		DryRun:     true,
+	}
+
+	if request.ChartVersion != "" {
+		ch, reqErr := LoadUpgradeChart(config, cluster.ProjectID, helmRelease.Chart.Metadata.Name, request.ChartVersion)
+
+		if reqErr != nil {
+			return nil, reqErr
+		}
+
+		conf.Chart = ch
+	}
+
+	// the release in the request scope may be an older revision, so the latest
+	// revision is read to diff against
+	deployedRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	dryRunRelease, err := helmAgent.UpgradeRelease(conf, request.Values, config.DOConf)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	diff, err := helm.DiffManifests(deployedRelease.Manifest, dryRunRelease.Manifest)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return diff, nil
+}
+
+// createDeployRequestIfProtected creates a pending deploy request for an upgrade if
+// the release or its namespace is protected. If neither is protected, it returns
+// nil and the upgrade should run immediately.
+func createDeployRequestIfProtected(
+	config *config.Config,
+	dr *models.DeployRequest,
+) (*models.DeployRequest, apierrors.RequestError) {
+	_, err := config.Repo.ProtectedResource().ReadProtectedResourceForRelease(dr.ProjectID, dr.ClusterID, dr.Namespace, dr.Name)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	// the upgrade runs when the deploy request is approved, so the values are
+	// validated now rather than when the upgrade fails
+	if _, err := chartutil.ReadValues(dr.Values); err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		)
+	}
+
+	dr.Status = types.DeployRequestStatusPending
+
+	dr, err = config.Repo.DeployRequest().CreateDeployRequest(dr)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return dr, nil
+}
+
+// UpgradeFromDeployRequest runs the upgrade of an approved deploy request against the
+// latest revision of the release. If the release is configured for a canary or
+// blue-green rollout, the upgrade is rolled out in the background and the returned
+// release is nil.
+func UpgradeFromDeployRequest(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	dr *models.DeployRequest,
+) (*release.Release, apierrors.RequestError) {
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       dr.Name,
+		Cluster:    cluster,
+		Repo:       config.Repo,
+		Registries: registries,
+	}
+
+	latestRelease, err := helmAgent.GetRelease(dr.Name, 0, false)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	if dr.ChartVersion != "" {
+		ch, reqErr := LoadUpgradeChart(config, cluster.ProjectID, latestRelease.Chart.Metadata.Name, dr.ChartVersion)
+
+		if reqErr != nil {
+			return nil, reqErr
+		}
+
+		conf.Chart = ch
+	}
+
+	valuesYaml, err := chartutil.ReadValues(dr.Values)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		)
+	}
+
+	conf.Values = valuesYaml
+
+	strategy, reqErr := startRolloutIfConfigured(config, cluster, helmAgent, latestRelease, conf)
+
+	if reqErr != nil {
+		return nil, reqErr
+	} else if strategy != "" {
+		return nil, nil
+	}
+
+	helmRelease, err := helmAgent.UpgradeReleaseByValues(conf, config.DOConf)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
+		if rel, err := config.Repo.Release().ReadRelease(cluster.ID, dr.Name, dr.Namespace); err == nil {
+			if err := updateReleaseRepo(config, rel, helmRelease); err != nil {
+				return nil, apierrors.NewErrInternal(err)
+			}
+		}
+	}
+
+	return helmRelease, nil
+}
+
+// startRolloutIfConfigured starts a canary or blue-green rollout of an upgrade if the
+// release is configured for one. It returns the strategy of the rollout, or an empty
+// strategy if the upgrade should be applied directly. The values of the upgrade must
+// be set in the upgrade config. The rollout runs in the background, and its progress
+// is recorded in the steps of the release.
+func startRolloutIfConfigured(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	conf *helm.UpgradeReleaseConfig,
+) (types.RolloutStrategy, apierrors.RequestError) {
+	if helmAgent.K8sAgent == nil || helmRelease.Chart == nil || helmRelease.Chart.Metadata.Name != "web" {
+		return "", nil
+	}
+
+	rel, err := config.Repo.Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	// releases which were not created through Porter cannot be configured for rollouts
+	if err != nil {
+		return "", nil
+	}
+
+	rolloutConf, err := getReleaseRolloutConfig(config, rel)
+
+	if err != nil {
+		return "", apierrors.NewErrInternal(err)
+	}
+
+	if rolloutConf.Strategy == types.RolloutStrategyRolling {
+		return "", nil
+	}
+
+	canary, err := helmAgent.PrepareRollout(conf, config.DOConf)
+
+	if errors.Is(err, kubernetes.ErrCanaryExists) {
+		return "", apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a rollout of this release is already in progress"),
+			http.StatusConflict,
+		)
+	} else if err != nil {
+		return "", apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	healthDeadline := config.ServerConf.AutoRollbackDeadline
+
+	if healthDeadline == 0 {
+		healthDeadline = 5 * time.Minute
+	}
+
+	helmRolloutConf := &helm.RolloutConfig{
+		Strategy:       rolloutConf.Strategy,
+		Steps:          rolloutConf.GetSteps(),
+		StepInterval:   time.Duration(rolloutConf.StepIntervalSeconds) * time.Second,
+		MaxErrorRate:   rolloutConf.MaxErrorRate,
+		HealthDeadline: healthDeadline,
+	}
+
+	go func() {
+		stepIndex := int64(400)
+
+		observe := func(status types.EventStatus, info string) {
+			step := &models.SubEvent{
+				EventID: "rollout",
+				Name:    "Rollout",
+				Index:   stepIndex,
+				Status:  status,
+				Info:    info,
+			}
+
+			// the outcome of the rollout is always the last step
+			if status != types.EventStatusInProgress {
+				step.Index = 499
+			} else if stepIndex < 498 {
+				stepIndex++
+			}
+
+			if err := appendReleaseStep(config, rel, step); err != nil {
+				config.Logger.Error().Err(err).Msgf("could not record rollout of release %s/%s", helmRelease.Namespace, helmRelease.Name)
+			}
+		}
+
+		newHelmRelease, err := helmAgent.RunRollout(canary, conf, config.DOConf, helmRolloutConf, observe)
+
+		if err != nil {
+			config.Logger.Error().Err(err).Msgf("rollout of release %s/%s failed", helmRelease.Namespace, helmRelease.Name)
+			return
+		}
+
+		if err := updateReleaseRepo(config, rel, newHelmRelease); err != nil {
+			config.Logger.Error().Err(err).Msgf("could not update image repository of release %s/%s", helmRelease.Namespace, helmRelease.Name)
+		}
+
+		WatchRevisionHealth(config, cluster, helmAgent, newHelmRelease)
+	}()
+
+	return rolloutConf.Strategy, nil
 }
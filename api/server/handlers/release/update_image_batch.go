@@ -1,9 +1,9 @@
 package release
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -33,6 +33,7 @@ func NewUpdateImageBatchHandler(
 }
 
 func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
 	helmAgent, err := c.GetHelmAgent(r, cluster, "")
@@ -70,6 +71,14 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	var wg sync.WaitGroup
 	mu := &sync.Mutex{}
 	errors := make([]string, 0)
+	deployRequests := make([]*types.DeployRequest, 0)
+
+	addError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errors = append(errors, err.Error())
+	}
 
 	for i := range releases {
 		index := i
@@ -81,9 +90,8 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 			rel, err := helmAgent.GetRelease(releases[index].Name, 0, false)
 
 			if err != nil {
-				mu.Lock()
-				errors = append(errors, err.Error())
-				mu.Unlock()
+				addError(err)
+				return
 			}
 
 			if rel.Chart.Name() == "job" {
@@ -93,6 +101,34 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 				rel.Config["image"] = image
 				rel.Config["paused"] = true
 
+				values, err := json.Marshal(rel.Config)
+
+				if err != nil {
+					addError(err)
+					return
+				}
+
+				deployRequest, reqErr := createDeployRequestIfProtected(c.Config(), &models.DeployRequest{
+					ProjectID:         cluster.ProjectID,
+					ClusterID:         cluster.ID,
+					Namespace:         releases[index].Namespace,
+					Name:              releases[index].Name,
+					Source:            types.DeployRequestSourceUpdateImageBatch,
+					Values:            values,
+					RequestedByUserID: user.ID,
+				})
+
+				if reqErr != nil {
+					addError(reqErr)
+					return
+				} else if deployRequest != nil {
+					mu.Lock()
+					deployRequests = append(deployRequests, deployRequest.ToDeployRequestType())
+					mu.Unlock()
+
+					return
+				}
+
 				conf := &helm.UpgradeReleaseConfig{
 					Name:       releases[index].Name,
 					Cluster:    cluster,
@@ -104,9 +140,7 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 				_, err = helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
 
 				if err != nil {
-					mu.Lock()
-					errors = append(errors, err.Error())
-					mu.Unlock()
+					addError(err)
 				}
 			}
 		}()
@@ -114,12 +148,14 @@ func (c *UpdateImageBatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 
 	wg.Wait()
 
+	// the deploy requests which were created are returned along with the errors, since
+	// they are pending approval even though other releases could not be updated
 	if len(errors) > 0 {
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-			fmt.Errorf("errors while deploying: %s", strings.Join(errors, ",")),
-			http.StatusBadRequest,
-		))
-
-		return
+		w.WriteHeader(http.StatusMultiStatus)
 	}
+
+	c.WriteResult(w, r, &types.UpdateImageBatchResponse{
+		DeployRequests: deployRequests,
+		Errors:         errors,
+	})
 }
@@ -0,0 +1,118 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+)
+
+func TestUpdateImageBatchPartialResults(t *testing.T) {
+	assert := assert.New(t)
+
+	config, user, proj, cluster := setupClusterTest(t)
+	helmAgent := newTargetHelmAgent("default")
+
+	// "cron" is protected and has a helm release, while "worker" has no helm
+	// release, so it cannot be updated
+	for _, name := range []string{"cron", "worker"} {
+		_, err := config.Repo.Release().CreateRelease(&models.Release{
+			ProjectID:    proj.ID,
+			ClusterID:    cluster.ID,
+			Name:         name,
+			Namespace:    "default",
+			WebhookToken: name,
+			ImageRepoURI: "gcr.io/project/job",
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := config.Repo.ProtectedResource().CreateProtectedResource(&models.ProtectedResource{
+		ProjectID:   proj.ID,
+		ClusterID:   cluster.ID,
+		Namespace:   "default",
+		ReleaseName: "cron",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = helmAgent.ActionConfig.Releases.Create(&helmrelease.Release{
+		Name:      "cron",
+		Namespace: "default",
+		Version:   1,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "job",
+				Version:    "0.1.0",
+			},
+		},
+		Config: map[string]interface{}{},
+		Info: &helmrelease.Info{
+			Status: helmrelease.StatusDeployed,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/clusters/1/namespaces/default/releases/image/batch",
+		&types.UpdateImageBatchRequest{
+			ImageRepoURI: "gcr.io/project/job",
+			Tag:          "def456",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, authz.HelmAgentCtxKey, helmAgent)
+	req = req.WithContext(ctx)
+
+	release.NewUpdateImageBatchHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	assert.Equal(http.StatusMultiStatus, rr.Code)
+
+	res := &types.UpdateImageBatchResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+
+	// the deploy request of the protected release is returned along with the error
+	if assert.Len(res.DeployRequests, 1) {
+		assert.Equal("cron", res.DeployRequests[0].Name)
+		assert.Equal(types.DeployRequestStatusPending, res.DeployRequests[0].Status)
+	}
+
+	assert.Len(res.Errors, 1)
+
+	deployRequests, err := config.Repo.DeployRequest().ListDeployRequestsByProjectID(proj.ID, "")
+
+	assert.NoError(err)
+	assert.Len(deployRequests, 1)
+}
@@ -0,0 +1,96 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UpdateOverlaysHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateOverlaysHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateOverlaysHandler {
+	return &UpdateOverlaysHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces the overlays of a release. The release does not need to exist
+// yet, so that overlays can be applied when it is first installed, so the route is not
+// in the release scope and access to the release is checked here.
+func (c *UpdateOverlaysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	if !authz.HasResourceVerbAccess(r, types.APIVerbUpdate, map[types.PermissionScope]types.NameOrUInt{
+		types.ReleaseScope: {Name: name},
+	}) {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user does not have access to update release %s", name),
+		))
+
+		return
+	}
+
+	request := &types.UpdateManifestOverlaysRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	newOverlays := make([]*models.ManifestOverlay, 0, len(request.Overlays))
+
+	for i, overlay := range request.Overlays {
+		patch, err := helm.EncodeManifestOverlayPatch(overlay)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("invalid patch for overlay %d: %w", i, err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		newOverlays = append(newOverlays, &models.ManifestOverlay{
+			ProjectID:   cluster.ProjectID,
+			ClusterID:   cluster.ID,
+			Namespace:   namespace,
+			ReleaseName: name,
+			Kind:        overlay.Kind,
+			Name:        overlay.Name,
+			PatchType:   overlay.PatchType,
+			Patch:       patch,
+		})
+	}
+
+	newOverlays, err := c.Repo().ManifestOverlay().ReplaceManifestOverlays(cluster.ID, namespace, name, newOverlays)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListManifestOverlaysResponse, 0, len(newOverlays))
+
+	for _, overlay := range newOverlays {
+		res = append(res, overlay.ToManifestOverlayType())
+	}
+
+	c.WriteResult(w, r, res)
+}
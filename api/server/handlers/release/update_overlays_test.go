@@ -0,0 +1,175 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func overlaysRequest(
+	t *testing.T,
+	method string,
+	cluster *models.Cluster,
+	request *types.UpdateManifestOverlaysRequest,
+) (*http.Request, *httptest.ResponseRecorder) {
+	var requestObj interface{}
+
+	if request != nil {
+		requestObj = request
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		method,
+		"/api/projects/1/clusters/1/namespaces/default/releases/web/overlays",
+		requestObj,
+	)
+
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamReleaseName): "web",
+	})
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, types.NamespaceScope, "default")
+
+	return req.WithContext(ctx), rr
+}
+
+func updateOverlays(
+	t *testing.T,
+	config *config.Config,
+	cluster *models.Cluster,
+	request *types.UpdateManifestOverlaysRequest,
+) *httptest.ResponseRecorder {
+	req, rr := overlaysRequest(t, string(types.HTTPVerbPost), cluster, request)
+
+	release.NewUpdateOverlaysHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func getOverlays(t *testing.T, config *config.Config, cluster *models.Cluster) types.ListManifestOverlaysResponse {
+	req, rr := overlaysRequest(t, string(types.HTTPVerbGet), cluster, nil)
+
+	release.NewGetOverlaysHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	res := make(types.ListManifestOverlaysResponse, 0)
+
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestUpdateOverlays(t *testing.T) {
+	assert := assert.New(t)
+
+	config, _, _, cluster := setupClusterTest(t)
+
+	replicasPatch := &types.ManifestOverlay{
+		Kind:      "Deployment",
+		Name:      "web",
+		PatchType: types.ManifestOverlayPatchTypeJSON,
+		Patch: []interface{}{
+			map[string]interface{}{"op": "replace", "path": "/spec/replicas", "value": float64(3)},
+		},
+	}
+
+	labelsPatch := &types.ManifestOverlay{
+		Kind:      "Service",
+		PatchType: types.ManifestOverlayPatchTypeStrategicMerge,
+		Patch: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"team": "platform"},
+			},
+		},
+	}
+
+	rr := updateOverlays(t, config, cluster, &types.UpdateManifestOverlaysRequest{
+		Overlays: []*types.ManifestOverlay{replicasPatch, labelsPatch},
+	})
+
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal(types.ListManifestOverlaysResponse{replicasPatch, labelsPatch}, getOverlays(t, config, cluster))
+
+	// overlays are replaced rather than appended to
+	rr = updateOverlays(t, config, cluster, &types.UpdateManifestOverlaysRequest{
+		Overlays: []*types.ManifestOverlay{labelsPatch},
+	})
+
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal(types.ListManifestOverlaysResponse{labelsPatch}, getOverlays(t, config, cluster))
+
+	// invalid patches are rejected without changing the stored overlays
+	rr = updateOverlays(t, config, cluster, &types.UpdateManifestOverlaysRequest{
+		Overlays: []*types.ManifestOverlay{{
+			Kind:      "Deployment",
+			PatchType: types.ManifestOverlayPatchTypeJSON,
+			Patch:     map[string]interface{}{"spec": map[string]interface{}{}},
+		}},
+	})
+
+	assert.Equal(http.StatusBadRequest, rr.Code)
+	assert.Equal(types.ListManifestOverlaysResponse{labelsPatch}, getOverlays(t, config, cluster))
+}
+
+func TestUpdateOverlaysForbidden(t *testing.T) {
+	config, _, _, cluster := setupClusterTest(t)
+
+	req, rr := overlaysRequest(t, string(types.HTTPVerbPost), cluster, &types.UpdateManifestOverlaysRequest{})
+
+	// the policy only grants access to the release "api" in the namespace
+	ctx := context.WithValue(req.Context(), types.PolicyDocsCtxKey, []*types.PolicyDocument{{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadWriteVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadWriteVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Children: map[types.PermissionScope]*types.PolicyDocument{
+							types.ReleaseScope: {
+								Scope:     types.ReleaseScope,
+								Verbs:     types.ReadWriteVerbGroup(),
+								Resources: []types.NameOrUInt{{Name: "api"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}})
+
+	release.NewUpdateOverlaysHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	).ServeHTTP(rr, req.WithContext(ctx))
+
+	apitest.AssertResponseForbidden(t, rr)
+}
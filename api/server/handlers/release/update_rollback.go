@@ -11,6 +11,8 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/porter-dev/porter/internal/models"
 	"helm.sh/helm/v3/pkg/release"
 )
@@ -129,3 +131,115 @@ func updateReleaseRepo(config *config.Config, release *models.Release, helmRelea
 
 	return nil
 }
+
+// WatchRevisionHealth waits in the background for the controllers of a new revision
+// to become healthy. If a pod crash loops, or the controllers are not healthy by the
+// auto-rollback deadline, the release is rolled back to the last revision which was
+// successfully deployed and the outcome is recorded in the steps of the release.
+func WatchRevisionHealth(
+	config *config.Config,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+) {
+	deadline := config.ServerConf.AutoRollbackDeadline
+
+	if deadline == 0 || helmAgent.K8sAgent == nil || helmRelease.Version <= 1 {
+		return
+	}
+
+	controllers := grapher.ParseControllers(grapher.ImportMultiDocYAML([]byte(helmRelease.Manifest)))
+
+	go func() {
+		healthErr := helmAgent.K8sAgent.WaitForControllersHealthy(helmRelease.Namespace, controllers, deadline)
+
+		if healthErr == nil {
+			return
+		}
+
+		// if the revision has been superseded, the new revision is watched instead
+		latestRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+
+		if err != nil || latestRelease.Version != helmRelease.Version {
+			return
+		}
+
+		step := &models.SubEvent{
+			EventID: "rollback",
+			Name:    "Rollback",
+			Index:   500,
+			Status:  types.EventStatusFailed,
+		}
+
+		prevVersion, err := getRollbackVersion(helmAgent, helmRelease)
+
+		if err != nil {
+			step.Info = fmt.Sprintf(
+				"revision %d could not be rolled back: %s: %s",
+				helmRelease.Version, healthErr.Error(), err.Error(),
+			)
+		} else if err := helmAgent.RollbackRelease(helmRelease.Name, prevVersion); err != nil {
+			config.Logger.Error().Err(err).Msgf("could not roll back release %s/%s", helmRelease.Namespace, helmRelease.Name)
+
+			step.Info = fmt.Sprintf(
+				"revision %d could not be rolled back to revision %d: %s: %s",
+				helmRelease.Version, prevVersion, healthErr.Error(), err.Error(),
+			)
+		} else {
+			step.Status = types.EventStatusSuccess
+			step.Info = fmt.Sprintf(
+				"revision %d was rolled back to revision %d: %s",
+				helmRelease.Version, prevVersion, healthErr.Error(),
+			)
+		}
+
+		rel, err := config.Repo.Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+		// releases which were not created through Porter have no steps to record to
+		if err != nil {
+			return
+		}
+
+		if step.Status == types.EventStatusSuccess {
+			if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
+				if rolledBackRelease, err := helmAgent.GetRelease(helmRelease.Name, 0, false); err == nil {
+					if err := updateReleaseRepo(config, rel, rolledBackRelease); err != nil {
+						config.Logger.Error().Err(err).Msgf("could not update image repository of release %s/%s", helmRelease.Namespace, helmRelease.Name)
+					}
+				}
+			}
+		}
+
+		if err := appendReleaseStep(config, rel, step); err != nil {
+			config.Logger.Error().Err(err).Msgf("could not record rollback of release %s/%s", helmRelease.Namespace, helmRelease.Name)
+		}
+	}()
+}
+
+// getRollbackVersion returns the newest revision before the given revision which was
+// successfully deployed. Revisions which failed or are still pending are skipped.
+func getRollbackVersion(helmAgent *helm.Agent, helmRelease *release.Release) (int, error) {
+	history, err := helmAgent.GetReleaseHistory(helmRelease.Name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	res := 0
+
+	for _, rel := range history {
+		if rel.Version >= helmRelease.Version || rel.Version <= res || rel.Info == nil {
+			continue
+		}
+
+		if rel.Info.Status == release.StatusDeployed || rel.Info.Status == release.StatusSuperseded {
+			res = rel.Version
+		}
+	}
+
+	if res == 0 {
+		return 0, fmt.Errorf("no earlier revision was successfully deployed")
+	}
+
+	return res, nil
+}
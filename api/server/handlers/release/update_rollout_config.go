@@ -0,0 +1,108 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type UpdateRolloutConfigHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateRolloutConfigHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateRolloutConfigHandler {
+	return &UpdateRolloutConfigHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateRolloutConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	request := &types.UpdateRolloutConfigRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// fields which are not set keep their current values
+	newConfig, err := getReleaseRolloutConfig(c.Config(), release)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	newConfig.Strategy = request.Strategy
+
+	if len(request.Steps) > 0 {
+		for i := 1; i < len(request.Steps); i++ {
+			if request.Steps[i] <= request.Steps[i-1] {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("rollout steps must be increasing"),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+		}
+
+		newConfig.SetSteps(request.Steps)
+	}
+
+	if request.StepIntervalSeconds != 0 {
+		newConfig.StepIntervalSeconds = request.StepIntervalSeconds
+	}
+
+	if request.MaxErrorRate != 0 {
+		newConfig.MaxErrorRate = request.MaxErrorRate
+	}
+
+	if release.RolloutConfig == 0 {
+		newConfig, err = c.Repo().RolloutConfig().CreateRolloutConfig(newConfig)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		release.RolloutConfig = newConfig.ID
+
+		_, err = c.Repo().Release().UpdateRelease(release)
+	} else {
+		newConfig, err = c.Repo().RolloutConfig().UpdateRolloutConfig(newConfig)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.UpdateRolloutConfigResponse)(newConfig.ToRolloutConfigType()))
+}
@@ -53,41 +53,45 @@ func (c *UpdateReleaseStepsHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := appendReleaseStep(c.Config(), release, &models.SubEvent{
+		EventID: request.Event.EventID,
+		Name:    request.Event.Name,
+		Index:   request.Event.Index,
+		Status:  request.Event.Status,
+		Info:    request.Event.Info,
+	}); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
+
+// appendReleaseStep appends an event to the steps of a release, creating the event
+// container of the release if it does not exist
+func appendReleaseStep(config *config.Config, release *models.Release, event *models.SubEvent) error {
 	if release.EventContainer == 0 {
 		// create new event container
-		container, err := c.Repo().BuildEvent().CreateEventContainer(&models.EventContainer{ReleaseID: release.ID})
+		container, err := config.Repo.BuildEvent().CreateEventContainer(&models.EventContainer{ReleaseID: release.ID})
+
 		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
+			return err
 		}
 
 		release.EventContainer = container.ID
 
-		release, err = c.Repo().Release().UpdateRelease(release)
+		release, err = config.Repo.Release().UpdateRelease(release)
 
 		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
+			return err
 		}
-
 	}
 
-	container, err := c.Repo().BuildEvent().ReadEventContainer(release.EventContainer)
+	container, err := config.Repo.BuildEvent().ReadEventContainer(release.EventContainer)
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		return err
 	}
 
-	if err := c.Repo().BuildEvent().AppendEvent(container, &models.SubEvent{
-		EventContainerID: container.ID,
-		EventID:          request.Event.EventID,
-		Name:             request.Event.Name,
-		Index:            request.Event.Index,
-		Status:           request.Event.Status,
-		Info:             request.Event.Info,
-	}); err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
+	event.EventContainerID = container.ID
+
+	return config.Repo.BuildEvent().AppendEvent(container, event)
 }
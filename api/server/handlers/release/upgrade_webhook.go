@@ -1,6 +1,7 @@
 package release
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -15,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/models"
 	"gorm.io/gorm"
 )
 
@@ -118,6 +120,38 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	values, err := json.Marshal(rel.Config)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the values are computed from the current release, so they are stored in the deploy
+	// request rather than recomputed when it is approved
+	deployRequest, reqErr := createDeployRequestIfProtected(c.Config(), &models.DeployRequest{
+		ProjectID: release.ProjectID,
+		ClusterID: release.ClusterID,
+		Namespace: release.Namespace,
+		Name:      release.Name,
+		Source:    types.DeployRequestSourceWebhook,
+		Values:    values,
+	})
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if deployRequest != nil {
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, &types.UpgradeReleaseResponse{
+			DeployRequest: deployRequest.ToDeployRequestType(),
+		})
+
+		return
+	}
+
 	registries, err := c.Repo().Registry().ListRegistriesByProjectID(release.ProjectID)
 
 	if err != nil {
@@ -166,6 +200,22 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		),
 	}
 
+	strategy, reqErr := startRolloutIfConfigured(c.Config(), cluster, helmAgent, rel, conf)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if strategy != "" {
+		w.WriteHeader(http.StatusAccepted)
+		c.WriteResult(w, r, &types.UpgradeReleaseResponse{
+			RolloutStrategy: strategy,
+		})
+
+		return
+	}
+
 	rel, err = helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
 
 	if err != nil {
@@ -184,6 +234,8 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	WatchRevisionHealth(c.Config(), cluster, helmAgent, rel)
+
 	if rel.Chart != nil && rel.Chart.Metadata.Name != "job" {
 		notifyOpts.Status = slack.StatusHelmDeployed
 		notifyOpts.Version = rel.Version
@@ -5,12 +5,11 @@ import (
 	"strings"
 
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
-	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
-	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/helm/upgrade"
 )
 
@@ -51,10 +50,10 @@ func (t *TemplateGetUpgradeNotesHandler) ServeHTTP(w http.ResponseWriter, r *htt
 		prevVersion = "v0.0.0"
 	}
 
-	chart, err := loader.LoadChartPublic(request.RepoURL, name, version)
+	chart, err := loadChart(t.Config(), r, request.RepoURL, name, version)
 
 	if err != nil {
-		t.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		t.HandleAPIError(w, r, release.NewLoadChartError(err))
 		return
 	}
 
@@ -0,0 +1,49 @@
+package template
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers/release"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/chart"
+	"k8s.io/helm/pkg/repo"
+)
+
+// loadChart loads the chart of a template. Requests in the scope of a project load
+// charts with the credentials of the project's helm repos and registries, while other
+// requests can only load charts from public repos.
+func loadChart(config *config.Config, r *http.Request, repoURL, name, version string) (*chart.Chart, error) {
+	proj, ok := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if !ok {
+		return loader.LoadChartPublic(repoURL, name, version)
+	}
+
+	return release.LoadChart(config, &release.LoadAddonChartOpts{
+		ProjectID:       proj.ID,
+		RepoURL:         repoURL,
+		TemplateName:    name,
+		TemplateVersion: version,
+	})
+}
+
+// loadRepoIndex loads the index of a template repo, with the credentials of the
+// project if the request is in the scope of a project
+func loadRepoIndex(config *config.Config, r *http.Request, repoURL string) (*repo.IndexFile, error) {
+	proj, ok := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if !ok {
+		return loader.LoadRepoIndexPublic(repoURL)
+	}
+
+	client, err := release.GetRepoClient(config, proj.ID, repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.LoadRepoIndex(client, repoURL)
+}
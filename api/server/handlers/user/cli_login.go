@@ -114,10 +114,20 @@ func (c *CLILoginExchangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if reqErr := checkRateLimit(c.Config(), w, r, rateLimitActionCLIExchange, ""); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	// look up the auth code and exchange it for a token
 	authCode, err := c.Repo().AuthCode().ReadAuthCode(request.AuthorizationCode)
 
 	if err != nil || authCode.IsExpired() {
+		if reqErr := recordRateLimitFailure(c.Config(), r, rateLimitActionCLIExchange, ""); reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+
 		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 		return
 	}
@@ -42,13 +42,18 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if reqErr := checkRateLimit(u.Config(), w, r, rateLimitActionLogin, request.Email); reqErr != nil {
+		u.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	// check that passwords match
 	storedUser, err := u.Repo().User().ReadUserByEmail(request.Email)
 
 	// case on user not existing, send forbidden error if not exist
 	if err != nil {
 		if targetErr := gorm.ErrRecordNotFound; errors.Is(err, targetErr) {
-			u.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+			u.handleLoginFailure(w, r, request.Email, apierrors.NewErrForbidden(err))
 			return
 		} else {
 			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -58,12 +63,45 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if err := bcrypt.CompareHashAndPassword([]byte(storedUser.Password), []byte(request.Password)); err != nil {
 		reqErr := apierrors.NewErrPassThroughToClient(fmt.Errorf("incorrect password"), http.StatusUnauthorized)
-		u.HandleAPIError(w, r, reqErr)
+		u.handleLoginFailure(w, r, request.Email, reqErr)
+		return
+	}
+
+	if storedUser.TOTPEnabled {
+		if request.TOTPCode == "" {
+			apierrors.HandleAPIError(u.Config().Logger, u.Config().Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf(types.TOTPRequiredErrorMessage),
+				http.StatusUnauthorized,
+			), true, apierrors.ErrorOpts{
+				Code: types.ErrCodeTOTPRequired,
+			})
+
+			return
+		}
+
+		if ok, err := checkTOTPCode(u.Repo().User(), storedUser, request.TOTPCode); err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		} else if !ok {
+			reqErr := apierrors.NewErrPassThroughToClient(fmt.Errorf("incorrect two-factor authentication code"), http.StatusUnauthorized)
+			u.handleLoginFailure(w, r, request.Email, reqErr)
+			return
+		}
+	}
+
+	if err := u.Config().AuthLimiter.Reset(rateLimitActionLogin, request.Email); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
 	// save the user as authenticated in the session
-	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
+	var redirect string
+
+	if storedUser.TOTPEnabled {
+		redirect, err = authn.SaveUserAuthenticatedWithTOTP(w, r, u.Config(), storedUser)
+	} else {
+		redirect, err = authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
+	}
 
 	if err != nil {
 		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -78,6 +116,16 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	u.WriteResult(w, r, storedUser.ToUserType())
 }
 
+// handleLoginFailure records a failed login attempt before writing the error
+func (u *UserLoginHandler) handleLoginFailure(w http.ResponseWriter, r *http.Request, email string, reqErr apierrors.RequestError) {
+	if err := recordRateLimitFailure(u.Config(), r, rateLimitActionLogin, email); err != nil {
+		u.HandleAPIError(w, r, err)
+		return
+	}
+
+	u.HandleAPIError(w, r, reqErr)
+}
+
 // checkUserRestrictions checks login restrictions specified by environment variables on the
 // Porter instance.
 func checkUserRestrictions(
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/handlers/user"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository/test"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestLoginUserSuccessful(t *testing.T) {
@@ -175,3 +180,190 @@ func TestLoginUserFailingReadUserByEmailMethod(t *testing.T) {
 
 	apitest.AssertResponseInternalServerError(t, rr)
 }
+
+func TestLoginUserTOTPRequired(t *testing.T) {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: "hello",
+		},
+	)
+
+	config := apitest.LoadConfig(t)
+	createTestUserWithTOTP(t, config, "abcde-fghjk")
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Code:  types.ErrCodeTOTPRequired,
+		Error: types.TOTPRequiredErrorMessage,
+	})
+}
+
+func TestLoginUserTOTPSuccessful(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := createTestUserWithTOTP(t, config, "abcde-fghjk")
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: "hello",
+			TOTPCode: totp.GenerateCode(authUser.TOTPSecret, time.Now()),
+		},
+	)
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	expUser := &types.LoginUserResponse{
+		ID:            1,
+		Email:         "test@test.it",
+		EmailVerified: true,
+		TOTPEnabled:   true,
+	}
+
+	gotUser := &types.LoginUserResponse{}
+
+	apitest.AssertResponseExpected(t, rr, expUser, gotUser)
+}
+
+func TestLoginUserTOTPCodeReplayed(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := createTestUserWithTOTP(t, config, "abcde-fghjk")
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	loginReq := &types.LoginUserRequest{
+		Email:    "test@test.it",
+		Password: "hello",
+		TOTPCode: totp.GenerateCode(authUser.TOTPSecret, time.Now()),
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/login", loginReq)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Result().StatusCode)
+	}
+
+	// the same code should not be accepted again within its validity window
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/login", loginReq)
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "incorrect two-factor authentication code",
+	})
+}
+
+func TestLoginUserTOTPIncorrectCode(t *testing.T) {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: "hello",
+			TOTPCode: "abcdef",
+		},
+	)
+
+	config := apitest.LoadConfig(t)
+	createTestUserWithTOTP(t, config, "abcde-fghjk")
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "incorrect two-factor authentication code",
+	})
+}
+
+func TestLoginUserTOTPRecoveryCodeSingleUse(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTOTP(t, config, "abcde-fghjk")
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	loginReq := &types.LoginUserRequest{
+		Email:    "test@test.it",
+		Password: "hello",
+		TOTPCode: "ABCDE-FGHJK",
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/login", loginReq)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Result().StatusCode)
+	}
+
+	// the recovery code should not be usable a second time
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/login", loginReq)
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "incorrect two-factor authentication code",
+	})
+}
+
+func createTestUserWithTOTP(t *testing.T, config *config.Config, recoveryCode string) *models.User {
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashedCode, err := bcrypt.GenerateFromPassword([]byte(recoveryCode), 8)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authUser.TOTPEnabled = true
+	authUser.TOTPSecret = secret
+	authUser.TOTPRecoveryCodes = string(hashedCode)
+
+	authUser, err = config.Repo.User().UpdateUser(authUser)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return authUser
+}
@@ -0,0 +1,249 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UserOAuthOIDCCallbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCCallbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCCallbackHandler {
+	return &UserOAuthOIDCCallbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	provider := p.Config().OIDCProvider
+
+	if provider == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("oidc login is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, ok := session.Values["state"]; !ok {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state not found in session")))
+		return
+	}
+
+	if r.URL.Query().Get("state") != session.Values["state"] {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state does not match")))
+		return
+	}
+
+	nonce, _ := session.Values["oidc_nonce"].(string)
+
+	if nonce == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("nonce not found in session")))
+		return
+	}
+
+	// the identity provider can redirect with an error instead of a code, for example
+	// if the user is not assigned to the application
+	if errStr := r.URL.Query().Get("error"); errStr != "" {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape("Single sign-on failed: "+errStr), 302)
+		return
+	}
+
+	token, err := provider.Exchange(r.Context(), r.URL.Query().Get("code"))
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	rawIDToken, err := oidc.RawIDToken(token)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	idToken, err := provider.VerifyIDToken(r.Context(), rawIDToken, nonce)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	// some providers only return the email and groups from the userinfo endpoint
+	if idToken.GetStringClaim(p.Config().ServerConf.OIDCEmailClaim) == "" {
+		if err := provider.PopulateUserInfo(r.Context(), idToken, token); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+			return
+		}
+	}
+
+	// otherwise, create the user if not exists
+	user, err := upsertOIDCUserFromIDToken(p.Config(), idToken)
+
+	if err != nil && strings.Contains(err.Error(), "already registered") {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(err.Error()), 302)
+		return
+	} else if err != nil && strings.Contains(err.Error(), "not allowed") {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(err.Error()), 302)
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
+
+	// the nonce is single-use
+	delete(session.Values, "oidc_nonce")
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// non-fatal send email verification
+	if !user.EmailVerified {
+		err = startEmailVerification(p.Config(), w, r, user)
+
+		if err != nil {
+			p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", 302)
+}
+
+func upsertOIDCUserFromIDToken(config *config.Config, idToken *oidc.IDToken) (*models.User, error) {
+	sc := config.ServerConf
+
+	email := strings.ToLower(idToken.GetStringClaim(sc.OIDCEmailClaim))
+
+	if email == "" {
+		return nil, fmt.Errorf("id token does not contain the %s claim", sc.OIDCEmailClaim)
+	}
+
+	if err := checkUserRestrictions(sc, email); err != nil {
+		return nil, err
+	}
+
+	// restrictions are checked on every login, not only on user creation, so that
+	// users that are removed from an allowed group lose access
+	if err := checkOIDCRestrictions(sc, email, idToken.GetStringListClaim(sc.OIDCGroupsClaim)); err != nil {
+		return nil, err
+	}
+
+	user, err := config.Repo.User().ReadUserByOIDCUserID(idToken.Issuer, idToken.Subject)
+
+	// if the user does not exist, create new user
+	if err != nil && err == gorm.ErrRecordNotFound {
+		// check if a user with that email address already exists
+		_, err = config.Repo.User().ReadUserByEmail(email)
+
+		if err == gorm.ErrRecordNotFound {
+			user = &models.User{
+				Email:         email,
+				EmailVerified: !config.Metadata.Email || idToken.GetBoolClaim("email_verified"),
+				OIDCIssuer:    idToken.Issuer,
+				OIDCUserID:    idToken.Subject,
+			}
+
+			user, err = config.Repo.User().CreateUser(user)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = addUserToDefaultProject(config, user)
+
+			if err != nil {
+				return nil, err
+			}
+
+			config.AnalyticsClient.Track(analytics.UserCreateTrack(&analytics.UserCreateTrackOpts{
+				UserScopedTrackOpts: analytics.GetUserScopedTrackOpts(user.ID),
+				Email:               user.Email,
+			}))
+		} else if err == nil {
+			return nil, fmt.Errorf("email already registered")
+		} else if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("unexpected error occurred:%s", err.Error())
+	}
+
+	return user, nil
+}
+
+// checkOIDCRestrictions checks the user's email and groups against the allowed
+// domains and groups. If both are configured, the user must pass both checks.
+func checkOIDCRestrictions(sc *env.ServerConf, email string, groups []string) error {
+	if len(sc.OIDCAllowedDomains) > 0 {
+		allowed := false
+
+		for _, domain := range sc.OIDCAllowedDomains {
+			if strings.HasSuffix(email, "@"+strings.ToLower(strings.TrimPrefix(domain, "@"))) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("email domain is not allowed")
+		}
+	}
+
+	if len(sc.OIDCAllowedGroups) > 0 {
+		allowedGroups := make(map[string]bool)
+
+		for _, group := range sc.OIDCAllowedGroups {
+			allowedGroups[group] = true
+		}
+
+		for _, group := range groups {
+			if allowedGroups[group] {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("user is not allowed to log in: not a member of an allowed group")
+	}
+
+	return nil
+}
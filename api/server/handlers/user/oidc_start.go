@@ -0,0 +1,90 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+type UserOAuthOIDCHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCHandler {
+	return &UserOAuthOIDCHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.Config().OIDCProvider == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("oidc login is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	state := oauth.CreateRandomState()
+
+	// the nonce binds the ID token to this login attempt, so that it cannot be
+	// replayed
+	nonce := oauth.CreateRandomState()
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	session.Values["state"] = state
+	session.Values["oidc_nonce"] = nonce
+
+	// the user is redirected after login, so only paths on this server are accepted to
+	// avoid an open redirect
+	if redirect := r.URL.Query().Get("redirect_uri"); redirect != "" && isRelativeRedirect(redirect) {
+		session.Values["redirect_uri"] = redirect
+	}
+
+	if err := session.Save(r, w); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	url, err := p.Config().OIDCProvider.AuthCodeURL(r.Context(), state, nonce)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	http.Redirect(w, r, url, 302)
+}
+
+// isRelativeRedirect checks that a redirect is a path on the same origin. Browsers
+// treat "//" and "/\" as the start of a host, and strip control characters from urls.
+func isRelativeRedirect(redirect string) bool {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		return false
+	}
+
+	for _, c := range redirect {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,255 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/auth/oidc/oidctest"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCLoginCreatesUser(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "Test@Example.com"
+	iss.Claims["email_verified"] = true
+	iss.Claims["groups"] = []string{"engineering"}
+
+	rr := runOIDCLogin(t, config, iss)
+
+	assert.Equal(t, http.StatusFound, rr.Code)
+	assert.Equal(t, "/dashboard", rr.Header().Get("Location"))
+
+	gotUser, err := config.Repo.User().ReadUserByOIDCUserID(iss.URL(), "oidc-user-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "test@example.com", gotUser.Email)
+	assert.True(t, gotUser.EmailVerified)
+
+	// logging in again should use the same user
+	rr = runOIDCLogin(t, config, iss)
+
+	assert.Equal(t, "/dashboard", rr.Header().Get("Location"))
+
+	users, err := config.Repo.User().ListUsersByIDs([]uint{1, 2})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, users, 1, "expected a single user to be created")
+}
+
+func TestOIDCLoginRestrictedDomain(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	config.ServerConf.OIDCAllowedDomains = []string{"example.com"}
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "test@other.com"
+
+	rr := runOIDCLogin(t, config, iss)
+
+	assertOIDCLoginError(t, rr)
+}
+
+func TestOIDCLoginRestrictedGroup(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	config.ServerConf.OIDCAllowedGroups = []string{"porter-users"}
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "test@example.com"
+	iss.Claims["groups"] = []string{"engineering"}
+
+	rr := runOIDCLogin(t, config, iss)
+
+	assertOIDCLoginError(t, rr)
+
+	iss.Claims["groups"] = []string{"engineering", "porter-users"}
+
+	rr = runOIDCLogin(t, config, iss)
+
+	assert.Equal(t, "/dashboard", rr.Header().Get("Location"))
+}
+
+func TestOIDCLoginEmailAlreadyRegistered(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	_, err := config.Repo.User().CreateUser(&models.User{
+		Email:    "test@example.com",
+		Password: "hello",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "test@example.com"
+
+	rr := runOIDCLogin(t, config, iss)
+
+	assertOIDCLoginError(t, rr)
+}
+
+func TestOIDCLoginRedirect(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "test@example.com"
+
+	tests := map[string]string{
+		"/projects/1?tab=settings": "/projects/1?tab=settings",
+		"https://evil.example.com": "/dashboard",
+		"//evil.example.com":       "/dashboard",
+		"/\\evil.example.com":      "/dashboard",
+		"/\t/evil.example.com":     "/dashboard",
+	}
+
+	for redirect, expLocation := range tests {
+		rr := runOIDCLoginWithRedirect(t, config, iss, redirect)
+
+		assert.Equal(t, expLocation, rr.Header().Get("Location"), redirect)
+	}
+}
+
+func TestOIDCLoginInvalidState(t *testing.T) {
+	config, iss := loadOIDCConfig(t)
+
+	iss.Claims["sub"] = "oidc-user-1"
+	iss.Claims["email"] = "test@example.com"
+
+	cookie, _, nonce := startOIDCLogin(t, config, "")
+
+	req := httptest.NewRequest(
+		"GET",
+		"/api/oauth/oidc/callback?"+url.Values{"state": {"bad-state"}, "code": {iss.IssueCode(nonce)}}.Encode(),
+		nil,
+	)
+
+	req.AddCookie(cookie)
+	rr := httptest.NewRecorder()
+
+	newOIDCCallbackHandler(config).ServeHTTP(rr, req)
+
+	apitest.AssertResponseForbidden(t, rr)
+}
+
+func loadOIDCConfig(t *testing.T) (*config.Config, *oidctest.Issuer) {
+	iss, err := oidctest.NewIssuer("porter")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(iss.Close)
+
+	conf := apitest.LoadConfig(t)
+	conf.Metadata = config.MetadataFromConf(conf.ServerConf, "dev")
+	conf.OIDCProvider = oidc.NewProvider(&oidc.Config{
+		IssuerURL:    iss.URL(),
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/api/oauth/oidc/callback",
+	})
+
+	return conf, iss
+}
+
+// startOIDCLogin calls the start handler and returns the session cookie, along with
+// the state and nonce sent to the issuer
+func startOIDCLogin(t *testing.T, config *config.Config, redirect string) (*http.Cookie, string, string) {
+	path := "/api/oauth/login/oidc"
+
+	if redirect != "" {
+		path += "?" + url.Values{"redirect_uri": {redirect}}.Encode()
+	}
+
+	req := httptest.NewRequest("GET", path, nil)
+	rr := httptest.NewRecorder()
+
+	handler := user.NewUserOAuthOIDCHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusFound {
+		t.Fatalf("expected redirect from start handler, got %d", rr.Code)
+	}
+
+	authURL, err := url.Parse(rr.Header().Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookies := rr.Result().Cookies()
+
+	if len(cookies) == 0 {
+		t.Fatalf("no cookie in response")
+	}
+
+	return cookies[0], authURL.Query().Get("state"), authURL.Query().Get("nonce")
+}
+
+func runOIDCLogin(t *testing.T, config *config.Config, iss *oidctest.Issuer) *httptest.ResponseRecorder {
+	return runOIDCLoginWithRedirect(t, config, iss, "")
+}
+
+func runOIDCLoginWithRedirect(
+	t *testing.T,
+	config *config.Config,
+	iss *oidctest.Issuer,
+	redirect string,
+) *httptest.ResponseRecorder {
+	cookie, state, nonce := startOIDCLogin(t, config, redirect)
+
+	req := httptest.NewRequest(
+		"GET",
+		"/api/oauth/oidc/callback?"+url.Values{"state": {state}, "code": {iss.IssueCode(nonce)}}.Encode(),
+		nil,
+	)
+
+	req.AddCookie(cookie)
+	rr := httptest.NewRecorder()
+
+	newOIDCCallbackHandler(config).ServeHTTP(rr, req)
+
+	return rr
+}
+
+func newOIDCCallbackHandler(config *config.Config) http.Handler {
+	return user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+}
+
+func assertOIDCLoginError(t *testing.T, rr *httptest.ResponseRecorder) {
+	loc, err := url.Parse(rr.Header().Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, http.StatusFound, rr.Code)
+	assert.Equal(t, "/login", loc.Path)
+	assert.NotEmpty(t, loc.Query().Get("error"), "expected login error")
+}
@@ -42,10 +42,23 @@ func (c *UserPasswordInitiateResetHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if reqErr := checkRateLimit(c.Config(), w, r, rateLimitActionPWResetInit, request.Email); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// every reset request counts as an attempt, so that the endpoint can't be used to
+	// flood a user with emails
+	if reqErr := recordRateLimitFailure(c.Config(), r, rateLimitActionPWResetInit, request.Email); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	// check that the email exists; return 200 status code even if it doesn't
 	user, err := c.Repo().User().ReadUserByEmail(request.Email)
 
-	if err == gorm.ErrRecordNotFound {
+	// service accounts have no password, and can't be given one
+	if err == gorm.ErrRecordNotFound || (err == nil && user.ServiceAccount) {
 		w.WriteHeader(http.StatusOK)
 		return
 	} else if err != nil {
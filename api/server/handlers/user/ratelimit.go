@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/internal/auth/ratelimit"
+)
+
+// actions that failed attempts are counted against
+const (
+	rateLimitActionLogin       = "login"
+	rateLimitActionCLIExchange = "cli_login_exchange"
+	rateLimitActionPWResetInit = "password_reset_initiate"
+)
+
+// checkRateLimit returns an error if the client's IP or the account is blocked for
+// the action. If the request is blocked, the Retry-After header is set.
+func checkRateLimit(
+	config *config.Config,
+	w http.ResponseWriter,
+	r *http.Request,
+	action, account string,
+) apierrors.RequestError {
+	err := config.AuthLimiter.Check(action, requestutils.GetClientIP(r, config.TrustedProxies), account)
+
+	if err == nil {
+		return nil
+	}
+
+	var rlErr *ratelimit.Error
+
+	if errors.As(err, &rlErr) {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(rlErr.RetryAfter.Seconds()))))
+
+		return apierrors.NewErrPassThroughToClient(rlErr, http.StatusTooManyRequests)
+	}
+
+	return apierrors.NewErrInternal(err)
+}
+
+// recordRateLimitFailure records a failed attempt for the client's IP and the account
+func recordRateLimitFailure(
+	config *config.Config,
+	r *http.Request,
+	action, account string,
+) apierrors.RequestError {
+	if err := config.AuthLimiter.Fail(action, requestutils.GetClientIP(r, config.TrustedProxies), account); err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRateLimitPolicy = &ratelimit.Policy{
+	FreeAttempts:    1,
+	MaxAttempts:     3,
+	Window:          time.Hour,
+	BaseBackoff:     time.Minute,
+	LockoutDuration: time.Hour,
+}
+
+func loadRateLimitedConfig(t *testing.T) (*config.Config, *[]*ratelimit.LockoutEvent) {
+	config := apitest.LoadConfig(t)
+	events := make([]*ratelimit.LockoutEvent, 0)
+
+	config.AuthLimiter = ratelimit.NewLimiter(&ratelimit.LimiterOpts{
+		Store:         ratelimit.NewMemoryStore(),
+		IPPolicy:      testRateLimitPolicy,
+		AccountPolicy: testRateLimitPolicy,
+		OnLockout: func(event *ratelimit.LockoutEvent) {
+			events = append(events, event)
+		},
+	})
+
+	return config, &events
+}
+
+func runTestLogin(t *testing.T, config *config.Config, ip, password string) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: password,
+		},
+	)
+
+	req.RemoteAddr = ip + ":1234"
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestLoginUserRateLimited(t *testing.T) {
+	config, _ := loadRateLimitedConfig(t)
+	apitest.CreateTestUser(t, config, true)
+
+	assert.Equal(t, http.StatusUnauthorized, runTestLogin(t, config, "10.0.0.1", "wrong").Code)
+	assert.Equal(t, http.StatusUnauthorized, runTestLogin(t, config, "10.0.0.1", "wrong").Code)
+
+	// after the free attempt, even the correct password should be rejected until the
+	// backoff has passed
+	rr := runTestLogin(t, config, "10.0.0.1", "hello")
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+
+	// the account is also blocked from other IPs
+	rr = runTestLogin(t, config, "10.0.0.2", "hello")
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
+
+func TestLoginUserLockout(t *testing.T) {
+	config, events := loadRateLimitedConfig(t)
+	apitest.CreateTestUser(t, config, true)
+
+	assert.Equal(t, http.StatusUnauthorized, runTestLogin(t, config, "10.0.0.1", "wrong").Code)
+	assert.Len(t, *events, 0, "a free attempt should not emit lockout events")
+
+	limiterFailures(t, config, 2)
+
+	assert.Len(t, *events, 2, "both the IP and the account should be locked out")
+	assert.Equal(t, ratelimit.KeyKindIP, (*events)[0].Kind)
+	assert.Equal(t, ratelimit.KeyKindAccount, (*events)[1].Kind)
+	assert.Equal(t, "test@test.it", (*events)[1].Value)
+
+	rr := runTestLogin(t, config, "10.0.0.1", "hello")
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "3600", rr.Header().Get("Retry-After"))
+}
+
+func TestLoginUserSuccessResetsAccount(t *testing.T) {
+	config, _ := loadRateLimitedConfig(t)
+	apitest.CreateTestUser(t, config, true)
+
+	assert.Equal(t, http.StatusUnauthorized, runTestLogin(t, config, "10.0.0.1", "wrong").Code)
+	assert.Equal(t, http.StatusOK, runTestLogin(t, config, "10.0.0.1", "hello").Code)
+
+	// the account's failure should be cleared, so a single failure from a new IP is
+	// still a free attempt
+	assert.Equal(t, http.StatusUnauthorized, runTestLogin(t, config, "10.0.0.2", "wrong").Code)
+	assert.Equal(t, http.StatusOK, runTestLogin(t, config, "10.0.0.2", "hello").Code)
+}
+
+func TestCLILoginExchangeRateLimited(t *testing.T) {
+	config, _ := loadRateLimitedConfig(t)
+
+	handler := user.NewCLILoginExchangeHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	codes := make([]int, 0)
+
+	for i := 0; i < 3; i++ {
+		req, rr := apitest.GetRequestAndRecorder(
+			t,
+			string(types.HTTPVerbPost),
+			"/api/cli/login/exchange",
+			&types.CLILoginExchangeRequest{
+				AuthorizationCode: "invalid",
+			},
+		)
+
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		handler.ServeHTTP(rr, req)
+
+		codes = append(codes, rr.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusForbidden, http.StatusForbidden, http.StatusTooManyRequests}, codes)
+}
+
+func TestPasswordResetInitiateRateLimited(t *testing.T) {
+	config, _ := loadRateLimitedConfig(t)
+	apitest.CreateTestUser(t, config, true)
+
+	handler := user.NewUserPasswordInitiateResetHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	codes := make([]int, 0)
+
+	for i := 0; i < 3; i++ {
+		req, rr := apitest.GetRequestAndRecorder(
+			t,
+			string(types.HTTPVerbPost),
+			"/api/password/reset/initiate",
+			&types.InitiateResetUserPasswordRequest{
+				Email: "test@test.it",
+			},
+		)
+
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		handler.ServeHTTP(rr, req)
+
+		codes = append(codes, rr.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+// limiterFailures records failed logins directly against the limiter, so that the
+// lockout is reached without waiting for the backoff between attempts
+func limiterFailures(t *testing.T, config *config.Config, n int) {
+	for i := 0; i < n; i++ {
+		if err := config.AuthLimiter.Fail("login", "10.0.0.1", "test@test.it"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UserListSessionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewUserListSessionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserListSessionsHandler {
+	return &UserListSessionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *UserListSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	sessions, err := u.Repo().Session().ListSessionsByUserID(user.ID)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	currentKey := authn.GetCurrentSessionKey(r, u.Config())
+
+	var res types.ListSessionsResponse = make([]*types.Session, 0)
+
+	for _, session := range sessions {
+		res = append(res, session.ToSessionType(currentKey))
+	}
+
+	u.WriteResult(w, r, res)
+}
+
+type UserRevokeSessionHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewUserRevokeSessionHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserRevokeSessionHandler {
+	return &UserRevokeSessionHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *UserRevokeSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	sessionID, reqErr := requestutils.GetURLParamUint(r, types.URLParamSessionID)
+
+	if reqErr != nil {
+		u.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// sessions are looked up through the user's list of sessions, so that a user can
+	// only revoke their own sessions
+	sessions, err := u.Repo().Session().ListSessionsByUserID(user.ID)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			if _, err := u.Repo().Session().DeleteSession(&models.Session{Key: session.Key}); err != nil {
+				u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			u.WriteResult(w, r, &types.RevokeSessionsResponse{
+				NumRevoked: 1,
+			})
+
+			return
+		}
+	}
+
+	u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+		fmt.Errorf("session %d not found", sessionID),
+		http.StatusNotFound,
+	))
+}
+
+type UserRevokeAllSessionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewUserRevokeAllSessionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserRevokeAllSessionsHandler {
+	return &UserRevokeAllSessionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *UserRevokeAllSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	// the session that made the request is kept, so that users are not logged out of
+	// the dashboard when revoking their other sessions
+	count, err := authn.RevokeUserSessions(u.Config(), user.ID, authn.GetCurrentSessionKey(r, u.Config()))
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.RevokeSessionsResponse{
+		NumRevoked: count,
+	})
+}
@@ -0,0 +1,157 @@
+package user_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSessions(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	cookie := apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+	apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+
+	sessions := listTestSessions(t, config, authUser, cookie)
+
+	assert.Len(t, sessions, 2, "expected two sessions")
+
+	numCurrent := 0
+
+	for _, session := range sessions {
+		if session.Current {
+			numCurrent++
+		}
+	}
+
+	assert.Equal(t, 1, numCurrent, "expected exactly one current session")
+}
+
+func TestRevokeSession(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	cookie := apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+	apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+
+	var otherID uint
+
+	for _, session := range listTestSessions(t, config, authUser, cookie) {
+		if !session.Current {
+			otherID = session.ID
+		}
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/users/current/sessions/1", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamSessionID): "1000",
+	})
+
+	handler := user.NewUserRevokeSessionHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code, "unknown session should return 404")
+
+	req, rr = apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/users/current/sessions/1", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamSessionID): fmt.Sprintf("%d", otherID),
+	})
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseExpected(t, rr, &types.RevokeSessionsResponse{NumRevoked: 1}, &types.RevokeSessionsResponse{})
+
+	sessions := listTestSessions(t, config, authUser, cookie)
+
+	assert.Len(t, sessions, 1, "expected one session after revocation")
+	assert.True(t, sessions[0].Current, "remaining session should be the current session")
+}
+
+func TestRevokeSessionOtherUser(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	otherUser, err := config.Repo.User().CreateUser(&models.User{
+		Email:    "other@example.com",
+		Password: "hello",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherCookie := apitest.AuthenticateUserWithCookie(t, config, otherUser, false)
+	otherSessions := listTestSessions(t, config, otherUser, otherCookie)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/users/current/sessions/1", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamSessionID): fmt.Sprintf("%d", otherSessions[0].ID),
+	})
+
+	handler := user.NewUserRevokeSessionHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code, "sessions of other users should not be revocable")
+	assert.Len(t, listTestSessions(t, config, otherUser, otherCookie), 1)
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	cookie := apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+	apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+	apitest.AuthenticateUserWithCookie(t, config, authUser, false)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/users/current/sessions", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+	req.AddCookie(cookie)
+
+	handler := user.NewUserRevokeAllSessionsHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseExpected(t, rr, &types.RevokeSessionsResponse{NumRevoked: 2}, &types.RevokeSessionsResponse{})
+
+	sessions := listTestSessions(t, config, authUser, cookie)
+
+	assert.Len(t, sessions, 1, "expected only the current session to remain")
+	assert.True(t, sessions[0].Current, "remaining session should be the current session")
+}
+
+func listTestSessions(t *testing.T, config *config.Config, authUser *models.User, cookie *http.Cookie) types.ListSessionsResponse {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/users/current/sessions", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+	req.AddCookie(cookie)
+
+	handler := user.NewUserListSessionsHandler(config, shared.NewDefaultResultWriter(config.Logger, config.Alerter))
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200 listing sessions, got %d", rr.Code)
+	}
+
+	res := types.ListSessionsResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
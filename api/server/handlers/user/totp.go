@@ -0,0 +1,354 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const numTOTPRecoveryCodes = 10
+
+type UserEnrollTOTPHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewUserEnrollTOTPHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserEnrollTOTPHandler {
+	return &UserEnrollTOTPHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *UserEnrollTOTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if user.Password == "" {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is only available for email and password login"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// a new secret is generated on every call, so restarting enrollment invalidates
+	// any previously scanned secret
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user.TOTPSecret = secret
+
+	user, err = u.Repo().User().UpdateUser(user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.EnrollTOTPResponse{
+		Secret: totp.EncodeSecret(secret),
+		URI:    totp.ProvisioningURI(u.Config().ServerConf.TOTPIssuer, user.Email, secret),
+	})
+}
+
+type UserVerifyTOTPHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserVerifyTOTPHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserVerifyTOTPHandler {
+	return &UserVerifyTOTPHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserVerifyTOTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.VerifyTOTPRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if len(user.TOTPSecret) == 0 {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication enrollment has not been started"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if ok, err := validateTOTPCode(u.Repo().User(), user, request.Code); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor authentication code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	codes, hashed, err := generateTOTPRecoveryCodes()
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPRecoveryCodes = hashed
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the current session has just proven possession of the second factor, so it
+	// should not be logged out
+	if err := authn.SaveUserTOTPVerified(w, r, u.Config()); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.VerifyTOTPResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+type UserDisableTOTPHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserDisableTOTPHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserDisableTOTPHandler {
+	return &UserDisableTOTPHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserDisableTOTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.DisableTOTPRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if ok, err := checkTOTPCode(u.Repo().User(), user, request.Code); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor authentication code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = []byte{}
+	user.TOTPRecoveryCodes = ""
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+type UserRegenerateTOTPRecoveryCodesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserRegenerateTOTPRecoveryCodesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserRegenerateTOTPRecoveryCodesHandler {
+	return &UserRegenerateTOTPRecoveryCodesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserRegenerateTOTPRecoveryCodesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.RegenerateTOTPRecoveryCodesRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// recovery codes cannot be used to generate new recovery codes
+	if ok, err := validateTOTPCode(u.Repo().User(), user, request.Code); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor authentication code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	codes, hashed, err := generateTOTPRecoveryCodes()
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user.TOTPRecoveryCodes = hashed
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.RegenerateTOTPRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// generateTOTPRecoveryCodes returns a new set of raw recovery codes, along with the
+// hashed representation which should be stored on the user
+func generateTOTPRecoveryCodes() ([]string, string, error) {
+	codes, err := totp.GenerateRecoveryCodes(numTOTPRecoveryCodes)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashedCodes := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(code), 8)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		hashedCodes = append(hashedCodes, string(hashed))
+	}
+
+	return codes, strings.Join(hashedCodes, ","), nil
+}
+
+// checkTOTPCode checks a code against the user's TOTP secret and, if that fails,
+// against the user's recovery codes. A matching recovery code is removed from the
+// user so that it cannot be used again.
+func checkTOTPCode(repo repository.UserRepository, user *models.User, code string) (bool, error) {
+	if ok, err := validateTOTPCode(repo, user, code); ok || err != nil {
+		return ok, err
+	}
+
+	if user.TOTPRecoveryCodes == "" {
+		return false, nil
+	}
+
+	normalized := totp.NormalizeRecoveryCode(code)
+	hashedCodes := strings.Split(user.TOTPRecoveryCodes, ",")
+
+	for i, hashed := range hashedCodes {
+		if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(normalized)); err == nil {
+			remaining := append(hashedCodes[:i:i], hashedCodes[i+1:]...)
+			user.TOTPRecoveryCodes = strings.Join(remaining, ",")
+
+			if _, err := repo.UpdateUser(user); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// validateTOTPCode checks a code against the user's TOTP secret. Codes from the time
+// step of the last accepted code or earlier are rejected, so that a code cannot be
+// replayed within its validity window, and the time step of an accepted code is stored
+// on the user.
+func validateTOTPCode(repo repository.UserRepository, user *models.User, code string) (bool, error) {
+	counter, ok := totp.ValidateAfter(code, user.TOTPSecret, time.Now(), user.TOTPLastCounter)
+
+	if !ok {
+		return false, nil
+	}
+
+	user.TOTPLastCounter = counter
+
+	if _, err := repo.UpdateUser(user); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
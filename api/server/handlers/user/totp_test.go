@@ -0,0 +1,114 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+func TestEnrollAndVerifyTOTP(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/users/current/totp/enroll", nil)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+
+	enrollHandler := user.NewUserEnrollTOTPHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	enrollHandler.ServeHTTP(rr, req)
+
+	if rr.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, rr.Result().StatusCode)
+	}
+
+	gotUser, err := config.Repo.User().ReadUser(authUser.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotUser.TOTPEnabled || len(gotUser.TOTPSecret) == 0 {
+		t.Fatalf("expected secret to be set without enabling TOTP")
+	}
+
+	req, rr = apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/users/current/totp/verify",
+		&types.VerifyTOTPRequest{
+			Code: totp.GenerateCode(gotUser.TOTPSecret, time.Now()),
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, gotUser)
+
+	verifyHandler := user.NewUserVerifyTOTPHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	verifyHandler.ServeHTTP(rr, req)
+
+	resp := &types.VerifyTOTPResponse{}
+
+	apitest.AssertResponseExpected(t, rr, resp, resp)
+
+	if len(resp.RecoveryCodes) != 10 {
+		t.Errorf("expected 10 recovery codes, got %d", len(resp.RecoveryCodes))
+	}
+
+	if gotUser, _ = config.Repo.User().ReadUser(authUser.ID); !gotUser.TOTPEnabled {
+		t.Errorf("expected TOTP to be enabled")
+	}
+}
+
+func TestVerifyTOTPIncorrectCode(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authUser.TOTPSecret = secret
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/users/current/totp/verify",
+		&types.VerifyTOTPRequest{
+			Code: "000000",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+
+	handler := user.NewUserVerifyTOTPHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	// the generated code may collide with 000000, in which case the test is skipped
+	if totp.Validate("000000", secret, time.Now()) {
+		t.Skip("generated secret produced code 000000")
+	}
+
+	apitest.AssertResponseError(t, rr, http.StatusBadRequest, &types.ExternalError{
+		Error: "incorrect two-factor authentication code",
+	})
+}
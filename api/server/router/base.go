@@ -487,6 +487,56 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/oauth/login/oidc
+	oidcLoginStartEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/login/oidc",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginStartHandler := user.NewUserOAuthOIDCHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: oidcLoginStartEndpoint,
+		Handler:  oidcLoginStartHandler,
+		Router:   r,
+	})
+
+	// GET /api/oauth/oidc/callback
+	oidcLoginCallbackEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/oidc/callback",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginCallbackHandler := user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: oidcLoginCallbackEndpoint,
+		Handler:  oidcLoginCallbackHandler,
+		Router:   r,
+	})
+
 	// GET /api/internal/credentials
 	getCredentialsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
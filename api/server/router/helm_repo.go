@@ -80,6 +80,35 @@ func getHelmRepoRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/helmrepos/{helm_repo_id}/provenance -> helmrepo.NewHelmRepoUpdateProvenanceHandler
+	updateProvenanceEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/provenance",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.HelmRepoScope,
+			},
+		},
+	)
+
+	updateProvenanceHandler := helmrepo.NewHelmRepoUpdateProvenanceHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: updateProvenanceEndpoint,
+		Handler:  updateProvenanceHandler,
+		Router:   r,
+	})
+
 	//  GET /api/projects/{project_id}/helmrepos/{helm_repo_id}/charts -> helmrepo.NewChartListHandler
 	hrListEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// maxAuditedBodySize is the largest request body that is read to summarize the
+// request. Larger bodies are not summarized.
+const maxAuditedBodySize = 1 << 20
+
+// AuditMiddleware records an audit log for each request to an endpoint. It should be
+// attached after the authentication middleware, so that the actor is known, and before
+// the authorization middleware, so that forbidden requests are recorded.
+type AuditMiddleware struct {
+	config       *config.Config
+	endpointMeta types.APIRequestMetadata
+}
+
+func NewAuditMiddleware(config *config.Config, endpointMeta types.APIRequestMetadata) *AuditMiddleware {
+	return &AuditMiddleware{config, endpointMeta}
+}
+
+func (a *AuditMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auditLog := &models.AuditLog{
+			IPAddress: requestutils.GetClientIP(r, a.config.TrustedProxies),
+			Verb:      a.endpointMeta.Verb,
+			Method:    a.endpointMeta.Method,
+			Path:      r.URL.Path,
+			Summary:   getRequestSummary(r),
+		}
+
+		if projID, reqErr := requestutils.GetURLParamUint(r, types.URLParamProjectID); reqErr == nil {
+			auditLog.ProjectID = projID
+		}
+
+		if reqScopes, reqErr := authz.GetRequestActionForEndpoint(r, a.endpointMeta); reqErr == nil {
+			auditLog.ScopePath = getScopePath(a.endpointMeta.Scopes, reqScopes)
+		}
+
+		setAuditLogActor(a.config, r, auditLog)
+
+		sw := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		auditLog.StatusCode = sw.statusCode
+		auditLog.Outcome = types.AuditLogOutcomeSuccess
+
+		if sw.statusCode >= 400 {
+			auditLog.Outcome = types.AuditLogOutcomeFailure
+		}
+
+		// failing to write an audit log should not fail the request, which has
+		// already been handled
+		if _, err := a.config.Repo.AuditLog().CreateAuditLog(auditLog); err != nil {
+			a.config.Logger.Error().Err(err).Msgf("could not write audit log for %s %s", auditLog.Method, auditLog.Path)
+		}
+	})
+}
+
+func setAuditLogActor(config *config.Config, r *http.Request, auditLog *models.AuditLog) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if user != nil {
+		auditLog.UserID = user.ID
+		auditLog.Actor = user.Email
+
+		if user.ServiceAccount {
+			auditLog.Actor = "service account"
+
+			if sa, err := config.Repo.ServiceAccount().ReadServiceAccountByUserID(user.ID); err == nil {
+				auditLog.Actor = fmt.Sprintf("service account %s", sa.Name)
+			}
+		}
+	}
+
+	// requests authenticated with an API token are attributed to the user who issued
+	// the token, but the token is recorded as the actor
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok {
+		auditLog.APITokenID = apiToken.ID
+
+		if user != nil && user.ServiceAccount {
+			auditLog.Actor = fmt.Sprintf("api token %s (%s)", apiToken.Name, auditLog.Actor)
+		} else {
+			auditLog.Actor = fmt.Sprintf("api token %s", apiToken.Name)
+		}
+	}
+
+	if auditLog.Actor == "" {
+		auditLog.Actor = "anonymous"
+	}
+}
+
+// getScopePath converts the resources of each scope of the request to a path, for
+// example "project/1/cluster/2/namespace/default"
+func getScopePath(
+	scopes []types.PermissionScope,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) string {
+	path := make([]string, 0)
+
+	for _, scope := range scopes {
+		action, ok := reqScopes[scope]
+
+		if !ok || scope == types.UserScope {
+			continue
+		}
+
+		path = append(path, string(scope))
+
+		if action.Resource.Name != "" {
+			path = append(path, action.Resource.Name)
+		} else if action.Resource.UInt != 0 {
+			path = append(path, fmt.Sprintf("%d", action.Resource.UInt))
+		}
+	}
+
+	return strings.Join(path, "/")
+}
+
+// getRequestSummary describes a request by its route and the top-level fields of its
+// JSON body. Values are not included, since they may contain secrets.
+func getRequestSummary(r *http.Request) string {
+	summary := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		summary = fmt.Sprintf("%s %s", r.Method, rctx.RoutePattern())
+	}
+
+	if r.Body == nil || r.ContentLength <= 0 || r.ContentLength > maxAuditedBodySize || strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		return summary
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+
+	// restore the body so that the handler can read it
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return summary
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(body, &fields); err != nil || len(fields) == 0 {
+		return summary
+	}
+
+	keys := make([]string, 0, len(fields))
+
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return fmt.Sprintf("%s (fields: %s)", summary, strings.Join(keys, ", "))
+}
+
+// statusResponseWriter records the status code written by a handler
+type statusResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (s *statusResponseWriter) WriteHeader(statusCode int) {
+	s.statusCode = statusCode
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (s *statusResponseWriter) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+
+	auditMw := middleware.NewAuditMiddleware(config, types.APIRequestMetadata{
+		Verb:   types.APIVerbUpdate,
+		Method: types.HTTPVerbPost,
+		Scopes: []types.PermissionScope{
+			types.UserScope,
+			types.ProjectScope,
+			types.ClusterScope,
+			types.NamespaceScope,
+			types.ReleaseScope,
+		},
+	})
+
+	r := chi.NewRouter()
+
+	// simulate authentication by adding the user to the context
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), types.UserScope, user)))
+		})
+	})
+
+	r.With(auditMw.Middleware).Post(
+		"/api/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/releases/{name}/upgrade",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+	)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/projects/1/clusters/2/namespaces/default/releases/web/upgrade",
+		strings.NewReader(`{"values": {"password": "secret"}, "image": "nginx"}`),
+	)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	auditLogs, count, err := config.Repo.AuditLog().ListAuditLogsByProjectID(1, &types.ListAuditLogsRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, 1, count)
+
+	auditLog := auditLogs[0]
+
+	assert.Equal(t, user.ID, auditLog.UserID)
+	assert.Equal(t, "test@test.it", auditLog.Actor)
+	assert.Equal(t, "project/1/cluster/2/namespace/default/release/web", auditLog.ScopePath)
+	assert.Equal(t, types.APIVerbUpdate, auditLog.Verb)
+	assert.Equal(t, http.StatusForbidden, auditLog.StatusCode)
+	assert.Equal(t, types.AuditLogOutcomeFailure, auditLog.Outcome)
+	assert.Equal(
+		t,
+		"POST /api/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/releases/{name}/upgrade (fields: image, values)",
+		auditLog.Summary,
+	)
+	assert.NotContains(t, auditLog.Summary, "secret", "request values should not be recorded")
+}
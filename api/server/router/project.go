@@ -6,11 +6,13 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/porter-dev/porter/api/server/handlers/billing"
 	"github.com/porter-dev/porter/api/server/handlers/cluster"
+	"github.com/porter-dev/porter/api/server/handlers/deploy_request"
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/helmrepo"
 	"github.com/porter-dev/porter/api/server/handlers/infra"
 	"github.com/porter-dev/porter/api/server/handlers/project"
 	"github.com/porter-dev/porter/api/server/handlers/registry"
+	"github.com/porter-dev/porter/api/server/handlers/template"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
@@ -141,124 +143,883 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/policy/can_i -> project.NewProjectCanIHandler
+	canIEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/policy/can_i",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	canIHandler := project.NewProjectCanIHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: canIEndpoint,
+		Handler:  canIHandler,
+		Router:   r,
+	})
+
 	// GET /api/projects/{project_id}/onboarding -> project.NewProjectGetOnboardingHandler
 	getOnboardingEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbGet,
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/onboarding",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getOnboardingHandler := project.NewOnboardingGetHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getOnboardingEndpoint,
+		Handler:  getOnboardingHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/onboarding -> project.NewProjectGetOnboardingHandler
+	updateOnboardingEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/onboarding",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateOnboardingHandler := project.NewOnboardingUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: updateOnboardingEndpoint,
+		Handler:  updateOnboardingHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/usage -> project.NewProjectGetUsageHandler
+	getUsageEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/usage",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getUsageHandler := project.NewProjectGetUsageHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getUsageEndpoint,
+		Handler:  getUsageHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/billing -> project.NewProjectGetBillingHandler
+	getBillingEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/billing",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getBillingHandler := project.NewProjectGetBillingHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getBillingEndpoint,
+		Handler:  getBillingHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/billing/token -> billing.NewBillingGetTokenEndpoint
+	getBillingTokenEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/billing/token",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	getBillingTokenHandler := billing.NewBillingGetTokenHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getBillingTokenEndpoint,
+		Handler:  getBillingTokenHandler,
+		Router:   r,
+	})
+
+	// GET /api/billing_webhook -> billing.NewBillingWebhookHandler
+	getBillingWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/billing_webhook",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	getBillingWebhookHandler := billing.NewBillingWebhookHandler(
+		config,
+		factory.GetDecoderValidator(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getBillingWebhookEndpoint,
+		Handler:  getBillingWebhookHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/clusters -> cluster.NewClusterListHandler
+	listClusterEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/clusters",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listClusterHandler := cluster.NewClusterListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listClusterEndpoint,
+		Handler:  listClusterHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/gitrepos -> gitinstallation.NewGitRepoListHandler
+	listGitReposEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/gitrepos",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listGitReposHandler := gitinstallation.NewGitRepoListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listGitReposEndpoint,
+		Handler:  listGitReposHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/collaborators -> project.NewCollaboratorsListHandler
+	listCollaboratorsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/collaborators",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listCollaboratorsHandler := project.NewCollaboratorsListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listCollaboratorsEndpoint,
+		Handler:  listCollaboratorsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/collaborators/{user_id}/sessions -> project.NewCollaboratorSessionsListHandler
+	// The sessions of a collaborator contain their IP addresses and user agents, so
+	// listing them requires the update verb on settings, which only admins have.
+	listCollaboratorSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/collaborators/{%s}/sessions", relPath, types.URLParamUserID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listCollaboratorSessionsHandler := project.NewCollaboratorSessionsListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listCollaboratorSessionsEndpoint,
+		Handler:  listCollaboratorSessionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/collaborators/{user_id}/sessions -> project.NewCollaboratorSessionsRevokeHandler
+	revokeCollaboratorSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/collaborators/{%s}/sessions", relPath, types.URLParamUserID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	revokeCollaboratorSessionsHandler := project.NewCollaboratorSessionsRevokeHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: revokeCollaboratorSessionsEndpoint,
+		Handler:  revokeCollaboratorSessionsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/roles -> project.NewRolesListHandler
+	listRolesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listRolesHandler := project.NewRolesListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listRolesEndpoint,
+		Handler:  listRolesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/roles -> project.NewRoleUpdateHandler
+	updateRoleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateRoleHandler := project.NewRoleUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: updateRoleEndpoint,
+		Handler:  updateRoleHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/roles -> project.NewRoleDeleteHandler
+	deleteRoleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteRoleHandler := project.NewRoleDeleteHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: deleteRoleEndpoint,
+		Handler:  deleteRoleHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/roles/custom -> project.NewCustomRolesListHandler
+	listCustomRolesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles/custom",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listCustomRolesHandler := project.NewCustomRolesListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listCustomRolesEndpoint,
+		Handler:  listCustomRolesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/roles/custom -> project.NewCustomRoleCreateHandler
+	createCustomRoleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles/custom",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createCustomRoleHandler := project.NewCustomRoleCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: createCustomRoleEndpoint,
+		Handler:  createCustomRoleHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/roles/custom/{custom_role_id} -> project.NewCustomRoleUpdateHandler
+	updateCustomRoleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/roles/custom/{%s}", relPath, types.URLParamCustomRoleID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	updateCustomRoleHandler := project.NewCustomRoleUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: updateCustomRoleEndpoint,
+		Handler:  updateCustomRoleHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/roles/custom/{custom_role_id} -> project.NewCustomRoleDeleteHandler
+	deleteCustomRoleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/roles/custom/{%s}", relPath, types.URLParamCustomRoleID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	deleteCustomRoleHandler := project.NewCustomRoleDeleteHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: deleteCustomRoleEndpoint,
+		Handler:  deleteCustomRoleHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/api_token -> project.NewAPITokenCreateHandler
+	createAPITokenEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/api_token",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createAPITokenHandler := project.NewAPITokenCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: createAPITokenEndpoint,
+		Handler:  createAPITokenHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/api_token -> project.NewAPITokensListHandler
+	listAPITokensEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/api_token",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listAPITokensHandler := project.NewAPITokensListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listAPITokensEndpoint,
+		Handler:  listAPITokensHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/api_token/{api_token_id}/revoke -> project.NewAPITokenRevokeHandler
+	revokeAPITokenEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/api_token/{%s}/revoke", relPath, types.URLParamAPITokenID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	revokeAPITokenHandler := project.NewAPITokenRevokeHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: revokeAPITokenEndpoint,
+		Handler:  revokeAPITokenHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/service_accounts -> project.NewServiceAccountsListHandler
+	listServiceAccountsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/service_accounts",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listServiceAccountsHandler := project.NewServiceAccountsListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listServiceAccountsEndpoint,
+		Handler:  listServiceAccountsHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/service_accounts -> project.NewServiceAccountCreateHandler
+	createServiceAccountEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/service_accounts",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createServiceAccountHandler := project.NewServiceAccountCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: createServiceAccountEndpoint,
+		Handler:  createServiceAccountHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/service_accounts/{service_account_id} -> project.NewServiceAccountDeleteHandler
+	deleteServiceAccountEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/service_accounts/{%s}", relPath, types.URLParamServiceAccountID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	deleteServiceAccountHandler := project.NewServiceAccountDeleteHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: deleteServiceAccountEndpoint,
+		Handler:  deleteServiceAccountHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/service_accounts/{service_account_id}/api_token -> project.NewServiceAccountAPITokenCreateHandler
+	createServiceAccountAPITokenEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/service_accounts/{%s}/api_token", relPath, types.URLParamServiceAccountID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createServiceAccountAPITokenHandler := project.NewServiceAccountAPITokenCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: createServiceAccountAPITokenEndpoint,
+		Handler:  createServiceAccountAPITokenHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/teams -> project.NewTeamsListHandler
+	listTeamsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
 			Method: types.HTTPVerbGet,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/onboarding",
+				RelativePath: relPath + "/teams",
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	getOnboardingHandler := project.NewOnboardingGetHandler(
+	listTeamsHandler := project.NewTeamsListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listTeamsEndpoint,
+		Handler:  listTeamsHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/teams -> project.NewTeamCreateHandler
+	createTeamEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/teams",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createTeamHandler := project.NewTeamCreateHandler(
 		config,
 		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: getOnboardingEndpoint,
-		Handler:  getOnboardingHandler,
+		Endpoint: createTeamEndpoint,
+		Handler:  createTeamHandler,
 		Router:   r,
 	})
 
-	// POST /api/projects/{project_id}/onboarding -> project.NewProjectGetOnboardingHandler
-	updateOnboardingEndpoint := factory.NewAPIEndpoint(
+	// POST /api/projects/{project_id}/teams/{team_id} -> project.NewTeamUpdateHandler
+	updateTeamEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
 			Verb:   types.APIVerbUpdate,
 			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/onboarding",
+				RelativePath: fmt.Sprintf("%s/teams/{%s}", relPath, types.URLParamTeamID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	updateOnboardingHandler := project.NewOnboardingUpdateHandler(
+	updateTeamHandler := project.NewTeamUpdateHandler(
 		config,
 		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: updateOnboardingEndpoint,
-		Handler:  updateOnboardingHandler,
+		Endpoint: updateTeamEndpoint,
+		Handler:  updateTeamHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/usage -> project.NewProjectGetUsageHandler
-	getUsageEndpoint := factory.NewAPIEndpoint(
+	// DELETE /api/projects/{project_id}/teams/{team_id} -> project.NewTeamDeleteHandler
+	deleteTeamEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbGet,
-			Method: types.HTTPVerbGet,
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/usage",
+				RelativePath: fmt.Sprintf("%s/teams/{%s}", relPath, types.URLParamTeamID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	getUsageHandler := project.NewProjectGetUsageHandler(
+	deleteTeamHandler := project.NewTeamDeleteHandler(
 		config,
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: getUsageEndpoint,
-		Handler:  getUsageHandler,
+		Endpoint: deleteTeamEndpoint,
+		Handler:  deleteTeamHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/billing -> project.NewProjectGetBillingHandler
-	getBillingEndpoint := factory.NewAPIEndpoint(
+	// POST /api/projects/{project_id}/teams/{team_id}/members -> project.NewTeamMemberAddHandler
+	addTeamMemberEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbGet,
-			Method: types.HTTPVerbGet,
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/billing",
+				RelativePath: fmt.Sprintf("%s/teams/{%s}/members", relPath, types.URLParamTeamID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	getBillingHandler := project.NewProjectGetBillingHandler(
+	addTeamMemberHandler := project.NewTeamMemberAddHandler(
 		config,
+		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: getBillingEndpoint,
-		Handler:  getBillingHandler,
+		Endpoint: addTeamMemberEndpoint,
+		Handler:  addTeamMemberHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/billing/token -> billing.NewBillingGetTokenEndpoint
-	getBillingTokenEndpoint := factory.NewAPIEndpoint(
+	// DELETE /api/projects/{project_id}/teams/{team_id}/members/{user_id} -> project.NewTeamMemberRemoveHandler
+	removeTeamMemberEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbGet,
-			Method: types.HTTPVerbGet,
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbDelete,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/billing/token",
+				RelativePath: fmt.Sprintf("%s/teams/{%s}/members/{%s}", relPath, types.URLParamTeamID, types.URLParamUserID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
@@ -268,77 +1029,109 @@ func getProjectRoutes(
 		},
 	)
 
-	getBillingTokenHandler := billing.NewBillingGetTokenHandler(
+	removeTeamMemberHandler := project.NewTeamMemberRemoveHandler(
 		config,
-		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: getBillingTokenEndpoint,
-		Handler:  getBillingTokenHandler,
+		Endpoint: removeTeamMemberEndpoint,
+		Handler:  removeTeamMemberHandler,
 		Router:   r,
 	})
 
-	// GET /api/billing_webhook -> billing.NewBillingWebhookHandler
-	getBillingWebhookEndpoint := factory.NewAPIEndpoint(
+	// GET /api/projects/{project_id}/protected_resources -> deploy_request.NewProtectedResourcesListHandler
+	listProtectedResourcesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/protected_resources",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listProtectedResourcesHandler := deploy_request.NewProtectedResourcesListHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listProtectedResourcesEndpoint,
+		Handler:  listProtectedResourcesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/protected_resources -> deploy_request.NewProtectedResourceCreateHandler
+	createProtectedResourceEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
 			Verb:   types.APIVerbCreate,
 			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: "/billing_webhook",
+				RelativePath: relPath + "/protected_resources",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
 			},
-			Scopes: []types.PermissionScope{},
 		},
 	)
 
-	getBillingWebhookHandler := billing.NewBillingWebhookHandler(
+	createProtectedResourceHandler := deploy_request.NewProtectedResourceCreateHandler(
 		config,
 		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: getBillingWebhookEndpoint,
-		Handler:  getBillingWebhookHandler,
+		Endpoint: createProtectedResourceEndpoint,
+		Handler:  createProtectedResourceHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/clusters -> cluster.NewClusterListHandler
-	listClusterEndpoint := factory.NewAPIEndpoint(
+	// DELETE /api/projects/{project_id}/protected_resources/{protected_resource_id} -> deploy_request.NewProtectedResourceDeleteHandler
+	deleteProtectedResourceEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbList,
-			Method: types.HTTPVerbGet,
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/clusters",
+				RelativePath: fmt.Sprintf("%s/protected_resources/{%s}", relPath, types.URLParamProtectedResourceID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	listClusterHandler := cluster.NewClusterListHandler(
+	deleteProtectedResourceHandler := deploy_request.NewProtectedResourceDeleteHandler(
 		config,
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: listClusterEndpoint,
-		Handler:  listClusterHandler,
+		Endpoint: deleteProtectedResourceEndpoint,
+		Handler:  deleteProtectedResourceHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/gitrepos -> gitinstallation.NewGitRepoListHandler
-	listGitReposEndpoint := factory.NewAPIEndpoint(
+	// GET /api/projects/{project_id}/deploy_policy -> project.NewDeployPolicyGetHandler
+	getDeployPolicyEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbList,
+			Verb:   types.APIVerbGet,
 			Method: types.HTTPVerbGet,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/gitrepos",
+				RelativePath: relPath + "/deploy_policy",
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
@@ -347,52 +1140,54 @@ func getProjectRoutes(
 		},
 	)
 
-	listGitReposHandler := gitinstallation.NewGitRepoListHandler(
+	getDeployPolicyHandler := project.NewDeployPolicyGetHandler(
 		config,
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: listGitReposEndpoint,
-		Handler:  listGitReposHandler,
+		Endpoint: getDeployPolicyEndpoint,
+		Handler:  getDeployPolicyHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/collaborators -> project.NewCollaboratorsListHandler
-	listCollaboratorsEndpoint := factory.NewAPIEndpoint(
+	// POST /api/projects/{project_id}/deploy_policy -> project.NewDeployPolicyUpdateHandler
+	updateDeployPolicyEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbList,
-			Method: types.HTTPVerbGet,
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/collaborators",
+				RelativePath: relPath + "/deploy_policy",
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
 				types.ProjectScope,
+				types.SettingsScope,
 			},
 		},
 	)
 
-	listCollaboratorsHandler := project.NewCollaboratorsListHandler(
+	updateDeployPolicyHandler := project.NewDeployPolicyUpdateHandler(
 		config,
+		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: listCollaboratorsEndpoint,
-		Handler:  listCollaboratorsHandler,
+		Endpoint: updateDeployPolicyEndpoint,
+		Handler:  updateDeployPolicyHandler,
 		Router:   r,
 	})
 
-	// GET /api/projects/{project_id}/roles -> project.NewRolesListHandler
-	listRolesEndpoint := factory.NewAPIEndpoint(
+	// GET /api/projects/{project_id}/deploy_requests -> deploy_request.NewDeployRequestsListHandler
+	listDeployRequestsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
 			Verb:   types.APIVerbList,
 			Method: types.HTTPVerbGet,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/roles",
+				RelativePath: relPath + "/deploy_requests",
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
@@ -401,25 +1196,26 @@ func getProjectRoutes(
 		},
 	)
 
-	listRolesHandler := project.NewRolesListHandler(
+	listDeployRequestsHandler := deploy_request.NewDeployRequestsListHandler(
 		config,
+		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: listRolesEndpoint,
-		Handler:  listRolesHandler,
+		Endpoint: listDeployRequestsEndpoint,
+		Handler:  listDeployRequestsHandler,
 		Router:   r,
 	})
 
-	// POST /api/projects/{project_id}/roles -> project.NewRoleUpdateHandler
-	updateRoleEndpoint := factory.NewAPIEndpoint(
+	// POST /api/projects/{project_id}/deploy_requests/{deploy_request_id}/approve -> deploy_request.NewDeployRequestApproveHandler
+	approveDeployRequestEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
 			Verb:   types.APIVerbUpdate,
 			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/roles",
+				RelativePath: fmt.Sprintf("%s/deploy_requests/{%s}/approve", relPath, types.URLParamDeployRequestID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
@@ -428,26 +1224,26 @@ func getProjectRoutes(
 		},
 	)
 
-	updateRoleHandler := project.NewRoleUpdateHandler(
+	approveDeployRequestHandler := deploy_request.NewDeployRequestApproveHandler(
 		config,
 		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: updateRoleEndpoint,
-		Handler:  updateRoleHandler,
+		Endpoint: approveDeployRequestEndpoint,
+		Handler:  approveDeployRequestHandler,
 		Router:   r,
 	})
 
-	// DELETE /api/projects/{project_id}/roles -> project.NewRoleDeleteHandler
-	deleteRoleEndpoint := factory.NewAPIEndpoint(
+	// POST /api/projects/{project_id}/deploy_requests/{deploy_request_id}/reject -> deploy_request.NewDeployRequestRejectHandler
+	rejectDeployRequestEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
-			Verb:   types.APIVerbDelete,
-			Method: types.HTTPVerbDelete,
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
 			Path: &types.Path{
 				Parent:       basePath,
-				RelativePath: relPath + "/roles",
+				RelativePath: fmt.Sprintf("%s/deploy_requests/{%s}/reject", relPath, types.URLParamDeployRequestID),
 			},
 			Scopes: []types.PermissionScope{
 				types.UserScope,
@@ -456,15 +1252,44 @@ func getProjectRoutes(
 		},
 	)
 
-	deleteRoleHandler := project.NewRoleDeleteHandler(
+	rejectDeployRequestHandler := deploy_request.NewDeployRequestRejectHandler(
 		config,
 		factory.GetDecoderValidator(),
 		factory.GetResultWriter(),
 	)
 
 	routes = append(routes, &Route{
-		Endpoint: deleteRoleEndpoint,
-		Handler:  deleteRoleHandler,
+		Endpoint: rejectDeployRequestEndpoint,
+		Handler:  rejectDeployRequestHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/audit_logs -> project.NewAuditLogsListHandler
+	listAuditLogsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_logs",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listAuditLogsHandler := project.NewAuditLogsListHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listAuditLogsEndpoint,
+		Handler:  listAuditLogsHandler,
 		Router:   r,
 	})
 
@@ -946,5 +1771,99 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/templates -> template.NewTemplateListHandler
+	listProjectTemplatesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/templates",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listProjectTemplatesHandler := template.NewTemplateListHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listProjectTemplatesEndpoint,
+		Handler:  listProjectTemplatesHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/templates/{name}/{version} -> template.NewTemplateGetHandler
+	getProjectTemplateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent: basePath,
+				RelativePath: fmt.Sprintf(
+					"%s/templates/{%s}/{%s}",
+					relPath,
+					types.URLParamTemplateName,
+					types.URLParamTemplateVersion,
+				),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getProjectTemplateHandler := template.NewTemplateGetHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getProjectTemplateEndpoint,
+		Handler:  getProjectTemplateHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/templates/{name}/{version}/upgrade_notes -> template.NewTemplateGetUpgradeNotesHandler
+	getProjectTemplateUpgradeNotesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent: basePath,
+				RelativePath: fmt.Sprintf(
+					"%s/templates/{%s}/{%s}/upgrade_notes",
+					relPath,
+					types.URLParamTemplateName,
+					types.URLParamTemplateVersion,
+				),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getProjectTemplateUpgradeNotesHandler := template.NewTemplateGetUpgradeNotesHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getProjectTemplateUpgradeNotesEndpoint,
+		Handler:  getProjectTemplateUpgradeNotesHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
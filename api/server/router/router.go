@@ -192,7 +192,7 @@ func registerRoutes(config *config.Config, routes []*Route) {
 	releaseFactory := authz.NewReleaseScopedFactory(config)
 
 	// Policy doc loader loads the policy documents for a specific project.
-	policyDocLoader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project())
+	policyDocLoader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole(), config.Repo.Team())
 
 	// set up logging middleware to log information about the request
 	loggerMw := middleware.NewRequestLoggerMiddleware(config.Logger)
@@ -203,15 +203,31 @@ func registerRoutes(config *config.Config, routes []*Route) {
 	for _, route := range routes {
 		atomicGroup := route.Router.Group(nil)
 
+		// all mutating requests are recorded in the audit log. For authenticated
+		// endpoints, the audit middleware is attached after authentication so that the
+		// actor is known.
+		auditMw := middleware.NewAuditMiddleware(config, *route.Endpoint.Metadata)
+		shouldAudit := route.Endpoint.Metadata.Method != types.HTTPVerbGet
+
+		if shouldAudit && !hasScope(route.Endpoint.Metadata.Scopes, types.UserScope) {
+			atomicGroup.Use(auditMw.Middleware)
+		}
+
 		for _, scope := range route.Endpoint.Metadata.Scopes {
 			switch scope {
 			case types.UserScope:
 				// if the endpoint should redirect when authn fails, attach redirect handler
 				if route.Endpoint.Metadata.ShouldRedirect {
 					atomicGroup.Use(authNFactory.NewAuthenticatedWithRedirect)
+				} else if route.Endpoint.Metadata.SkipTOTPEnforcement {
+					atomicGroup.Use(authNFactory.NewAuthenticatedWithoutTOTPEnforcement)
 				} else {
 					atomicGroup.Use(authNFactory.NewAuthenticated)
 				}
+
+				if shouldAudit {
+					atomicGroup.Use(auditMw.Middleware)
+				}
 			case types.ProjectScope:
 				policyFactory := authz.NewPolicyMiddleware(config, *route.Endpoint.Metadata, policyDocLoader)
 
@@ -259,3 +275,13 @@ func registerRoutes(config *config.Config, routes []*Route) {
 		)
 	}
 }
+
+func hasScope(scopes []types.PermissionScope, scope types.PermissionScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -108,7 +108,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/logout",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:              []types.PermissionScope{types.UserScope},
+			SkipTOTPEnforcement: true,
 		},
 	)
 
@@ -129,7 +130,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/users/current",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:              []types.PermissionScope{types.UserScope},
+			SkipTOTPEnforcement: true,
 		},
 	)
 
@@ -168,6 +170,179 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// POST /api/users/current/totp/enroll -> user.NewUserEnrollTOTPHandler
+	enrollTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/totp/enroll",
+			},
+			Scopes:              []types.PermissionScope{types.UserScope},
+			SkipTOTPEnforcement: true,
+		},
+	)
+
+	enrollTOTPHandler := user.NewUserEnrollTOTPHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: enrollTOTPEndpoint,
+		Handler:  enrollTOTPHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/totp/verify -> user.NewUserVerifyTOTPHandler
+	verifyTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/totp/verify",
+			},
+			Scopes:              []types.PermissionScope{types.UserScope},
+			SkipTOTPEnforcement: true,
+		},
+	)
+
+	verifyTOTPHandler := user.NewUserVerifyTOTPHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: verifyTOTPEndpoint,
+		Handler:  verifyTOTPHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/totp/disable -> user.NewUserDisableTOTPHandler
+	disableTOTPEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/totp/disable",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	disableTOTPHandler := user.NewUserDisableTOTPHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: disableTOTPEndpoint,
+		Handler:  disableTOTPHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/totp/recovery_codes -> user.NewUserRegenerateTOTPRecoveryCodesHandler
+	regenerateTOTPRecoveryCodesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/totp/recovery_codes",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	regenerateTOTPRecoveryCodesHandler := user.NewUserRegenerateTOTPRecoveryCodesHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: regenerateTOTPRecoveryCodesEndpoint,
+		Handler:  regenerateTOTPRecoveryCodesHandler,
+		Router:   r,
+	})
+
+	// GET /api/users/current/sessions -> user.NewUserListSessionsHandler
+	listSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/sessions",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	listSessionsHandler := user.NewUserListSessionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listSessionsEndpoint,
+		Handler:  listSessionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/users/current/sessions -> user.NewUserRevokeAllSessionsHandler
+	revokeAllSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/sessions",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	revokeAllSessionsHandler := user.NewUserRevokeAllSessionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: revokeAllSessionsEndpoint,
+		Handler:  revokeAllSessionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/users/current/sessions/{session_id} -> user.NewUserRevokeSessionHandler
+	revokeSessionEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/users/current/sessions/{%s}", types.URLParamSessionID),
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	revokeSessionHandler := user.NewUserRevokeSessionHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: revokeSessionEndpoint,
+		Handler:  revokeSessionHandler,
+		Router:   r,
+	})
+
 	// POST /api/projects -> project.NewProjectCreateHandler
 	createEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -7,6 +7,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/envloader"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/ratelimit"
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
@@ -54,6 +55,7 @@ func (t *TestConfigLoader) LoadConfig() (*config.Config, error) {
 		Logger:          l,
 		Repo:            repo,
 		Store:           store,
+		AuthLimiter:     ratelimit.NewLimiter(&ratelimit.LimiterOpts{Store: ratelimit.NewMemoryStore()}),
 		ServerConf:      envConf.ServerConf,
 		TokenConf:       tokenConf,
 		UserNotifier:    notifier,
@@ -4,8 +4,11 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/auth/ratelimit"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
@@ -38,6 +41,14 @@ type Config struct {
 	// Store implements a session store for session-based cookies
 	Store sessions.Store
 
+	// AuthLimiter throttles failed attempts on the login, password reset and CLI
+	// login endpoints
+	AuthLimiter *ratelimit.Limiter
+
+	// TrustedProxies are the proxies which are trusted to set the X-Forwarded-For
+	// header when determining the IP of a client
+	TrustedProxies *requestutils.TrustedProxies
+
 	// ServerConf is the set of configuration variables for the Porter server
 	ServerConf *env.ServerConf
 
@@ -66,6 +77,9 @@ type Config struct {
 	// GoogleConf is the configuration for a Google OAuth client
 	GoogleConf *oauth2.Config
 
+	// OIDCProvider is the generic OIDC provider used for single sign-on
+	OIDCProvider *oidc.Provider
+
 	// SlackConf is the configuration for a Slack OAuth client
 	SlackConf *oauth2.Config
 
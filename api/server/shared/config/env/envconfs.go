@@ -28,11 +28,39 @@ type ServerConf struct {
 	IsTesting            bool          `env:"IS_TESTING,default=false"`
 	AppRootDomain        string        `env:"APP_ROOT_DOMAIN,default=porter.run"`
 
+	// How often expired sessions are deleted from the database
+	SessionCleanupInterval time.Duration `env:"SESSION_CLEANUP_INTERVAL,default=1h"`
+
+	// How long the controllers of a new revision have to become healthy before the
+	// release is rolled back to the previous revision. Set to 0 to disable rollbacks.
+	AutoRollbackDeadline time.Duration `env:"AUTO_ROLLBACK_DEADLINE,default=5m"`
+
 	DefaultApplicationHelmRepoURL string `env:"HELM_APP_REPO_URL,default=https://charts.dev.getporter.dev"`
 	DefaultAddonHelmRepoURL       string `env:"HELM_ADD_ON_REPO_URL,default=https://chart-addons.dev.getporter.dev"`
 
 	BasicLoginEnabled bool `env:"BASIC_LOGIN_ENABLED,default=true"`
 
+	// Whether users that log in with an email and password must enroll in TOTP
+	// two-factor authentication before accessing any other endpoints
+	TOTPEnforced bool   `env:"TOTP_ENFORCED,default=false"`
+	TOTPIssuer   string `env:"TOTP_ISSUER,default=Porter"`
+
+	// Brute-force protection for the login, password reset and CLI login endpoints.
+	// After the free attempts, each failure blocks further attempts with an exponential
+	// backoff, and after the max attempts the IP or account is locked out.
+	LoginFreeAttemptsPerIP      int64         `env:"LOGIN_FREE_ATTEMPTS_PER_IP,default=20"`
+	LoginMaxAttemptsPerIP       int64         `env:"LOGIN_MAX_ATTEMPTS_PER_IP,default=100"`
+	LoginFreeAttemptsPerAccount int64         `env:"LOGIN_FREE_ATTEMPTS_PER_ACCOUNT,default=3"`
+	LoginMaxAttemptsPerAccount  int64         `env:"LOGIN_MAX_ATTEMPTS_PER_ACCOUNT,default=10"`
+	LoginAttemptWindow          time.Duration `env:"LOGIN_ATTEMPT_WINDOW,default=15m"`
+	LoginLockoutDuration        time.Duration `env:"LOGIN_LOCKOUT_DURATION,default=15m"`
+
+	// The proxies in front of the server which are trusted to set the X-Forwarded-For
+	// header, either as a number of proxies or as a list of networks separated by ";".
+	// If neither is set, the address of the connection is used as the client IP.
+	TrustedProxyCount int      `env:"TRUSTED_PROXY_COUNT,default=0"`
+	TrustedProxyCIDRs []string `env:"TRUSTED_PROXY_CIDRS"`
+
 	GithubClientID     string `env:"GITHUB_CLIENT_ID"`
 	GithubClientSecret string `env:"GITHUB_CLIENT_SECRET"`
 	GithubLoginEnabled bool   `env:"GITHUB_LOGIN_ENABLED,default=true"`
@@ -48,6 +76,23 @@ type ServerConf struct {
 	GoogleClientSecret     string `env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRestrictedDomain string `env:"GOOGLE_RESTRICTED_DOMAIN"`
 
+	// Generic OIDC login, configured with the issuer's discovery URL. List values
+	// are separated by semicolons.
+	OIDCIssuerURL    string   `env:"OIDC_ISSUER_URL"`
+	OIDCClientID     string   `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string   `env:"OIDC_CLIENT_SECRET"`
+	OIDCScopes       []string `env:"OIDC_SCOPES"`
+	OIDCProviderName string   `env:"OIDC_PROVIDER_NAME,default=SSO"`
+
+	// The claims that the user's email and groups are read from
+	OIDCEmailClaim  string `env:"OIDC_EMAIL_CLAIM,default=email"`
+	OIDCGroupsClaim string `env:"OIDC_GROUPS_CLAIM,default=groups"`
+
+	// If set, only users with an email in one of these domains, or who are a member
+	// of one of these groups, can log in
+	OIDCAllowedDomains []string `env:"OIDC_ALLOWED_DOMAINS"`
+	OIDCAllowedGroups  []string `env:"OIDC_ALLOWED_GROUPS"`
+
 	SendgridAPIKey                  string `env:"SENDGRID_API_KEY"`
 	SendgridPWResetTemplateID       string `env:"SENDGRID_PW_RESET_TEMPLATE_ID"`
 	SendgridPWGHTemplateID          string `env:"SENDGRID_PW_GH_TEMPLATE_ID"`
@@ -1,18 +1,23 @@
 package loader
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	gorillaws "github.com/gorilla/websocket"
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/server/shared/config/envloader"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/internal/adapter"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/auth/ratelimit"
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
@@ -86,11 +91,21 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 
 	res.Repo = gorm.NewRepository(InstanceDB, &key, InstanceCredentialBackend)
 
+	res.TrustedProxies, err = requestutils.NewTrustedProxies(
+		envConf.ServerConf.TrustedProxyCount,
+		envConf.ServerConf.TrustedProxyCIDRs,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
 	// create the session store
 	res.Store, err = sessionstore.NewStore(
 		&sessionstore.NewStoreOpts{
 			SessionRepository: res.Repo.Session(),
 			CookieSecrets:     envConf.ServerConf.CookieSecrets,
+			TrustedProxies:    res.TrustedProxies,
 		},
 	)
 
@@ -121,6 +136,8 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		res.Alerter, err = alerter.NewSentryAlerter(envConf.ServerConf.SentryDSN, envConf.ServerConf.SentryEnv)
 	}
 
+	res.AuthLimiter = getAuthLimiter(res, envConf.RedisConf)
+
 	if sc.DOClientID != "" && sc.DOClientSecret != "" {
 		res.DOConf = oauth.NewDigitalOceanClient(&oauth.Config{
 			ClientID:     sc.DOClientID,
@@ -143,6 +160,16 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	if res.Metadata.OIDCLogin {
+		res.OIDCProvider = oidc.NewProvider(&oidc.Config{
+			IssuerURL:    sc.OIDCIssuerURL,
+			ClientID:     sc.OIDCClientID,
+			ClientSecret: sc.OIDCClientSecret,
+			RedirectURL:  sc.ServerURL + "/api/oauth/oidc/callback",
+			Scopes:       sc.OIDCScopes,
+		})
+	}
+
 	if sc.GithubClientID != "" && sc.GithubClientSecret != "" {
 		res.GithubConf = oauth.NewGithubClient(&oauth.Config{
 			ClientID:     sc.GithubClientID,
@@ -219,6 +246,63 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	return res, nil
 }
 
+// getAuthLimiter returns a limiter backed by Redis if Redis is enabled and reachable,
+// and by an in-memory store otherwise. Lockouts are logged and sent to the alerter so
+// that admins are notified of brute-force attempts.
+func getAuthLimiter(res *config.Config, redisConf *env.RedisConf) *ratelimit.Limiter {
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+
+	if redisConf.Enabled {
+		redisClient, err := adapter.NewRedisClient(redisConf)
+
+		if err == nil {
+			store = ratelimit.NewRedisStore(redisClient)
+		} else {
+			res.Logger.Warn().Err(err).Msg("could not connect to redis, using in-memory store for login rate limiting")
+		}
+	}
+
+	sc := res.ServerConf
+
+	return ratelimit.NewLimiter(&ratelimit.LimiterOpts{
+		Store: store,
+		IPPolicy: &ratelimit.Policy{
+			FreeAttempts:    sc.LoginFreeAttemptsPerIP,
+			MaxAttempts:     sc.LoginMaxAttemptsPerIP,
+			Window:          sc.LoginAttemptWindow,
+			BaseBackoff:     time.Second,
+			LockoutDuration: sc.LoginLockoutDuration,
+		},
+		AccountPolicy: &ratelimit.Policy{
+			FreeAttempts:    sc.LoginFreeAttemptsPerAccount,
+			MaxAttempts:     sc.LoginMaxAttemptsPerAccount,
+			Window:          sc.LoginAttemptWindow,
+			BaseBackoff:     time.Second,
+			LockoutDuration: sc.LoginLockoutDuration,
+		},
+		OnLockout: func(event *ratelimit.LockoutEvent) {
+			res.Logger.Warn().
+				Str("kind", string(event.Kind)).
+				Str("value", event.Value).
+				Str("action", event.Action).
+				Int64("failures", event.Failures).
+				Dur("duration", event.Duration).
+				Msg("locked out after too many failed attempts")
+
+			res.Alerter.SendAlert(
+				context.Background(),
+				fmt.Errorf("%s %s locked out for %s after %d failed %s attempts", event.Kind, event.Value, event.Duration, event.Failures, event.Action),
+				map[string]interface{}{
+					"kind":     event.Kind,
+					"value":    event.Value,
+					"action":   event.Action,
+					"failures": event.Failures,
+				},
+			)
+		},
+	})
+}
+
 func getProvisionerServiceClient(sc *env.ServerConf) (*client.Client, error) {
 	if sc.ProvisionerServerURL != "" && sc.ProvisionerToken != "" {
 		baseURL := fmt.Sprintf("%s/api/v1", sc.ProvisionerServerURL)
@@ -0,0 +1,116 @@
+package requestutils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies configures which proxies in front of the server are trusted to append
+// the address of the client to the X-Forwarded-For header. Either the number of proxies
+// or the networks of the proxies can be set.
+type TrustedProxies struct {
+	// Count is the number of proxies in front of the server
+	Count int
+
+	// CIDRs are the networks of the proxies in front of the server
+	CIDRs []*net.IPNet
+}
+
+// NewTrustedProxies parses the trusted proxy configuration. It returns nil if no
+// proxies are trusted.
+func NewTrustedProxies(count int, cidrs []string) (*TrustedProxies, error) {
+	res := &TrustedProxies{
+		Count: count,
+		CIDRs: make([]*net.IPNet, 0),
+	}
+
+	for _, cidr := range cidrs {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(cidr)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy network %s: %w", cidr, err)
+		}
+
+		res.CIDRs = append(res.CIDRs, ipNet)
+	}
+
+	if res.Count == 0 && len(res.CIDRs) == 0 {
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+// GetClientIP returns the IP address of the client that made the request. The address
+// of the connection is used unless the request was forwarded by trusted proxies, in
+// which case the X-Forwarded-For header is read from the right, since each proxy
+// appends the address that it received the request from and any entries to the left
+// of the trusted proxies can be set by the client.
+func GetClientIP(r *http.Request, proxies *TrustedProxies) string {
+	remoteIP := r.RemoteAddr
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remoteIP = host
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+
+	if proxies == nil || len(forwarded) == 0 {
+		return remoteIP
+	}
+
+	addrs := make([]string, 0)
+
+	for _, header := range forwarded {
+		for _, addr := range strings.Split(header, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+
+	if len(addrs) == 0 {
+		return remoteIP
+	}
+
+	if len(proxies.CIDRs) > 0 {
+		// walk back through the addresses until one which is not a trusted proxy is found
+		clientIP := remoteIP
+
+		for i := len(addrs) - 1; i >= 0 && proxies.isTrusted(clientIP); i-- {
+			clientIP = addrs[i]
+		}
+
+		return clientIP
+	}
+
+	// each of the proxies appends one address, so the address appended by the
+	// outermost proxy is the address of the client
+	if len(addrs) < proxies.Count {
+		return addrs[0]
+	}
+
+	return addrs[len(addrs)-proxies.Count]
+}
+
+func (p *TrustedProxies) isTrusted(addr string) bool {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range p.CIDRs {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var getClientIPTests = []struct {
+	description string
+	proxyCount  int
+	proxyCIDRs  []string
+	remoteAddr  string
+	forwarded   string
+	expIP       string
+}{
+	{
+		description: "should use remote address",
+		remoteAddr:  "10.0.0.1:1234",
+		expIP:       "10.0.0.1",
+	},
+	{
+		description: "should ignore forwarded addresses without trusted proxies",
+		remoteAddr:  "10.0.0.1:1234",
+		forwarded:   "1.1.1.1, 2.2.2.2",
+		expIP:       "10.0.0.1",
+	},
+	{
+		description: "should use address appended by single proxy",
+		proxyCount:  1,
+		remoteAddr:  "10.0.0.1:1234",
+		forwarded:   "1.1.1.1, 2.2.2.2",
+		expIP:       "2.2.2.2",
+	},
+	{
+		description: "should use address appended by outermost proxy",
+		proxyCount:  2,
+		remoteAddr:  "10.0.0.1:1234",
+		forwarded:   "1.1.1.1, 2.2.2.2, 10.0.0.2",
+		expIP:       "2.2.2.2",
+	},
+	{
+		description: "should use first untrusted address",
+		proxyCIDRs:  []string{"10.0.0.0/8"},
+		remoteAddr:  "10.0.0.1:1234",
+		forwarded:   "1.1.1.1, 2.2.2.2, 10.0.0.2",
+		expIP:       "2.2.2.2",
+	},
+	{
+		description: "should ignore forwarded addresses from untrusted remote",
+		proxyCIDRs:  []string{"10.0.0.0/8"},
+		remoteAddr:  "3.3.3.3:1234",
+		forwarded:   "1.1.1.1",
+		expIP:       "3.3.3.3",
+	},
+	{
+		description: "should use leftmost address if all are trusted",
+		proxyCIDRs:  []string{"10.0.0.0/8"},
+		remoteAddr:  "10.0.0.1:1234",
+		forwarded:   "10.0.0.3, 10.0.0.2",
+		expIP:       "10.0.0.3",
+	},
+}
+
+func TestGetClientIP(t *testing.T) {
+	for _, test := range getClientIPTests {
+		proxies, err := requestutils.NewTrustedProxies(test.proxyCount, test.proxyCIDRs)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remoteAddr
+
+		if test.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+
+		assert.Equal(t, test.expIP, requestutils.GetClientIP(req, proxies), "[ %s ]: ip not equal", test.description)
+	}
+}
+
+func TestNewTrustedProxiesInvalidCIDR(t *testing.T) {
+	_, err := requestutils.NewTrustedProxies(0, []string{"10.0.0.0"})
+
+	assert.Error(t, err)
+}
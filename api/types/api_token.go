@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+const URLParamAPITokenID URLParam = "api_token_id"
+
+type APITokenMeta struct {
+	ID              uint      `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ProjectID       uint      `json:"project_id"`
+	CreatedByUserID uint      `json:"created_by_user_id"`
+	Name            string    `json:"name"`
+	Role            RoleKind  `json:"role"`
+
+	// ServiceAccountID is set if the token is owned by a service account, in which
+	// case the token's access is determined by the service account's role
+	ServiceAccountID uint `json:"service_account_id,omitempty"`
+
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Revoked    bool       `json:"revoked"`
+}
+
+type APIToken struct {
+	*APITokenMeta
+
+	// The encoded token, which is only returned when the token is created
+	Token string `json:"token"`
+}
+
+type CreateAPITokenRequest struct {
+	Name string   `json:"name" form:"required,max=255"`
+	Role RoleKind `json:"role" form:"required,oneof=admin developer viewer"`
+
+	// Optional expiry for the token. Tokens without an expiry are valid until revoked.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type CreateAPITokenResponse APIToken
+
+type ListAPITokensResponse []*APITokenMeta
+
+type GetAPITokenResponse APITokenMeta
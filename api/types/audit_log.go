@@ -0,0 +1,64 @@
+package types
+
+import "time"
+
+type AuditLogOutcome string
+
+const (
+	AuditLogOutcomeSuccess AuditLogOutcome = "success"
+	AuditLogOutcomeFailure AuditLogOutcome = "failure"
+)
+
+// AuditLog is a record of a mutating API call
+type AuditLog struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+
+	// The actor is either a user or an API token. Actor is a readable description of
+	// the actor at the time of the request, i.e. the user's email or the token's name.
+	UserID     uint   `json:"user_id,omitempty"`
+	APITokenID uint   `json:"api_token_id,omitempty"`
+	Actor      string `json:"actor"`
+	IPAddress  string `json:"ip_address"`
+
+	// ScopePath is the path of resources the request acted on, for example
+	// "project/1/cluster/2/namespace/default/release/web"
+	ScopePath string   `json:"scope_path"`
+	Verb      APIVerb  `json:"verb"`
+	Method    HTTPVerb `json:"method"`
+	Path      string   `json:"path"`
+
+	// Summary describes the request without including any request values, which may
+	// contain secrets
+	Summary string `json:"summary"`
+
+	StatusCode int             `json:"status_code"`
+	Outcome    AuditLogOutcome `json:"outcome"`
+}
+
+type ListAuditLogsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+
+	UserID     uint            `schema:"user_id"`
+	APITokenID uint            `schema:"api_token_id"`
+	Verb       APIVerb         `schema:"verb" form:"omitempty,oneof=create update delete"`
+	Outcome    AuditLogOutcome `schema:"outcome" form:"omitempty,oneof=success failure"`
+
+	// ScopePath filters logs to a scope path and its children, for example
+	// "project/1/cluster/2" matches all requests against cluster 2
+	ScopePath string `schema:"scope_path"`
+
+	// Since and Until filter logs by creation time, as unix timestamps
+	Since int64 `schema:"since"`
+	Until int64 `schema:"until"`
+}
+
+type ListAuditLogsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	AuditLogs []*AuditLog `json:"audit_logs"`
+}
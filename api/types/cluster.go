@@ -30,8 +30,22 @@ type Cluster struct {
 
 	// (optional) The aws integration id, if available
 	AWSIntegrationID uint `json:"aws_integration_id"`
+
+	// The storage of the Helm releases of this cluster
+	HelmStorage HelmStorage `json:"helm_storage"`
 }
 
+// HelmStorage is where the Helm releases of a cluster are stored
+type HelmStorage string
+
+const (
+	// HelmStorageSecret stores releases in secrets in the cluster, which is the default
+	HelmStorageSecret HelmStorage = "secret"
+
+	// HelmStorageSQL stores releases in Porter's database
+	HelmStorageSQL HelmStorage = "sql"
+)
+
 type ClusterCandidate struct {
 	ID uint `json:"id"`
 
@@ -242,6 +256,17 @@ type UpdateClusterRequest struct {
 	Name string `json:"name" form:"required"`
 }
 
+type UpdateClusterHelmStorageRequest struct {
+	Storage HelmStorage `json:"storage" form:"required,oneof=secret sql"`
+}
+
+type UpdateClusterHelmStorageResponse struct {
+	*Cluster
+
+	// MigratedRevisions is the number of release revisions copied to the new storage
+	MigratedRevisions int `json:"migrated_revisions"`
+}
+
 type ListClusterResponse []*Cluster
 
 type CreateClusterCandidateResponse []*ClusterCandidate
@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+const URLParamCustomRoleID URLParam = "custom_role_id"
+
+// CustomRole is a project role which grants access according to its policy
+// documents, instead of one of the built-in role kinds
+type CustomRole struct {
+	ID        uint              `json:"id"`
+	CreatedAt time.Time         `json:"created_at"`
+	ProjectID uint              `json:"project_id"`
+	Name      string            `json:"name"`
+	Policy    []*PolicyDocument `json:"policy"`
+}
+
+type CreateCustomRoleRequest struct {
+	Name   string            `json:"name" form:"required,max=255"`
+	Policy []*PolicyDocument `json:"policy" form:"required,min=1"`
+}
+
+type CreateCustomRoleResponse CustomRole
+
+type UpdateCustomRoleRequest struct {
+	Name   string            `json:"name" form:"max=255"`
+	Policy []*PolicyDocument `json:"policy"`
+}
+
+type UpdateCustomRoleResponse CustomRole
+
+type ListCustomRolesResponse []*CustomRole
+
+type DeleteCustomRoleResponse CustomRole
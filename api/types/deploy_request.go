@@ -0,0 +1,92 @@
+package types
+
+import "time"
+
+const (
+	URLParamProtectedResourceID URLParam = "protected_resource_id"
+	URLParamDeployRequestID     URLParam = "deploy_request_id"
+)
+
+// ProtectedResource marks a namespace, or a single release in a namespace, as
+// protected. Upgrades of protected releases create a deploy request which must be
+// approved before the upgrade runs.
+type ProtectedResource struct {
+	ID              uint      `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ProjectID       uint      `json:"project_id"`
+	CreatedByUserID uint      `json:"created_by_user_id"`
+	ClusterID       uint      `json:"cluster_id"`
+	Namespace       string    `json:"namespace"`
+
+	// ReleaseName is empty if every release in the namespace is protected
+	ReleaseName string `json:"release_name,omitempty"`
+}
+
+type CreateProtectedResourceRequest struct {
+	ClusterID   uint   `json:"cluster_id" form:"required"`
+	Namespace   string `json:"namespace" form:"required"`
+	ReleaseName string `json:"release_name"`
+}
+
+type CreateProtectedResourceResponse ProtectedResource
+
+type ListProtectedResourcesResponse []*ProtectedResource
+
+type DeleteProtectedResourceResponse ProtectedResource
+
+type DeployRequestStatus string
+
+const (
+	DeployRequestStatusPending  DeployRequestStatus = "pending"
+	DeployRequestStatusApproved DeployRequestStatus = "approved"
+	DeployRequestStatusRejected DeployRequestStatus = "rejected"
+
+	// DeployRequestStatusFailed is set when a deploy request was approved, but the
+	// upgrade failed
+	DeployRequestStatusFailed DeployRequestStatus = "failed"
+)
+
+type DeployRequestSource string
+
+const (
+	DeployRequestSourceUpgrade          DeployRequestSource = "upgrade"
+	DeployRequestSourceWebhook          DeployRequestSource = "webhook"
+	DeployRequestSourceUpdateImageBatch DeployRequestSource = "update_image_batch"
+	DeployRequestSourcePromote          DeployRequestSource = "promote"
+)
+
+// DeployRequest is a pending upgrade of a protected release
+type DeployRequest struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+
+	Source       DeployRequestSource `json:"source"`
+	ChartVersion string              `json:"chart_version,omitempty"`
+
+	// RequestedByUserID is 0 if the upgrade was triggered by a webhook
+	RequestedByUserID uint `json:"requested_by_user_id"`
+
+	Status           DeployRequestStatus `json:"status"`
+	ReviewedByUserID uint                `json:"reviewed_by_user_id,omitempty"`
+	ReviewComment    string              `json:"review_comment,omitempty"`
+
+	// Error is the error of the upgrade if the status is failed
+	Error string `json:"error,omitempty"`
+}
+
+type ListDeployRequestsRequest struct {
+	Status DeployRequestStatus `schema:"status"`
+}
+
+type ListDeployRequestsResponse []*DeployRequest
+
+type ReviewDeployRequestRequest struct {
+	Comment string `json:"comment" form:"max=1024"`
+}
+
+type ReviewDeployRequestResponse DeployRequest
@@ -1,7 +1,8 @@
 package types
 
 const (
-	ErrCodeUnavailable uint = 601
+	ErrCodeUnavailable  uint = 601
+	ErrCodeTOTPRequired uint = 602
 )
 
 type ExternalError struct {
@@ -10,6 +10,13 @@ type HelmRepo struct {
 	Name string `json:"name"`
 
 	RepoURL string `json:"repo_name"`
+
+	// VerifyProvenance is true if charts from the repo must be signed by a key in the
+	// keyring to be installed or upgraded
+	VerifyProvenance bool `json:"verify_provenance"`
+
+	// Keyring is the PGP public keyring which verifies the provenance of charts
+	Keyring string `json:"keyring,omitempty"`
 }
 
 type GetHelmRepoResponse HelmRepo
@@ -18,4 +25,16 @@ type CreateHelmRepoRequest struct {
 	URL                string `json:"url"`
 	Name               string `json:"name" form:"required"`
 	BasicIntegrationID uint   `json:"basic_integration_id"`
+
+	HelmRepoProvenanceConfig
 }
+
+// HelmRepoProvenanceConfig configures the provenance verification of the charts in a
+// helm repo. The keyring is an armored or binary PGP public keyring, and is required
+// if verification is enabled.
+type HelmRepoProvenanceConfig struct {
+	Keyring          string `json:"keyring"`
+	VerifyProvenance bool   `json:"verify_provenance"`
+}
+
+type UpdateHelmRepoProvenanceRequest HelmRepoProvenanceConfig
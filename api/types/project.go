@@ -36,14 +36,43 @@ type ListProjectInfraResponse []*Infra
 
 type GetProjectPolicyResponse []*PolicyDocument
 
+// CanIRequest asks whether a subject can perform a verb on a scope path within the
+// project. The path is made up of the project, and optionally a cluster, namespace and
+// release, or the project settings. The subject is the requesting user or token unless
+// a user or API token is given.
+type CanIRequest struct {
+	Verb      APIVerb `schema:"verb" form:"required,oneof=get list create update delete"`
+	ClusterID uint    `schema:"cluster_id"`
+	Namespace string  `schema:"namespace"`
+	Release   string  `schema:"release"`
+	Settings  bool    `schema:"settings"`
+
+	UserID     uint `schema:"user_id"`
+	APITokenID uint `schema:"api_token_id"`
+}
+
+type CanIResponse struct {
+	Allowed bool `json:"allowed"`
+
+	// MatchingPolicyDocument is the policy document which permits the action, if
+	// the action is allowed
+	MatchingPolicyDocument *PolicyDocument `json:"matching_policy_document,omitempty"`
+}
+
 type ListProjectRolesResponse []RoleKind
 
 type Collaborator struct {
-	ID        uint   `json:"id"`
-	Kind      string `json:"kind"`
-	UserID    uint   `json:"user_id"`
-	Email     string `json:"email"`
-	ProjectID uint   `json:"project_id"`
+	ID           uint   `json:"id"`
+	Kind         string `json:"kind"`
+	CustomRoleID uint   `json:"custom_role_id,omitempty"`
+	UserID       uint   `json:"user_id"`
+	Email        string `json:"email"`
+	ProjectID    uint   `json:"project_id"`
+
+	// ServiceAccountID and ServiceAccountName are set if the collaborator is a
+	// service account rather than a user
+	ServiceAccountID   uint   `json:"service_account_id,omitempty"`
+	ServiceAccountName string `json:"service_account_name,omitempty"`
 }
 
 type ListCollaboratorsResponse []*Collaborator
@@ -51,6 +80,9 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// CustomRoleID must be set to a custom role in the project if the kind is custom
+	CustomRoleID uint `json:"custom_role_id"`
 }
 
 type UpdateRoleResponse struct {
@@ -105,3 +137,30 @@ type OnboardingData struct {
 }
 
 type UpdateOnboardingRequest OnboardingData
+
+// DeployPolicy is a set of rules which the rendered manifests of every release in a
+// project are checked against before the release is installed or upgraded, along with
+// the changes which are made to the manifests on deploy
+type DeployPolicy struct {
+	// RequireResourceLimits requires every container to set CPU and memory limits
+	RequireResourceLimits bool `json:"require_resource_limits"`
+
+	// ForbidPrivileged forbids privileged containers
+	ForbidPrivileged bool `json:"forbid_privileged"`
+
+	// ForbidHostPath forbids hostPath volumes
+	ForbidHostPath bool `json:"forbid_host_path"`
+
+	// RequiredLabels are the labels which every object must set
+	RequiredLabels []string `json:"required_labels" form:"dive,required,excludesall=0x2C"`
+
+	// AllowedRegistries restricts the images of containers to these registries, such as
+	// "gcr.io/my-project". If empty, images from any registry are allowed.
+	AllowedRegistries []string `json:"allowed_registries" form:"dive,required,excludesall=0x2C"`
+
+	// PinImageDigests rewrites the image of every container to the digest which its
+	// tag points to, so that rolling back a release deploys the same images
+	PinImageDigests bool `json:"pin_image_digests"`
+}
+
+type UpdateDeployPolicyRequest DeployPolicy
@@ -12,6 +12,17 @@ type Release struct {
 	*PorterRelease
 
 	Form *FormYAML `json:"form,omitempty"`
+
+	// ImageDigests are the images of the revision which were pinned to digests
+	ImageDigests []*ImageDigest `json:"image_digests,omitempty"`
+}
+
+// ImageDigest is an image of a release revision which was pinned to the digest of its
+// tag on deploy
+type ImageDigest struct {
+	// Image is the name and tag of the image, such as gcr.io/project/web:latest
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
 }
 
 type PorterRelease struct {
@@ -62,6 +73,222 @@ type RollbackReleaseRequest struct {
 type UpgradeReleaseRequest struct {
 	Values       string `json:"values" form:"required"`
 	ChartVersion string `json:"version"`
+
+	// DryRun renders the upgrade without applying it, and returns the diff against
+	// the deployed manifest
+	DryRun bool `json:"dry_run"`
+}
+
+// UpgradeReleaseResponse is returned by the upgrade endpoints. If the release is
+// protected, the upgrade does not run and a pending deploy request is returned.
+type UpgradeReleaseResponse struct {
+	DeployRequest *DeployRequest `json:"deploy_request,omitempty"`
+
+	// ManifestDiff is only set for dry-run upgrades
+	ManifestDiff []*ManifestDiff `json:"manifest_diff,omitempty"`
+
+	// RolloutStrategy is set if the upgrade is rolled out in the background as a
+	// canary or blue-green rollout
+	RolloutStrategy RolloutStrategy `json:"rollout_strategy,omitempty"`
+}
+
+type ManifestDiffStatus string
+
+const (
+	ManifestDiffStatusAdded     ManifestDiffStatus = "added"
+	ManifestDiffStatusRemoved   ManifestDiffStatus = "removed"
+	ManifestDiffStatusChanged   ManifestDiffStatus = "changed"
+	ManifestDiffStatusUnchanged ManifestDiffStatus = "unchanged"
+)
+
+// ManifestDiff is the diff of a single object between two rendered manifests
+type ManifestDiff struct {
+	Kind      string             `json:"kind"`
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+	Status    ManifestDiffStatus `json:"status"`
+
+	// Diff is a unified diff of the object, empty if the object is unchanged
+	Diff string `json:"diff,omitempty"`
+}
+
+// GetReleaseHistoryResponse is the list of stored revisions of a release
+type GetReleaseHistoryResponse []*release.Release
+
+// ValuesDiff is the diff of a single user-supplied value between two revisions of a
+// release. The path is the dot-separated path of the value, and lists are compared
+// as a single value.
+type ValuesDiff struct {
+	Path     string             `json:"path"`
+	Status   ManifestDiffStatus `json:"status"`
+	OldValue interface{}        `json:"old_value,omitempty"`
+	NewValue interface{}        `json:"new_value,omitempty"`
+}
+
+// GetReleaseDiffRequest selects the revisions to diff. The "to" revision defaults to
+// the latest revision, and the "from" revision defaults to the revision before it.
+type GetReleaseDiffRequest struct {
+	FromRevision int `schema:"from"`
+	ToRevision   int `schema:"to"`
+}
+
+// GetReleaseDiffResponse is the diff of the user-supplied values, chart version and
+// rendered manifest between two revisions of a release. Unchanged values are omitted.
+type GetReleaseDiffResponse struct {
+	FromRevision     int             `json:"from_revision"`
+	ToRevision       int             `json:"to_revision"`
+	FromChartVersion string          `json:"from_chart_version"`
+	ToChartVersion   string          `json:"to_chart_version"`
+	ValuesDiff       []*ValuesDiff   `json:"values_diff"`
+	ManifestDiff     []*ManifestDiff `json:"manifest_diff"`
+}
+
+type DriftStatus string
+
+const (
+	DriftStatusInSync  DriftStatus = "in_sync"
+	DriftStatusDrifted DriftStatus = "drifted"
+	DriftStatusMissing DriftStatus = "missing"
+)
+
+// FieldDrift is a field of a live object which does not match the release manifest.
+// The path is the dot-separated path of the field, with list indices in brackets.
+type FieldDrift struct {
+	Path     string      `json:"path"`
+	Expected interface{} `json:"expected"`
+	Live     interface{} `json:"live"`
+}
+
+// ObjectDrift is the drift of a single object in the release manifest from the live
+// object in the cluster
+type ObjectDrift struct {
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name"`
+	Status    DriftStatus `json:"status"`
+
+	// Fields is only set if the object has drifted
+	Fields []*FieldDrift `json:"fields,omitempty"`
+}
+
+// GetReleaseDriftResponse compares each object in the manifest of a release revision
+// against the live object in the cluster. Only fields which are set in the manifest
+// are compared, so fields which are defaulted or managed by the cluster are ignored.
+type GetReleaseDriftResponse struct {
+	Revision int            `json:"revision"`
+	Drifted  bool           `json:"drifted"`
+	Objects  []*ObjectDrift `json:"objects"`
+}
+
+// ReconcileReleaseDriftResponse is the drift of a release after it has been re-applied.
+// Reconciled is false if the release had not drifted, in which case it is not re-applied.
+type ReconcileReleaseDriftResponse struct {
+	GetReleaseDriftResponse
+
+	Reconciled bool `json:"reconciled"`
+}
+
+// ManifestOverlayPatchType is the type of patch applied by a manifest overlay
+type ManifestOverlayPatchType string
+
+const (
+	// ManifestOverlayPatchTypeJSON is a JSON patch (RFC 6902), which is a list of
+	// operations
+	ManifestOverlayPatchTypeJSON ManifestOverlayPatchType = "json"
+
+	// ManifestOverlayPatchTypeStrategicMerge is a strategic merge patch, which is a
+	// partial object. Objects whose kind is not built into Kubernetes are patched
+	// with a JSON merge patch instead.
+	ManifestOverlayPatchTypeStrategicMerge ManifestOverlayPatchType = "strategic-merge"
+)
+
+// ManifestOverlay is a patch which is applied to the rendered manifests of a
+// release, for fields which the chart does not expose as values. It targets the
+// objects of a kind, or a single object if the name is set.
+type ManifestOverlay struct {
+	Kind      string                   `json:"kind" form:"required"`
+	Name      string                   `json:"name,omitempty"`
+	PatchType ManifestOverlayPatchType `json:"patch_type" form:"required,oneof=json strategic-merge"`
+	Patch     interface{}              `json:"patch" form:"required"`
+}
+
+type ListManifestOverlaysResponse []*ManifestOverlay
+
+// UpdateManifestOverlaysRequest replaces the overlays of a release. The overlays
+// are applied in order the next time the release is installed or upgraded.
+type UpdateManifestOverlaysRequest struct {
+	Overlays []*ManifestOverlay `json:"overlays" form:"dive"`
+}
+
+// PromoteReleaseRequest copies a release to another cluster or namespace in the
+// same project. The chart version, values, image tag and env group links of the
+// source release are copied, unless they are overridden.
+type PromoteReleaseRequest struct {
+	TargetClusterID uint   `json:"target_cluster_id" form:"required"`
+	TargetNamespace string `json:"target_namespace" form:"required"`
+
+	// TargetName defaults to the name of the source release
+	TargetName string `json:"target_name"`
+
+	// ChartVersion defaults to the chart version of the source release
+	ChartVersion string `json:"chart_version"`
+
+	// ImageTag defaults to the image tag of the source release
+	ImageTag string `json:"image_tag"`
+
+	// Values are merged over the values of the source release
+	Values string `json:"values"`
+
+	// DryRun renders the promoted release without applying it, and returns the diff
+	// against the target release
+	DryRun bool `json:"dry_run"`
+}
+
+// PromoteReleaseResponse is returned by the promote endpoint. If the target release
+// is protected, the promotion does not run and a pending deploy request is returned.
+type PromoteReleaseResponse struct {
+	// Created is true if the release did not exist in the target namespace
+	Created bool `json:"created"`
+
+	// EnvGroups are the env groups which the target release is linked to
+	EnvGroups []string `json:"env_groups"`
+
+	DeployRequest   *DeployRequest  `json:"deploy_request,omitempty"`
+	RolloutStrategy RolloutStrategy `json:"rollout_strategy,omitempty"`
+	ManifestDiff    []*ManifestDiff `json:"manifest_diff,omitempty"`
+}
+
+// GetPorterYAMLResponse is a porter.yaml resource group which creates or updates the
+// release with "porter apply"
+type GetPorterYAMLResponse struct {
+	Version   string                `json:"version"`
+	Resources []*PorterYAMLResource `json:"resources"`
+}
+
+// PorterYAMLResource is a resource in a porter.yaml file. For Porter applications,
+// the config contains the build config, env groups and values of the release. For
+// other charts, the config contains only the values.
+type PorterYAMLResource struct {
+	Name   string                 `json:"name"`
+	Driver string                 `json:"driver"`
+	Source *PorterYAMLSource      `json:"source"`
+	Target *PorterYAMLTarget      `json:"target"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// PorterYAMLSource is the chart of a porter.yaml resource. The repo is not set for
+// Porter applications, since "porter apply" looks them up in the Porter chart
+// repositories.
+type PorterYAMLSource struct {
+	Name    string `json:"name"`
+	Repo    string `json:"repo,omitempty"`
+	Version string `json:"version"`
+}
+
+type PorterYAMLTarget struct {
+	Project   uint   `json:"project"`
+	Cluster   uint   `json:"cluster"`
+	Namespace string `json:"namespace"`
 }
 
 type UpdateImageBatchRequest struct {
@@ -69,6 +296,14 @@ type UpdateImageBatchRequest struct {
 	Tag          string `json:"tag" form:"required"`
 }
 
+// UpdateImageBatchResponse lists the deploy requests which were created for the
+// protected releases with the image, instead of upgrading them, and the errors for
+// the releases which could not be updated
+type UpdateImageBatchResponse struct {
+	DeployRequests []*DeployRequest `json:"deploy_requests"`
+	Errors         []string         `json:"errors,omitempty"`
+}
+
 type GetJobsStatusResponse struct {
 	Status    string       `json:"status,omitempty"`
 	StartTime *metav1.Time `json:"start_time,omitempty"`
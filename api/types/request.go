@@ -70,10 +70,22 @@ type APIRequestMetadata struct {
 
 	// The usage metric that the request should check for, if CheckUsage
 	UsageMetric UsageMetric
+
+	// Whether the endpoint can be called by users that have not enrolled in TOTP
+	// when TOTP is enforced
+	SkipTOTPEnforcement bool
 }
 
 const RequestScopeCtxKey = "requestscopes"
 
+// APITokenCtxKey is the context key for the stored API token that a request was
+// authenticated with, if any
+const APITokenCtxKey = "apitoken"
+
+// PolicyDocsCtxKey is the context key for the policy documents that a request was
+// authorized with, which are used to filter lists by resource grants
+const PolicyDocsCtxKey = "policydocs"
+
 type RequestAction struct {
 	Verb     APIVerb
 	Resource NameOrUInt
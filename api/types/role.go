@@ -13,4 +13,8 @@ type Role struct {
 	Kind      RoleKind `json:"kind"`
 	UserID    uint     `json:"user_id"`
 	ProjectID uint     `json:"project_id"`
+
+	// CustomRoleID is the custom role that the policy is loaded from when the
+	// kind is custom
+	CustomRoleID uint `json:"custom_role_id,omitempty"`
 }
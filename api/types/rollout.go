@@ -0,0 +1,38 @@
+package types
+
+type RolloutStrategy string
+
+const (
+	// RolloutStrategyRolling upgrades the release in place, which is the default
+	RolloutStrategyRolling RolloutStrategy = "rolling"
+
+	// RolloutStrategyCanary deploys the new revision alongside the stable one, and
+	// shifts traffic to it in steps
+	RolloutStrategyCanary RolloutStrategy = "canary"
+
+	// RolloutStrategyBlueGreen deploys the new revision alongside the stable one at
+	// full scale, and shifts all traffic to it once it is healthy
+	RolloutStrategyBlueGreen RolloutStrategy = "blue_green"
+)
+
+// RolloutConfig configures how upgrades of a web release are rolled out
+type RolloutConfig struct {
+	Strategy RolloutStrategy `json:"strategy" form:"required,oneof=rolling canary blue_green"`
+
+	// Steps are the percentages of traffic sent to the canary, in order. They are
+	// only used by the canary strategy.
+	Steps []uint `json:"steps,omitempty" form:"omitempty,dive,min=1,max=100"`
+
+	// StepIntervalSeconds is how long each step runs before the canary is checked
+	StepIntervalSeconds uint `json:"step_interval_seconds" form:"omitempty,min=10"`
+
+	// MaxErrorRate is the highest percentage of 5xx responses from the canary for
+	// which the rollout continues
+	MaxErrorRate float64 `json:"max_error_rate" form:"omitempty,min=0,max=100"`
+}
+
+type GetRolloutConfigResponse RolloutConfig
+
+type UpdateRolloutConfigRequest RolloutConfig
+
+type UpdateRolloutConfigResponse RolloutConfig
@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+const URLParamServiceAccountID URLParam = "service_account_id"
+
+// ServiceAccount is a non-human member of a project. Service accounts hold a role
+// in the project like any other collaborator, and own the API tokens issued for
+// them, so that the tokens do not depend on the user who created them.
+type ServiceAccount struct {
+	ID              uint      `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ProjectID       uint      `json:"project_id"`
+	CreatedByUserID uint      `json:"created_by_user_id"`
+	Name            string    `json:"name"`
+
+	// UserID is the id of the service account's user, which can be used to update
+	// the role of the service account through the role API
+	UserID uint  `json:"user_id"`
+	Role   *Role `json:"role,omitempty"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name string   `json:"name" form:"required,max=255"`
+	Kind RoleKind `json:"kind" form:"required,oneof=admin developer viewer custom"`
+
+	// CustomRoleID must be set to a custom role in the project if the kind is custom
+	CustomRoleID uint `json:"custom_role_id"`
+}
+
+type CreateServiceAccountResponse ServiceAccount
+
+type ListServiceAccountsResponse []*ServiceAccount
+
+type DeleteServiceAccountResponse ServiceAccount
+
+type CreateServiceAccountAPITokenRequest struct {
+	Name string `json:"name" form:"required,max=255"`
+
+	// Optional expiry for the token. Tokens without an expiry are valid until revoked.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+const (
+	URLParamSessionID URLParam = "session_id"
+	URLParamUserID    URLParam = "user_id"
+)
+
+type Session struct {
+	ID         uint      `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+
+	// Whether this is the session that the request was made with
+	Current bool `json:"current"`
+}
+
+type ListSessionsResponse []*Session
+
+type RevokeSessionsResponse struct {
+	// The number of sessions that were revoked
+	NumRevoked int `json:"num_revoked"`
+}
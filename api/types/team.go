@@ -0,0 +1,56 @@
+package types
+
+import "time"
+
+const URLParamTeamID URLParam = "team_id"
+
+// Team is a group of project collaborators with a role binding. Members of a team
+// are granted the policy of the team's role in addition to their own role.
+type Team struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+	Name      string    `json:"name"`
+
+	Kind         RoleKind `json:"kind"`
+	CustomRoleID uint     `json:"custom_role_id,omitempty"`
+
+	Members []*TeamMember `json:"members"`
+}
+
+type TeamMember struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+type CreateTeamRequest struct {
+	Name string   `json:"name" form:"required,max=255"`
+	Kind RoleKind `json:"kind" form:"required,oneof=admin developer viewer custom"`
+
+	// CustomRoleID must be set to a custom role in the project if the kind is custom
+	CustomRoleID uint `json:"custom_role_id"`
+}
+
+type CreateTeamResponse Team
+
+type ListTeamsResponse []*Team
+
+type UpdateTeamRequest struct {
+	Name string   `json:"name" form:"max=255"`
+	Kind RoleKind `json:"kind" form:"omitempty,oneof=admin developer viewer custom"`
+
+	// CustomRoleID must be set to a custom role in the project if the kind is custom
+	CustomRoleID uint `json:"custom_role_id"`
+}
+
+type UpdateTeamResponse Team
+
+type DeleteTeamResponse Team
+
+type AddTeamMemberRequest struct {
+	UserID uint `json:"user_id" form:"required"`
+}
+
+type AddTeamMemberResponse Team
+
+type RemoveTeamMemberResponse Team
@@ -4,6 +4,7 @@ type User struct {
 	ID            uint   `json:"id"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
+	TOTPEnabled   bool   `json:"totp_enabled"`
 }
 
 type CreateUserRequest struct {
@@ -18,6 +19,10 @@ type GetAuthenticatedUserResponse User
 type LoginUserRequest struct {
 	Email    string `json:"email" form:"required,max=255,email"`
 	Password string `json:"password" form:"required,max=255"`
+
+	// TOTPCode is either a code from the user's authenticator app or one of the
+	// user's recovery codes. It is only required if the user has enabled TOTP.
+	TOTPCode string `json:"totp_code" form:"max=255"`
 }
 
 type LoginUserResponse User
@@ -67,3 +72,33 @@ type WelcomeWebhookRequest struct {
 	Company   string `json:"company" schema:"company"`
 	Role      string `json:"role" schema:"role"`
 }
+
+// TOTPRequiredErrorMessage is returned with ErrCodeTOTPRequired when a user that has
+// enabled TOTP logs in without a code
+const TOTPRequiredErrorMessage = "two-factor authentication code required"
+
+type EnrollTOTPResponse struct {
+	// Base32-encoded secret, for manual entry into an authenticator app
+	Secret string `json:"secret"`
+
+	// otpauth:// URI which can be rendered as a QR code
+	URI string `json:"uri"`
+}
+
+type VerifyTOTPRequest struct {
+	Code string `json:"code" form:"required,max=255"`
+}
+
+type VerifyTOTPResponse struct {
+	// Single-use recovery codes, which are only shown once
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTOTPRequest struct {
+	// Either a code from the authenticator app or a recovery code
+	Code string `json:"code" form:"required,max=255"`
+}
+
+type RegenerateTOTPRecoveryCodesRequest VerifyTOTPRequest
+
+type RegenerateTOTPRecoveryCodesResponse VerifyTOTPResponse
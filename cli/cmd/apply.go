@@ -52,9 +52,28 @@ applying a configuration:
   PORTER_PROJECT              Project ID that contains the application
   PORTER_NAMESPACE            The Kubernetes namespace that the application belongs to
   PORTER_SOURCE_NAME          Name of the source Helm chart
-  PORTER_SOURCE_REPO          The URL of the Helm charts registry
+  PORTER_SOURCE_REPO          The URL of the Helm charts registry, which may be an OCI registry (oci://)
   PORTER_SOURCE_VERSION       The version of the Helm chart to use
   PORTER_TAG                  The Docker image tag to use (like the git commit hash)
+
+Patches for fields which a chart does not expose as values can be set with the "overlays" key
+of a resource's config. Each overlay targets objects by kind, and optionally by name, and is
+either a JSON patch or a strategic merge patch:
+
+  overlays:
+  - kind: Deployment
+    name: web
+    patch_type: strategic-merge
+    patch:
+      spec:
+        template:
+          spec:
+            tolerations:
+            - key: dedicated
+              operator: Exists
+
+The overlays of a release are replaced by the overlays in porter.yaml, and are left unchanged
+if the "overlays" key is not set.
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
@@ -202,23 +221,25 @@ func NewPorterDriver(resource *models.Resource, opts *drivers.SharedDriverOpts)
 		output:      make(map[string]interface{}),
 	}
 
-	source := &Source{}
+	target := &Target{}
 
-	err := getSource(resource.Source, source)
+	err := getTarget(resource.Target, target)
 	if err != nil {
 		return nil, err
 	}
 
-	driver.source = source
+	driver.target = target
 
-	target := &Target{}
+	source := &Source{}
 
-	err = getTarget(resource.Target, target)
+	// the source is looked up in the project of the target, so that charts in
+	// private repos are loaded with the credentials of the project
+	err = getSource(target.Project, resource.Source, source)
 	if err != nil {
 		return nil, err
 	}
 
-	driver.target = target
+	driver.source = source
 
 	return driver, nil
 }
@@ -249,6 +270,12 @@ func (d *Driver) Apply(resource *models.Resource) (*models.Resource, error) {
 		color.New(color.FgYellow).Printf("Could not read release %s/%s (%s): attempting creation\n", d.target.Namespace, resource.Name, err.Error())
 	}
 
+	// overlays are stored before the release is deployed, so that they are applied
+	// when the release is first created
+	if err := d.applyOverlays(resource, client); err != nil {
+		return nil, err
+	}
+
 	if d.source.IsApplication {
 		return d.applyApplication(resource, client, shouldCreate)
 	}
@@ -256,6 +283,52 @@ func (d *Driver) Apply(resource *models.Resource) (*models.Resource, error) {
 	return d.applyAddon(resource, client, shouldCreate)
 }
 
+// applyOverlays replaces the manifest overlays of the release with the overlays in
+// the config of the resource. The overlays are removed from the config, since the
+// config of an addon is passed to the chart as values.
+func (d *Driver) applyOverlays(resource *models.Resource, client *api.Client) error {
+	rawOverlays, ok := resource.Config["overlays"]
+
+	if !ok {
+		return nil
+	}
+
+	newConfig := make(map[string]interface{})
+
+	for key, val := range resource.Config {
+		if key != "overlays" {
+			newConfig[key] = val
+		}
+	}
+
+	resource.Config = newConfig
+
+	overlaysBytes, err := json.Marshal(rawOverlays)
+
+	if err != nil {
+		return err
+	}
+
+	overlays := make([]*types.ManifestOverlay, 0)
+
+	if err := json.Unmarshal(overlaysBytes, &overlays); err != nil {
+		return fmt.Errorf("invalid overlays for %s: %w", resource.Name, err)
+	}
+
+	_, err = client.UpdateReleaseOverlays(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		resource.Name,
+		&types.UpdateManifestOverlaysRequest{
+			Overlays: overlays,
+		},
+	)
+
+	return err
+}
+
 // Simple apply for addons
 func (d *Driver) applyAddon(resource *models.Resource, client *api.Client, shouldCreate bool) (*models.Resource, error) {
 	var err error
@@ -282,7 +355,7 @@ func (d *Driver) applyAddon(resource *models.Resource, client *api.Client, shoul
 			return nil, err
 		}
 
-		err = client.UpgradeRelease(
+		resp, err := client.UpgradeRelease(
 			context.Background(),
 			d.target.Project,
 			d.target.Cluster,
@@ -292,6 +365,14 @@ func (d *Driver) applyAddon(resource *models.Resource, client *api.Client, shoul
 				Values: string(bytes),
 			},
 		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.DeployRequest != nil {
+			printPendingDeployRequest(resp.DeployRequest)
+		}
 	}
 
 	if err != nil {
@@ -514,12 +595,16 @@ func (d *Driver) updateApplication(resource *models.Resource, client *api.Client
 		}
 	}
 
-	err = updateAgent.UpdateImageAndValues(appConf.Values)
+	resp, err := updateAgent.UpdateImageAndValues(appConf.Values)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.DeployRequest != nil {
+		printPendingDeployRequest(resp.DeployRequest)
+	}
+
 	return resource, nil
 }
 
@@ -545,7 +630,7 @@ func (d *Driver) Output() (map[string]interface{}, error) {
 	return d.output, nil
 }
 
-func getSource(input map[string]interface{}, output *Source) error {
+func getSource(projectID uint, input map[string]interface{}, output *Source) error {
 	// first read from env vars
 	output.Name = os.Getenv("PORTER_SOURCE_NAME")
 	output.Repo = os.Getenv("PORTER_SOURCE_REPO")
@@ -596,7 +681,7 @@ func getSource(input map[string]interface{}, output *Source) error {
 	if output.Repo == "" {
 		output.Repo = "https://charts.getporter.dev"
 
-		values, err := existsInRepo(output.Name, output.Version, output.Repo)
+		values, err := existsInRepo(projectID, output.Name, output.Version, output.Repo)
 
 		if err == nil {
 			// found in "https://charts.getporter.dev"
@@ -607,7 +692,7 @@ func getSource(input map[string]interface{}, output *Source) error {
 
 		output.Repo = "https://chart-addons.getporter.dev"
 
-		values, err = existsInRepo(output.Name, output.Version, output.Repo)
+		values, err = existsInRepo(projectID, output.Name, output.Version, output.Repo)
 
 		if err == nil {
 			// found in https://chart-addons.getporter.dev
@@ -618,6 +703,13 @@ func getSource(input map[string]interface{}, output *Source) error {
 		return fmt.Errorf("source does not exist in any repo")
 	}
 
+	values, err := existsInRepo(projectID, output.Name, output.Version, output.Repo)
+
+	if err == nil {
+		output.SourceValues = values
+		return nil
+	}
+
 	return fmt.Errorf("source '%s' does not exist in repo '%s'", output.Name, output.Repo)
 }
 
@@ -644,7 +736,7 @@ func getTarget(input map[string]interface{}, output *Target) error {
 	// next, check for values in the YAML file
 	if output.Project == 0 {
 		if project, ok := input["project"]; ok {
-			projectVal, ok := project.(uint)
+			projectVal, ok := getUintValue(project)
 			if !ok {
 				return fmt.Errorf("project value must be an integer")
 			}
@@ -654,7 +746,7 @@ func getTarget(input map[string]interface{}, output *Target) error {
 
 	if output.Cluster == 0 {
 		if cluster, ok := input["cluster"]; ok {
-			clusterVal, ok := cluster.(uint)
+			clusterVal, ok := getUintValue(cluster)
 			if !ok {
 				return fmt.Errorf("cluster value must be an integer")
 			}
@@ -686,6 +778,25 @@ func getTarget(input map[string]interface{}, output *Target) error {
 	return nil
 }
 
+// getUintValue reads a non-negative integer from a parsed porter.yaml file, in which
+// numbers are decoded as float64
+func getUintValue(val interface{}) (uint, bool) {
+	switch v := val.(type) {
+	case uint:
+		return v, true
+	case int:
+		if v >= 0 {
+			return uint(v), true
+		}
+	case float64:
+		if v >= 0 && v == float64(uint(v)) {
+			return uint(v), true
+		}
+	}
+
+	return 0, false
+}
+
 func (d *Driver) getApplicationConfig(resource *models.Resource) (*ApplicationConfig, error) {
 	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
 		RawConf:      resource.Config,
@@ -713,10 +824,10 @@ func (d *Driver) getApplicationConfig(resource *models.Resource) (*ApplicationCo
 	return config, nil
 }
 
-func existsInRepo(name, version, url string) (map[string]interface{}, error) {
-	chart, err := GetAPIClient(config).GetTemplate(
+func existsInRepo(projectID uint, name, version, url string) (map[string]interface{}, error) {
+	chart, err := GetAPIClient(config).GetProjectTemplate(
 		context.Background(),
-		name, version,
+		projectID, name, version,
 		&types.GetTemplateRequest{
 			TemplateGetBaseRequest: types.TemplateGetBaseRequest{
 				RepoURL: url,
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+// auditCmd represents the "porter audit" command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Lists the audit log of mutating API calls in the current project",
+	Long: fmt.Sprintf(`
+%s
+
+Lists the audit log of the current project, most recent first. Every API call which
+creates, updates or deletes a resource is recorded along with its outcome. For example,
+to list the failed calls against a cluster in the last day:
+
+  %s
+
+The --scope flag matches a resource and all of its children, and is relative to the
+current project.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter audit\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter audit --scope cluster/1 --outcome failure --since 24h"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listAuditLogs)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var auditOpts = &types.ListAuditLogsRequest{}
+var auditSince time.Duration
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.PersistentFlags().IntVar(
+		&auditOpts.Limit,
+		"limit",
+		20,
+		"the number of audit logs to list",
+	)
+
+	auditCmd.PersistentFlags().IntVar(
+		&auditOpts.Skip,
+		"skip",
+		0,
+		"the number of audit logs to skip, for pagination",
+	)
+
+	auditCmd.PersistentFlags().UintVar(
+		&auditOpts.UserID,
+		"user",
+		0,
+		"only list calls made by the user with this id",
+	)
+
+	auditCmd.PersistentFlags().UintVar(
+		&auditOpts.APITokenID,
+		"api-token",
+		0,
+		"only list calls made with the API token with this id",
+	)
+
+	auditCmd.PersistentFlags().StringVar(
+		(*string)(&auditOpts.Verb),
+		"verb",
+		"",
+		"only list calls with this verb (\"create\", \"update\" or \"delete\")",
+	)
+
+	auditCmd.PersistentFlags().StringVar(
+		(*string)(&auditOpts.Outcome),
+		"outcome",
+		"",
+		"only list calls with this outcome (\"success\" or \"failure\")",
+	)
+
+	auditCmd.PersistentFlags().StringVar(
+		&auditOpts.ScopePath,
+		"scope",
+		"",
+		"only list calls against this resource and its children, e.g. cluster/1/namespace/default",
+	)
+
+	auditCmd.PersistentFlags().DurationVar(
+		&auditSince,
+		"since",
+		0,
+		"only list calls made within this duration, e.g. 24h",
+	)
+
+	auditCmd.PersistentFlags().StringVar(
+		&output,
+		"output",
+		"",
+		"the output format to use (\"json\" or a table by default)",
+	)
+}
+
+func listAuditLogs(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := *auditOpts
+
+	if req.ScopePath != "" && !strings.HasPrefix(req.ScopePath, "project/") {
+		req.ScopePath = fmt.Sprintf("project/%d/%s", config.Project, strings.Trim(req.ScopePath, "/"))
+	}
+
+	if auditSince != 0 {
+		req.Since = time.Now().Add(-auditSince).Unix()
+	}
+
+	resp, err := client.ListAuditLogs(context.Background(), config.Project, &req)
+
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(resp, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(bytes))
+
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "TIME", "ACTOR", "VERB", "SCOPE", "STATUS", "SUMMARY")
+
+	for _, auditLog := range resp.AuditLogs {
+		line := fmt.Sprintf(
+			"%s\t%s\t%s\t%s\t%d\t%s\n",
+			auditLog.CreatedAt.Local().Format("2006-01-02 15:04:05"), auditLog.Actor, auditLog.Verb,
+			auditLog.ScopePath, auditLog.StatusCode, auditLog.Summary,
+		)
+
+		if auditLog.Outcome == types.AuditLogOutcomeFailure {
+			color.New(color.FgRed).Fprint(w, line)
+		} else {
+			fmt.Fprint(w, line)
+		}
+	}
+
+	w.Flush()
+
+	if shown := req.Skip + len(resp.AuditLogs); int64(shown) < resp.Count {
+		fmt.Printf("\nShowing %d-%d of %d audit logs. Use --skip %d to see more.\n", req.Skip+1, shown, resp.Count, shown)
+	}
+
+	return nil
+}
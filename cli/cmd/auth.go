@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/fatih/color"
 
@@ -12,6 +15,7 @@ import (
 	loginBrowser "github.com/porter-dev/porter/cli/cmd/login"
 	"github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 var authCmd = &cobra.Command{
@@ -57,7 +61,76 @@ var logoutCmd = &cobra.Command{
 	},
 }
 
+var sessionsCmd = &cobra.Command{
+	Use:     "sessions",
+	Aliases: []string{"session"},
+	Short:   "Commands for managing the logged in sessions of the current user",
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the active sessions for the current user",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listSessions)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var sessionsRevokeCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Revokes the session with the given id, or all other sessions with --all",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, revokeSessions)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var canICmd = &cobra.Command{
+	Use:   "can-i [verb] [resource]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Checks whether an action is allowed by the policy of the current user or token",
+	Long: fmt.Sprintf(`
+%s
+
+Checks whether the current user or token can perform a verb on a resource in the current
+project, and prints the policy document which allows it. The verb is one of get, list,
+create, update or delete. The resource is one of:
+
+  project                 the current project
+  settings                the settings of the current project
+  cluster[/<id>]          the current cluster, or the cluster with the given id
+  namespace/<name>        a namespace in the current cluster
+  release/<name>          a release in the namespace given by --namespace
+
+To check the access of another collaborator or API token, use the --user or --api-token
+flags, which requires access to the project settings:
+
+  %s
+
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter auth can-i\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter auth can-i update release/web --namespace default --user 2"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, canI)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var manual bool = false
+var revokeAllSessions bool = false
+var canIUserID uint
+var canIAPITokenID uint
 
 func init() {
 	rootCmd.AddCommand(authCmd)
@@ -65,6 +138,40 @@ func init() {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(registerCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(sessionsCmd)
+
+	authCmd.AddCommand(canICmd)
+
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsRevokeCmd)
+
+	sessionsRevokeCmd.PersistentFlags().BoolVar(
+		&revokeAllSessions,
+		"all",
+		false,
+		"whether to revoke all sessions other than the current session",
+	)
+
+	canICmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"the namespace of the release, when checking access to a release",
+	)
+
+	canICmd.PersistentFlags().UintVar(
+		&canIUserID,
+		"user",
+		0,
+		"the id of a collaborator to check the access of",
+	)
+
+	canICmd.PersistentFlags().UintVar(
+		&canIAPITokenID,
+		"api-token",
+		0,
+		"the id of an API token to check the access of",
+	)
 
 	loginCmd.PersistentFlags().BoolVar(
 		&manual,
@@ -196,10 +303,23 @@ func loginManual() error {
 		return err
 	}
 
-	_, err = client.Login(context.Background(), &types.LoginUserRequest{
+	loginReq := &types.LoginUserRequest{
 		Email:    username,
 		Password: pw,
-	})
+	}
+
+	_, err = client.Login(context.Background(), loginReq)
+
+	// if the user has enabled two-factor authentication, prompt for a code and retry
+	if err != nil && err.Error() == types.TOTPRequiredErrorMessage {
+		loginReq.TOTPCode, err = utils.PromptPlaintext("Two-factor authentication code (or recovery code): ")
+
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Login(context.Background(), loginReq)
+	}
 
 	if err != nil {
 		return err
@@ -276,3 +396,160 @@ func logout(user *types.GetAuthenticatedUserResponse, client *api.Client, args [
 
 	return nil
 }
+
+func listSessions(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListSessions(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	sessions := *resp
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ID", "CREATED", "LAST SEEN", "IP ADDRESS", "USER AGENT")
+
+	for _, session := range sessions {
+		if session.Current {
+			color.New(color.FgGreen).Fprintf(
+				w, "%d\t%s\t%s\t%s\t%s (current session)\n",
+				session.ID, session.CreatedAt.Format("2006-01-02 15:04"), session.LastSeenAt.Format("2006-01-02 15:04"),
+				session.IPAddress, session.UserAgent,
+			)
+		} else {
+			fmt.Fprintf(
+				w, "%d\t%s\t%s\t%s\t%s\n",
+				session.ID, session.CreatedAt.Format("2006-01-02 15:04"), session.LastSeenAt.Format("2006-01-02 15:04"),
+				session.IPAddress, session.UserAgent,
+			)
+		}
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func revokeSessions(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if revokeAllSessions {
+		resp, err := client.RevokeAllSessions(context.Background())
+
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("Revoked %d session(s)\n", resp.NumRevoked)
+
+		return nil
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("a session id must be specified, or use --all to revoke all other sessions")
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	err = client.RevokeSession(context.Background(), uint(id))
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Revoked session with id %d\n", id)
+
+	return nil
+}
+
+func canI(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req, err := getCanIRequest(args[0], args[1])
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.CanI(context.Background(), config.Project, req)
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.Allowed {
+		color.New(color.FgRed).Println("no")
+		return nil
+	}
+
+	color.New(color.FgGreen).Println("yes")
+
+	bytes, err := yaml.Marshal(resp.MatchingPolicyDocument)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nAllowed by policy document:\n\n%s", string(bytes))
+
+	return nil
+}
+
+// getCanIRequest converts the verb and resource arguments of "porter auth can-i" to a
+// request, using the current cluster and the --namespace flag for nested resources
+func getCanIRequest(verb, resource string) (*types.CanIRequest, error) {
+	req := &types.CanIRequest{
+		Verb:       types.APIVerb(strings.ToLower(verb)),
+		UserID:     canIUserID,
+		APITokenID: canIAPITokenID,
+	}
+
+	kind, name := resource, ""
+
+	if i := strings.Index(resource, "/"); i != -1 {
+		kind, name = resource[:i], resource[i+1:]
+	}
+
+	if kind != "project" && kind != "settings" && config.Cluster == 0 && !(kind == "cluster" && name != "") {
+		return nil, fmt.Errorf("no cluster is set: use \"porter config set-cluster\" or the --cluster flag")
+	}
+
+	switch kind {
+	case "project":
+	case "settings":
+		req.Settings = true
+	case "cluster":
+		req.ClusterID = config.Cluster
+
+		if name != "" {
+			clusterID, err := strconv.ParseUint(name, 10, 64)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid cluster id %s", name)
+			}
+
+			req.ClusterID = uint(clusterID)
+		}
+	case "namespace":
+		if name == "" {
+			return nil, fmt.Errorf("a namespace name must be specified, e.g. namespace/default")
+		}
+
+		req.ClusterID = config.Cluster
+		req.Namespace = name
+	case "release":
+		if name == "" {
+			return nil, fmt.Errorf("a release name must be specified, e.g. release/web")
+		}
+
+		req.ClusterID = config.Cluster
+		req.Namespace = namespace
+		req.Release = name
+	default:
+		return nil, fmt.Errorf("unknown resource %s: must be one of project, settings, cluster, namespace or release", resource)
+	}
+
+	return req, nil
+}
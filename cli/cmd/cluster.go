@@ -48,6 +48,34 @@ var clusterDeleteCmd = &cobra.Command{
 	},
 }
 
+var clusterHelmStorageCmd = &cobra.Command{
+	Use:       "helm-storage [secret|sql]",
+	Args:      cobra.ExactValidArgs(1),
+	ValidArgs: []string{string(types.HelmStorageSecret), string(types.HelmStorageSQL)},
+	Short:     "Switches where the Helm releases of the current cluster are stored",
+	Long: fmt.Sprintf(`
+%s
+
+Switches where the Helm releases of the current cluster are stored, and copies the existing
+releases to the new storage. Releases are stored in secrets in the cluster by default. To
+store releases in Porter's database instead:
+
+  %s
+
+Releases are not deleted from the previous storage, so the cluster can be switched back.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter cluster helm-storage\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter cluster helm-storage sql"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, updateClusterHelmStorage)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var clusterNamespaceCmd = &cobra.Command{
 	Use:     "namespace",
 	Aliases: []string{"namespaces"},
@@ -72,6 +100,7 @@ func init() {
 	clusterCmd.AddCommand(clusterNamespaceCmd)
 	clusterCmd.AddCommand(clusterListCmd)
 	clusterCmd.AddCommand(clusterDeleteCmd)
+	clusterCmd.AddCommand(clusterHelmStorageCmd)
 
 	clusterNamespaceCmd.AddCommand(clusterNamespaceListCmd)
 }
@@ -137,6 +166,30 @@ func deleteCluster(user *types.GetAuthenticatedUserResponse, client *api.Client,
 	return nil
 }
 
+func updateClusterHelmStorage(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.UpdateClusterHelmStorage(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		&types.UpdateClusterHelmStorageRequest{
+			Storage: types.HelmStorage(args[0]),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf(
+		"Cluster %s now stores Helm releases in %s storage (%d revisions migrated)\n",
+		resp.Name,
+		resp.HelmStorage,
+		resp.MigratedRevisions,
+	)
+
+	return nil
+}
+
 func listNamespaces(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
 	pID := config.Project
 
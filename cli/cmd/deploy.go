@@ -184,10 +184,16 @@ You can update the configuration with only a new tag with the --tag flag, which
 the image that the application uses if no --values file is specified:
 
   %s
+
+To see the changes to the application's Kubernetes manifests without deploying them, use the
+--dry-run flag:
+
+  %s
 `,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update config\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --tag custom-tag"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml --dry-run"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, updateUpgrade)
@@ -207,6 +213,7 @@ var method string
 var stream bool
 var buildFlagsEnv []string
 var forcePush bool
+var updateDryRun bool
 
 func init() {
 	buildFlagsEnv = []string{}
@@ -315,6 +322,13 @@ func init() {
 	updateCmd.AddCommand(updateBuildCmd)
 	updateCmd.AddCommand(updatePushCmd)
 	updateCmd.AddCommand(updateConfigCmd)
+
+	updateConfigCmd.PersistentFlags().BoolVar(
+		&updateDryRun,
+		"dry-run",
+		false,
+		"show the changes to the application's manifests without deploying them",
+	)
 }
 
 func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -400,6 +414,10 @@ func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, ar
 		return err
 	}
 
+	if updateDryRun {
+		return updateDryRunWithAgent(updateAgent)
+	}
+
 	return updateUpgradeWithAgent(updateAgent)
 }
 
@@ -432,7 +450,8 @@ func updateGetAgent(client *api.Client) (*deploy.DeployAgent, error) {
 			Method:          buildMethod,
 			AdditionalEnv:   additionalEnv,
 		},
-		Local: source != "github",
+		Local:  source != "github",
+		DryRun: updateDryRun,
 	})
 }
 
@@ -592,7 +611,7 @@ func updateUpgradeWithAgent(updateAgent *deploy.DeployAgent) error {
 		return err
 	}
 
-	err = updateAgent.UpdateImageAndValues(valuesObj)
+	resp, err := updateAgent.UpdateImageAndValues(valuesObj)
 
 	if err != nil {
 		if stream {
@@ -607,17 +626,100 @@ func updateUpgradeWithAgent(updateAgent *deploy.DeployAgent) error {
 		return err
 	}
 
+	info := ""
+
+	if resp.DeployRequest != nil {
+		info = fmt.Sprintf("deploy request %d is pending approval", resp.DeployRequest.ID)
+	} else if resp.RolloutStrategy != "" {
+		info = fmt.Sprintf("%s rollout started", resp.RolloutStrategy)
+	}
+
 	if stream {
 		updateAgent.StreamEvent(types.SubEvent{
 			EventID: "upgrade",
 			Name:    "Upgrade",
 			Index:   330,
 			Status:  types.EventStatusSuccess,
-			Info:    "",
+			Info:    info,
 		})
 	}
 
+	if resp.DeployRequest != nil {
+		printPendingDeployRequest(resp.DeployRequest)
+		return nil
+	}
+
+	if resp.RolloutStrategy != "" {
+		color.New(color.FgGreen).Printf("Started a %s rollout of %s, which will be promoted once it is healthy\n", resp.RolloutStrategy, app)
+		return nil
+	}
+
 	color.New(color.FgGreen).Println("Successfully updated", app)
 
 	return nil
 }
+
+func updateDryRunWithAgent(updateAgent *deploy.DeployAgent) error {
+	valuesObj, err := readValuesFile()
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := updateAgent.UpdateImageAndValues(valuesObj)
+
+	if err != nil {
+		return err
+	}
+
+	printManifestDiff(resp.ManifestDiff)
+
+	return nil
+}
+
+// printManifestDiff prints the objects which a diff changes, with added lines in
+// green and removed lines in red
+func printManifestDiff(diffs []*types.ManifestDiff) {
+	numChanged := printChangedObjects(diffs)
+
+	fmt.Printf("%d of %d objects would change\n", numChanged, len(diffs))
+}
+
+// printChangedObjects prints the diff of each changed object, and returns the number
+// of changed objects
+func printChangedObjects(diffs []*types.ManifestDiff) int {
+	numChanged := 0
+
+	for _, diff := range diffs {
+		if diff.Status == types.ManifestDiffStatusUnchanged {
+			continue
+		}
+
+		numChanged++
+
+		header := fmt.Sprintf("%s %s", diff.Kind, diff.Name)
+
+		if diff.Namespace != "" {
+			header = fmt.Sprintf("%s %s/%s", diff.Kind, diff.Namespace, diff.Name)
+		}
+
+		color.New(color.FgBlue, color.Bold).Printf("%s (%s)\n", header, diff.Status)
+
+		for _, line := range strings.Split(strings.TrimSuffix(diff.Diff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				fmt.Println(line)
+			case strings.HasPrefix(line, "+"):
+				color.New(color.FgGreen).Println(line)
+			case strings.HasPrefix(line, "-"):
+				color.New(color.FgRed).Println(line)
+			default:
+				fmt.Println(line)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	return numChanged
+}
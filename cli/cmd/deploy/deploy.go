@@ -49,6 +49,9 @@ type DeployOpts struct {
 	*SharedOpts
 
 	Local bool
+
+	// DryRun renders upgrades without applying them
+	DryRun bool
 }
 
 // NewDeployAgent creates a new DeployAgent given a Porter API client, application
@@ -331,8 +334,10 @@ func (d *DeployAgent) Push(forcePush bool) error {
 // UpdateImageAndValues updates the current image for a release, along with new
 // configuration passed in via overrrideValues. If overrideValues is nil, it just
 // reuses the configuration set for the application. If overrideValues is not nil,
-// it will merge the overriding values with the existing configuration.
-func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}) error {
+// it will merge the overriding values with the existing configuration. If the
+// release is protected, the response contains the pending deploy request. If the
+// agent is a dry run, the response contains the diff of the rendered manifests.
+func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}) (*types.UpgradeReleaseResponse, error) {
 	// if this is a job chart, set "paused" to false so that the job doesn't run, unless
 	// the user has explicitly overriden the "paused" field
 	if _, exists := overrideValues["paused"]; d.release.Chart.Name() == "job" && !exists {
@@ -350,7 +355,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 		newImage, err := d.getReleaseImage()
 
 		if err != nil {
-			return fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
+			return nil, fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
 		}
 
 		currImageSection["repository"] = newImage
@@ -367,7 +372,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 	bytes, err := json.Marshal(mergedValues)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	return d.client.UpgradeRelease(
@@ -378,6 +383,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 		d.release.Name,
 		&types.UpgradeReleaseRequest{
 			Values: string(bytes),
+			DryRun: d.opts.DryRun,
 		},
 	)
 }
@@ -28,11 +28,19 @@ func coalesceEnvGroups(
 	envGroups []types.EnvGroupMeta,
 	config map[string]interface{},
 ) error {
+	synced := getSyncedEnvGroups(config)
+
 	for _, group := range envGroups {
 		if group.Name == "" {
 			return fmt.Errorf("env group name cannot be empty")
 		}
 
+		// env groups which are synced to the release are read by the chart, so their
+		// variables are not copied into the normal env
+		if synced[group.Name] {
+			continue
+		}
+
 		envGroup, err := client.GetEnvGroup(
 			context.Background(),
 			projectID,
@@ -66,3 +74,26 @@ func coalesceEnvGroups(
 
 	return nil
 }
+
+// getSyncedEnvGroups returns the names of the env groups in container.env.synced
+func getSyncedEnvGroups(config map[string]interface{}) map[string]bool {
+	res := make(map[string]bool)
+
+	envConfig, err := getNestedMap(config, "container", "env")
+
+	if err != nil {
+		return res
+	}
+
+	synced, _ := envConfig["synced"].([]interface{})
+
+	for _, groupInter := range synced {
+		if group, ok := groupInter.(map[string]interface{}); ok {
+			if name, ok := group["name"].(string); ok {
+				res[name] = true
+			}
+		}
+	}
+
+	return res
+}
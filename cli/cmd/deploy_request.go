@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var deployRequestCmd = &cobra.Command{
+	Use:     "deploy-request",
+	Aliases: []string{"deploy-requests"},
+	Short:   "Commands for reviewing upgrades of protected namespaces and releases",
+	Long: fmt.Sprintf(`
+%s
+
+Upgrades of protected namespaces and releases do not run immediately. Instead, a deploy
+request is created, which must be approved by a different user who can upgrade the
+release. For example, to list the pending deploy requests in the current project:
+
+  %s
+
+Approving a deploy request runs the upgrade against the latest revision of the release.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter deploy-request\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter deploy-request list --status pending"),
+	),
+}
+
+var deployRequestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the deploy requests in the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listDeployRequests)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var deployRequestApproveCmd = &cobra.Command{
+	Use:   "approve [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Approves a pending deploy request, which runs the upgrade",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, approveDeployRequest)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var deployRequestRejectCmd = &cobra.Command{
+	Use:   "reject [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Rejects a pending deploy request",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, rejectDeployRequest)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var deployRequestStatus string
+var deployRequestComment string
+
+func init() {
+	rootCmd.AddCommand(deployRequestCmd)
+
+	deployRequestCmd.AddCommand(deployRequestListCmd)
+	deployRequestCmd.AddCommand(deployRequestApproveCmd)
+	deployRequestCmd.AddCommand(deployRequestRejectCmd)
+
+	deployRequestListCmd.PersistentFlags().StringVar(
+		&deployRequestStatus,
+		"status",
+		"",
+		"only list deploy requests with this status (\"pending\", \"approved\", \"rejected\" or \"failed\")",
+	)
+
+	deployRequestApproveCmd.PersistentFlags().StringVar(
+		&deployRequestComment,
+		"comment",
+		"",
+		"a comment to record with the review",
+	)
+
+	deployRequestRejectCmd.PersistentFlags().StringVar(
+		&deployRequestComment,
+		"comment",
+		"",
+		"a comment to record with the review",
+	)
+}
+
+func listDeployRequests(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListDeployRequests(context.Background(), config.Project, &types.ListDeployRequestsRequest{
+		Status: types.DeployRequestStatus(deployRequestStatus),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "CREATED", "CLUSTER", "NAMESPACE", "NAME", "SOURCE", "STATUS")
+
+	for _, dr := range *resp {
+		fmt.Fprintf(
+			w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			dr.ID, dr.CreatedAt.Local().Format("2006-01-02 15:04"), dr.ClusterID, dr.Namespace, dr.Name, dr.Source, dr.Status,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func approveDeployRequest(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ApproveDeployRequest(context.Background(), config.Project, uint(id), &types.ReviewDeployRequestRequest{
+		Comment: deployRequestComment,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Approved deploy request %d: upgraded %s in namespace %s\n", resp.ID, resp.Name, resp.Namespace)
+
+	return nil
+}
+
+func rejectDeployRequest(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.RejectDeployRequest(context.Background(), config.Project, uint(id), &types.ReviewDeployRequestRequest{
+		Comment: deployRequestComment,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Rejected deploy request %d\n", resp.ID)
+
+	return nil
+}
+
+// printPendingDeployRequest informs the user that an upgrade of a protected release
+// did not run, and must be approved
+func printPendingDeployRequest(dr *types.DeployRequest) {
+	color.New(color.FgYellow).Printf(
+		"%s in namespace %s is protected: created deploy request %d, which must be approved with \"porter deploy-request approve %d\"\n",
+		dr.Name, dr.Namespace, dr.ID, dr.ID,
+	)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Checks if the objects of a release were changed outside of Porter",
+	Long: fmt.Sprintf(`
+%s
+
+Compares each object in the manifest of the latest revision of a release against the live
+object in the cluster, and prints the fields which were changed outside of Porter, for example
+with "kubectl edit". Only fields which are set in the manifest are compared. For example:
+
+  %s
+
+To revert the changed fields by re-applying the release with its deployed chart and values,
+use --reconcile:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter drift\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter drift web-app --namespace default"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter drift web-app --namespace default --reconcile"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, drift)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var driftReconcile bool
+
+func init() {
+	rootCmd.AddCommand(driftCmd)
+
+	driftCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"the namespace of the release",
+	)
+
+	driftCmd.PersistentFlags().BoolVar(
+		&driftReconcile,
+		"reconcile",
+		false,
+		"re-apply the release if it has drifted",
+	)
+}
+
+func drift(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if !driftReconcile {
+		resp, err := client.GetReleaseDrift(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		printReleaseDrift(resp)
+
+		return nil
+	}
+
+	resp, err := client.ReconcileReleaseDrift(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.Reconciled {
+		color.New(color.FgGreen).Printf("Release %s has not drifted from revision %d\n", args[0], resp.Revision)
+		return nil
+	}
+
+	color.New(color.FgGreen).Printf("Re-applied release %s as revision %d\n", args[0], resp.Revision)
+
+	if resp.Drifted {
+		fmt.Println()
+		printReleaseDrift(&resp.GetReleaseDriftResponse)
+	}
+
+	return nil
+}
+
+// printReleaseDrift prints the changed fields of each drifted object, and the objects
+// which are missing from the cluster
+func printReleaseDrift(resp *types.GetReleaseDriftResponse) {
+	numDrifted := 0
+
+	for _, obj := range resp.Objects {
+		if obj.Status == types.DriftStatusInSync {
+			continue
+		}
+
+		numDrifted++
+
+		header := fmt.Sprintf("%s %s", obj.Kind, obj.Name)
+
+		if obj.Namespace != "" {
+			header = fmt.Sprintf("%s %s/%s", obj.Kind, obj.Namespace, obj.Name)
+		}
+
+		color.New(color.FgBlue, color.Bold).Printf("%s (%s)\n", header, obj.Status)
+
+		for _, field := range obj.Fields {
+			fmt.Printf("  %s\n", field.Path)
+			fmt.Printf("    manifest: %v\n", field.Expected)
+			color.New(color.FgYellow).Printf("    live:     %v\n", field.Live)
+		}
+
+		fmt.Println()
+	}
+
+	fmt.Printf("%d of %d objects have drifted from revision %d\n", numDrifted, len(resp.Objects), resp.Revision)
+}
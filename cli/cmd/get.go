@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"text/tabwriter"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/time"
+	k8syaml "sigs.k8s.io/yaml"
 )
 
 // getCmd represents the "porter get" base command when called
@@ -42,8 +47,72 @@ var getValuesCmd = &cobra.Command{
 	},
 }
 
+// getPorterYAMLCmd represents the "porter get porter-yaml" command
+var getPorterYAMLCmd = &cobra.Command{
+	Use:   "porter-yaml [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Exports a release as a porter.yaml file.",
+	Long: fmt.Sprintf(`
+%s
+
+Exports the chart, target, build config, env groups and values of a release as a porter.yaml
+file, which creates or updates the release with "porter apply". For example:
+
+  %s
+
+Releases which are deployed from a git repository are built from the source directory of the
+repository, so "porter apply" should be run from the root of the repository. Other releases are
+deployed from the image in their values.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter get porter-yaml\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter get porter-yaml web-app --namespace default > porter.yaml"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, getPorterYAML)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+// getHistoryCmd represents the "porter get history" command
+var getHistoryCmd = &cobra.Command{
+	Use:   "history [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists the revisions of a release, or diffs two revisions.",
+	Long: fmt.Sprintf(`
+%s
+
+Lists the revisions of a release. With --diff, prints the changes to the user-supplied values,
+chart version and rendered manifest between two revisions. By default, the latest revision is
+diffed against the revision before it. For example:
+
+  %s
+
+To diff two specific revisions, use the --from and --to flags:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter get history\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter get history web-app --diff"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter get history web-app --diff --from 3 --to 5"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, getHistory)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var output string
 
+var historyDiff bool
+var historyFromRevision int
+var historyToRevision int
+
 func init() {
 	getCmd.PersistentFlags().StringVar(
 		&namespace,
@@ -59,7 +128,30 @@ func init() {
 		"the output format to use (\"yaml\" or \"json\")",
 	)
 
+	getHistoryCmd.Flags().BoolVar(
+		&historyDiff,
+		"diff",
+		false,
+		"diff the values, chart version and manifest of two revisions",
+	)
+
+	getHistoryCmd.Flags().IntVar(
+		&historyFromRevision,
+		"from",
+		0,
+		"the revision to diff from (defaults to the revision before --to)",
+	)
+
+	getHistoryCmd.Flags().IntVar(
+		&historyToRevision,
+		"to",
+		0,
+		"the revision to diff to (defaults to the latest revision)",
+	)
+
 	getCmd.AddCommand(getValuesCmd)
+	getCmd.AddCommand(getPorterYAMLCmd)
+	getCmd.AddCommand(getHistoryCmd)
 
 	rootCmd.AddCommand(getCmd)
 }
@@ -140,3 +232,180 @@ func getValues(_ *types.GetAuthenticatedUserResponse, client *api.Client, args [
 
 	return nil
 }
+
+func getPorterYAML(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resGroup, err := client.GetPorterYAML(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		bytes, err := json.Marshal(resGroup)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(bytes))
+	} else { // yaml is the default
+		// the resource group is marshaled with its json field names, which are read by "porter apply"
+		bytes, err := k8syaml.Marshal(resGroup)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(bytes))
+	}
+
+	return nil
+}
+
+func getHistory(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if historyDiff {
+		return getHistoryDiff(client, args[0])
+	}
+
+	history, err := client.GetReleaseHistory(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	// revisions are listed from newest to oldest
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Version > history[j].Version
+	})
+
+	if output == "json" || output == "yaml" {
+		revisions := make([]*getReleaseRevisionInfo, 0, len(history))
+
+		for _, rel := range history {
+			revisions = append(revisions, getRevisionInfo(rel))
+		}
+
+		return printOutput(revisions)
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "REVISION", "UPDATED", "STATUS", "CHART VERSION", "DESCRIPTION")
+
+	for _, rel := range history {
+		info := getRevisionInfo(rel)
+
+		fmt.Fprintf(
+			w, "%d\t%s\t%s\t%s\t%s\n",
+			info.Revision, info.Updated.Local().Format("2006-01-02 15:04:05"), info.Status,
+			info.ChartVersion, info.Description,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+type getReleaseRevisionInfo struct {
+	Revision     int       `json:"revision" yaml:"revision"`
+	Updated      time.Time `json:"updated" yaml:"updated"`
+	Status       string    `json:"status" yaml:"status"`
+	ChartVersion string    `json:"chart_version" yaml:"chart_version"`
+	Description  string    `json:"description" yaml:"description"`
+}
+
+func getRevisionInfo(rel *release.Release) *getReleaseRevisionInfo {
+	info := &getReleaseRevisionInfo{
+		Revision: rel.Version,
+	}
+
+	if rel.Info != nil {
+		info.Updated = rel.Info.LastDeployed
+		info.Status = rel.Info.Status.String()
+		info.Description = rel.Info.Description
+	}
+
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		info.ChartVersion = rel.Chart.Metadata.Version
+	}
+
+	return info
+}
+
+func getHistoryDiff(client *api.Client, name string) error {
+	diff, err := client.GetReleaseDiff(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		name,
+		&types.GetReleaseDiffRequest{
+			FromRevision: historyFromRevision,
+			ToRevision:   historyToRevision,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if output == "json" || output == "yaml" {
+		return printOutput(diff)
+	}
+
+	color.New(color.FgBlue, color.Bold).Printf("Revision %d -> %d\n\n", diff.FromRevision, diff.ToRevision)
+
+	if diff.FromChartVersion != diff.ToChartVersion {
+		fmt.Printf("Chart version: %s -> %s\n\n", diff.FromChartVersion, diff.ToChartVersion)
+	} else {
+		fmt.Printf("Chart version: %s (unchanged)\n\n", diff.ToChartVersion)
+	}
+
+	if len(diff.ValuesDiff) == 0 {
+		fmt.Printf("Values: unchanged\n\n")
+	} else {
+		color.New(color.FgBlue, color.Bold).Println("Values")
+
+		for _, valuesDiff := range diff.ValuesDiff {
+			switch valuesDiff.Status {
+			case types.ManifestDiffStatusAdded:
+				color.New(color.FgGreen).Printf("+ %s: %v\n", valuesDiff.Path, valuesDiff.NewValue)
+			case types.ManifestDiffStatusRemoved:
+				color.New(color.FgRed).Printf("- %s: %v\n", valuesDiff.Path, valuesDiff.OldValue)
+			default:
+				color.New(color.FgYellow).Printf("~ %s: %v -> %v\n", valuesDiff.Path, valuesDiff.OldValue, valuesDiff.NewValue)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	numChanged := printChangedObjects(diff.ManifestDiff)
+
+	fmt.Printf("%d of %d objects changed\n", numChanged, len(diff.ManifestDiff))
+
+	return nil
+}
+
+// printOutput prints a response as json or yaml, depending on the output flag
+func printOutput(res interface{}) error {
+	var bytes []byte
+	var err error
+
+	if output == "json" {
+		bytes, err = json.Marshal(res)
+	} else {
+		// responses are marshaled with their json field names
+		bytes, err = k8syaml.Marshal(res)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(bytes))
+
+	return nil
+}
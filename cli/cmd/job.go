@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -133,7 +134,7 @@ func init() {
 func batchImageUpdate(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
 	color.New(color.FgGreen).Println("Updating all jobs which use the image:", imageRepoURI)
 
-	return client.UpdateBatchImage(
+	resp, err := client.UpdateBatchImage(
 		context.TODO(),
 		config.Project,
 		config.Cluster,
@@ -143,6 +144,20 @@ func batchImageUpdate(_ *types.GetAuthenticatedUserResponse, client *api.Client,
 			Tag:          tag,
 		},
 	)
+
+	if err != nil {
+		return err
+	}
+
+	for _, deployRequest := range resp.DeployRequests {
+		printPendingDeployRequest(deployRequest)
+	}
+
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("errors while deploying: %s", strings.Join(resp.Errors, ","))
+	}
+
+	return nil
 }
 
 // waits for a job with a given name/namespace
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Copies a release to another cluster or namespace",
+	Long: fmt.Sprintf(`
+%s
+
+Copies the chart version, values, image tag and env group links of a release to another
+cluster or namespace in the current project. If the release does not exist in the target
+namespace, it is created. For example, to promote the release "web-app" from the "staging"
+namespace to the "production" namespace of cluster 2:
+
+  %s
+
+Values in the file passed with --values are merged over the values of the release. To
+preview the changes to the target release without applying them, use --dry-run.
+
+Env groups which are linked to the release must exist in the target namespace. The
+promoted release is linked to the latest version of each env group.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter promote\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter promote web-app --namespace staging --target-cluster 2 --target-namespace production"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, promote)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var promoteTargetCluster uint
+var promoteTargetNamespace string
+var promoteTargetName string
+var promoteChartVersion string
+var promoteImageTag string
+var promoteValues string
+var promoteDryRun bool
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"the namespace of the release to promote",
+	)
+
+	promoteCmd.PersistentFlags().UintVar(
+		&promoteTargetCluster,
+		"target-cluster",
+		0,
+		"the id of the cluster to promote the release to (defaults to the current cluster)",
+	)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&promoteTargetNamespace,
+		"target-namespace",
+		"",
+		"the namespace to promote the release to",
+	)
+
+	promoteCmd.MarkPersistentFlagRequired("target-namespace")
+
+	promoteCmd.PersistentFlags().StringVar(
+		&promoteTargetName,
+		"target-name",
+		"",
+		"the name of the promoted release (defaults to the name of the release)",
+	)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&promoteChartVersion,
+		"chart-version",
+		"",
+		"the chart version of the promoted release (defaults to the chart version of the release)",
+	)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&promoteImageTag,
+		"tag",
+		"",
+		"the image tag of the promoted release (defaults to the image tag of the release)",
+	)
+
+	promoteCmd.PersistentFlags().StringVarP(
+		&promoteValues,
+		"values",
+		"v",
+		"",
+		"filepath to a values.yaml file which is merged over the values of the release",
+	)
+
+	promoteCmd.PersistentFlags().BoolVar(
+		&promoteDryRun,
+		"dry-run",
+		false,
+		"show the changes to the target release without applying them",
+	)
+}
+
+func promote(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.PromoteReleaseRequest{
+		TargetClusterID: promoteTargetCluster,
+		TargetNamespace: promoteTargetNamespace,
+		TargetName:      promoteTargetName,
+		ChartVersion:    promoteChartVersion,
+		ImageTag:        promoteImageTag,
+		DryRun:          promoteDryRun,
+	}
+
+	if req.TargetClusterID == 0 {
+		req.TargetClusterID = config.Cluster
+	}
+
+	if promoteValues != "" {
+		bytes, err := ioutil.ReadFile(promoteValues)
+
+		if err != nil {
+			return fmt.Errorf("could not read values file: %w", err)
+		}
+
+		req.Values = string(bytes)
+	}
+
+	resp, err := client.PromoteRelease(context.Background(), config.Project, config.Cluster, namespace, args[0], req)
+
+	if err != nil {
+		return err
+	}
+
+	targetName := req.TargetName
+
+	if targetName == "" {
+		targetName = args[0]
+	}
+
+	if len(resp.EnvGroups) > 0 {
+		fmt.Printf("Env groups: %s\n", strings.Join(resp.EnvGroups, ", "))
+	}
+
+	if promoteDryRun {
+		if resp.Created {
+			fmt.Printf("%s does not exist in namespace %s and would be created\n", targetName, req.TargetNamespace)
+		}
+
+		printManifestDiff(resp.ManifestDiff)
+
+		return nil
+	}
+
+	switch {
+	case resp.DeployRequest != nil:
+		printPendingDeployRequest(resp.DeployRequest)
+	case resp.RolloutStrategy != "":
+		color.New(color.FgGreen).Printf(
+			"Started a %s rollout of %s in namespace %s, which will be promoted once it is healthy\n",
+			resp.RolloutStrategy, targetName, req.TargetNamespace,
+		)
+	case resp.Created:
+		color.New(color.FgGreen).Printf("Created %s in namespace %s\n", targetName, req.TargetNamespace)
+	default:
+		color.New(color.FgGreen).Printf("Promoted %s to namespace %s\n", targetName, req.TargetNamespace)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Commands for configuring how upgrades of a web release are rolled out",
+	Long: fmt.Sprintf(`
+%s
+
+By default, upgrades of a web release replace its pods in place. With the "canary"
+strategy, the new revision is deployed alongside the current one, and traffic is shifted
+to it in steps while its error rate is checked. With the "blue_green" strategy, the new
+revision is deployed at full scale, and all traffic is shifted to it once it is healthy.
+For example, to shift 10%%, 25%% and then 50%% of traffic to new revisions of the release
+"web-app" in two minute steps:
+
+  %s
+
+Canary and blue-green rollouts require the release to be exposed through an NGINX ingress.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter rollout\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter rollout set web-app --strategy canary --steps 10,25,50 --interval 120"),
+	),
+}
+
+var rolloutGetCmd = &cobra.Command{
+	Use:   "get [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Gets the rollout config of a release",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, getRolloutConfig)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var rolloutSetCmd = &cobra.Command{
+	Use:   "set [release]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Sets the rollout config of a release",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, setRolloutConfig)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var rolloutStrategy string
+var rolloutSteps []uint
+var rolloutInterval uint
+var rolloutMaxErrorRate float64
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+
+	rolloutCmd.AddCommand(rolloutGetCmd)
+	rolloutCmd.AddCommand(rolloutSetCmd)
+
+	rolloutCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"the namespace of the release",
+	)
+
+	rolloutSetCmd.PersistentFlags().StringVar(
+		&rolloutStrategy,
+		"strategy",
+		"",
+		"the rollout strategy (\"rolling\", \"canary\" or \"blue_green\")",
+	)
+
+	rolloutSetCmd.MarkPersistentFlagRequired("strategy")
+
+	rolloutSetCmd.PersistentFlags().UintSliceVar(
+		&rolloutSteps,
+		"steps",
+		nil,
+		"the increasing percentages of traffic sent to a canary, such as 10,25,50",
+	)
+
+	rolloutSetCmd.PersistentFlags().UintVar(
+		&rolloutInterval,
+		"interval",
+		0,
+		"the number of seconds that each step runs before the canary is checked",
+	)
+
+	rolloutSetCmd.PersistentFlags().Float64Var(
+		&rolloutMaxErrorRate,
+		"max-error-rate",
+		0,
+		"the highest percentage of 5xx responses from the canary for which the rollout continues",
+	)
+}
+
+func getRolloutConfig(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.GetRolloutConfig(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	printRolloutConfig((*types.RolloutConfig)(resp))
+
+	return nil
+}
+
+func setRolloutConfig(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.UpdateRolloutConfig(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		args[0],
+		&types.UpdateRolloutConfigRequest{
+			Strategy:            types.RolloutStrategy(rolloutStrategy),
+			Steps:               rolloutSteps,
+			StepIntervalSeconds: rolloutInterval,
+			MaxErrorRate:        rolloutMaxErrorRate,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Updated the rollout config of %s\n", args[0])
+
+	printRolloutConfig((*types.RolloutConfig)(resp))
+
+	return nil
+}
+
+func printRolloutConfig(conf *types.RolloutConfig) {
+	steps := make([]string, 0, len(conf.Steps))
+
+	for _, step := range conf.Steps {
+		steps = append(steps, fmt.Sprintf("%d%%", step))
+	}
+
+	fmt.Printf("Strategy:       %s\n", conf.Strategy)
+	fmt.Printf("Steps:          %s\n", strings.Join(steps, ", "))
+	fmt.Printf("Step interval:  %ds\n", conf.StepIntervalSeconds)
+	fmt.Printf("Max error rate: %.2f%%\n", conf.MaxErrorRate)
+}
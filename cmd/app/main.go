@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/router"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/loader"
+	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/models"
 	"gorm.io/gorm"
 )
@@ -43,6 +44,11 @@ func main() {
 		log.Fatal("Data initialization failed: ", err)
 	}
 
+	// delete expired sessions in the background for as long as the server runs
+	if pgStore, ok := config.Store.(*sessionstore.PGStore); ok {
+		pgStore.Cleanup(config.ServerConf.SessionCleanupInterval, config.Logger)
+	}
+
 	appRouter := router.NewAPIRouter(config)
 
 	address := fmt.Sprintf(":%d", config.ServerConf.Port)
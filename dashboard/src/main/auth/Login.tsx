@@ -20,6 +20,8 @@ type StateType = {
   hasBasic: boolean;
   hasGithub: boolean;
   hasGoogle: boolean;
+  hasOIDC: boolean;
+  oidcProviderName: string;
   hasResetPassword: boolean;
 };
 
@@ -32,6 +34,8 @@ export default class Login extends Component<PropsType, StateType> {
     hasBasic: true,
     hasGithub: true,
     hasGoogle: false,
+    hasOIDC: false,
+    oidcProviderName: "SSO",
     hasResetPassword: true,
   };
 
@@ -54,6 +58,8 @@ export default class Login extends Component<PropsType, StateType> {
           hasBasic: res.data?.basic_login,
           hasGithub: res.data?.github_login,
           hasGoogle: res.data?.google_login,
+          hasOIDC: res.data?.oidc_login,
+          oidcProviderName: res.data?.oidc_provider_name || "SSO",
           hasResetPassword: res.data?.email,
         });
       })
@@ -130,6 +136,11 @@ export default class Login extends Component<PropsType, StateType> {
     window.location.href = redirectUrl;
   };
 
+  oidcRedirect = () => {
+    let redirectUrl = `/api/oauth/login/oidc`;
+    window.location.href = redirectUrl;
+  };
+
   renderGithubSection = () => {
     if (this.state.hasGithub) {
       return (
@@ -156,6 +167,16 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  renderOIDCSection = () => {
+    if (this.state.hasOIDC) {
+      return (
+        <OAuthButton onClick={this.oidcRedirect}>
+          <IconWrapper>Log in with {this.state.oidcProviderName}</IconWrapper>
+        </OAuthButton>
+      );
+    }
+  };
+
   renderBasicSection = () => {
     if (this.state.hasBasic) {
       let { email, password, credentialError, emailError } = this.state;
@@ -221,7 +242,9 @@ export default class Login extends Component<PropsType, StateType> {
       <StyledLogin>
         <LoginPanel
           hasBasic={this.state.hasBasic}
-          numOAuth={+this.state.hasGithub + +this.state.hasGoogle}
+          numOAuth={
+            +this.state.hasGithub + +this.state.hasGoogle + +this.state.hasOIDC
+          }
         >
           <OverflowWrapper>
             <GradientBg />
@@ -231,7 +254,10 @@ export default class Login extends Component<PropsType, StateType> {
             <Prompt>Log in to Porter</Prompt>
             {this.renderGithubSection()}
             {this.renderGoogleSection()}
-            {(this.state.hasGithub || this.state.hasGoogle) &&
+            {this.renderOIDCSection()}
+            {(this.state.hasGithub ||
+              this.state.hasGoogle ||
+              this.state.hasOIDC) &&
             this.state.hasBasic ? (
               <OrWrapper>
                 <Line />
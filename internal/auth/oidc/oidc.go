@@ -0,0 +1,516 @@
+// Package oidc implements a generic OpenID Connect relying party, which is used for
+// single sign-on with identity providers such as Okta or Keycloak. The provider's
+// endpoints and signing keys are read from its discovery document.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+// DiscoveryPath is appended to the issuer URL to get the discovery document
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// keySetRefreshInterval is the minimum amount of time between fetches of the
+// provider's key set, so that tokens signed by unknown keys cannot be used to
+// make the server hammer the provider
+const keySetRefreshInterval = 5 * time.Minute
+
+// Config is the configuration for a generic OIDC provider
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// HTTPClient is used for requests to the provider. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// DiscoveryDocument contains the fields of the provider metadata which are used by
+// the relying party
+type DiscoveryDocument struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+	JWKSURL     string `json:"jwks_uri"`
+}
+
+// IDToken is a verified ID token
+type IDToken struct {
+	Issuer  string
+	Subject string
+
+	// Claims contains all claims in the ID token, merged with the claims returned by
+	// the userinfo endpoint if the ID token did not contain them
+	Claims map[string]interface{}
+}
+
+// Provider is a lazily-discovered OIDC provider. The discovery document is fetched on
+// first use so that an unreachable identity provider does not prevent the server
+// from starting.
+type Provider struct {
+	conf *Config
+
+	mu          sync.Mutex
+	discovery   *DiscoveryDocument
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+}
+
+// NewProvider returns a provider for the given configuration
+func NewProvider(conf *Config) *Provider {
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+
+	if len(conf.Scopes) == 0 {
+		conf.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &Provider{
+		conf: conf,
+	}
+}
+
+// Discover returns the provider's discovery document, fetching it if it has not
+// been fetched before
+func (p *Provider) Discover(ctx context.Context) (*DiscoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.discover(ctx)
+}
+
+// AuthCodeURL returns the URL to redirect the user to in order to log in with the
+// provider
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	oauthConf, err := p.oauthConfig(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConf.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange exchanges an authorization code for a token. The returned token's ID
+// token can be read with RawIDToken.
+func (p *Provider) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	oauthConf, err := p.oauthConfig(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return oauthConf.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.conf.HTTPClient), code)
+}
+
+// RawIDToken returns the id_token field of a token response
+func RawIDToken(tok *oauth2.Token) (string, error) {
+	raw, ok := tok.Extra("id_token").(string)
+
+	if !ok || raw == "" {
+		return "", fmt.Errorf("token response did not contain an id_token")
+	}
+
+	return raw, nil
+}
+
+// VerifyIDToken verifies the signature, issuer, audience, expiry and nonce of a raw
+// ID token and returns its claims
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDToken, error) {
+	doc, err := p.Discover(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
+		kid, _ := t.Header["kid"].(string)
+
+		return p.getKey(ctx, kid)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	// jwt-go only validates exp, iat and nbf by default
+	if !claims.VerifyIssuer(doc.Issuer, true) {
+		return nil, fmt.Errorf("invalid id token: unexpected issuer %v", claims["iss"])
+	}
+
+	if !verifyAudience(claims, p.conf.ClientID) {
+		return nil, fmt.Errorf("invalid id token: client id is not in audience")
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("invalid id token: missing exp claim")
+	}
+
+	if tokNonce, _ := claims["nonce"].(string); nonce != "" && tokNonce != nonce {
+		return nil, fmt.Errorf("invalid id token: nonce does not match")
+	}
+
+	sub, _ := claims["sub"].(string)
+
+	if sub == "" {
+		return nil, fmt.Errorf("invalid id token: missing sub claim")
+	}
+
+	return &IDToken{
+		Issuer:  doc.Issuer,
+		Subject: sub,
+		Claims:  claims,
+	}, nil
+}
+
+// PopulateUserInfo adds the claims from the userinfo endpoint to the ID token, for
+// any claims which are not already set. This is used for providers which only
+// include a minimal set of claims in the ID token.
+func (p *Provider) PopulateUserInfo(ctx context.Context, idToken *IDToken, tok *oauth2.Token) error {
+	doc, err := p.Discover(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if doc.UserInfoURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", doc.UserInfoURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+
+	userInfo := make(map[string]interface{})
+
+	if err := p.getJSON(req, &userInfo); err != nil {
+		return fmt.Errorf("failed getting user info: %w", err)
+	}
+
+	// the userinfo sub must match the ID token sub, see OIDC core section 5.3.2
+	if sub, _ := userInfo["sub"].(string); sub != idToken.Subject {
+		return fmt.Errorf("userinfo subject does not match id token subject")
+	}
+
+	for key, val := range userInfo {
+		if _, exists := idToken.Claims[key]; !exists {
+			idToken.Claims[key] = val
+		}
+	}
+
+	return nil
+}
+
+// GetStringClaim returns a string claim, or the empty string if the claim does not
+// exist or is not a string
+func (t *IDToken) GetStringClaim(name string) string {
+	val, _ := t.Claims[name].(string)
+
+	return val
+}
+
+// GetBoolClaim returns a boolean claim. Some providers encode booleans as strings,
+// so "true" is also accepted.
+func (t *IDToken) GetBoolClaim(name string) bool {
+	switch val := t.Claims[name].(type) {
+	case bool:
+		return val
+	case string:
+		return val == "true"
+	default:
+		return false
+	}
+}
+
+// GetStringListClaim returns a claim which is a list of strings. A single string is
+// treated as a list of one element.
+func (t *IDToken) GetStringListClaim(name string) []string {
+	switch val := t.Claims[name].(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		res := make([]string, 0, len(val))
+
+		for _, elem := range val {
+			if str, ok := elem.(string); ok {
+				res = append(res, str)
+			}
+		}
+
+		return res
+	default:
+		return []string{}
+	}
+}
+
+func (p *Provider) discover(ctx context.Context) (*DiscoveryDocument, error) {
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(p.conf.IssuerURL, "/")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", issuer+DiscoveryPath, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &DiscoveryDocument{}
+
+	if err := p.getJSON(req, doc); err != nil {
+		return nil, fmt.Errorf("failed getting oidc discovery document: %w", err)
+	}
+
+	// the issuer in the discovery document must exactly match the configured issuer,
+	// see OIDC discovery section 4.3
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc issuer %s does not match configured issuer %s", doc.Issuer, p.conf.IssuerURL)
+	}
+
+	if doc.AuthURL == "" || doc.TokenURL == "" || doc.JWKSURL == "" {
+		return nil, fmt.Errorf("oidc discovery document is missing required endpoints")
+	}
+
+	p.discovery = doc
+
+	return doc, nil
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	doc, err := p.Discover(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.conf.ClientID,
+		ClientSecret: p.conf.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  doc.AuthURL,
+			TokenURL: doc.TokenURL,
+		},
+		RedirectURL: p.conf.RedirectURL,
+		Scopes:      p.conf.Scopes,
+	}, nil
+}
+
+// getKey returns the public key with the given key id, refreshing the key set if the
+// key is not known, since providers rotate their signing keys
+func (p *Provider) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	if !p.keysFetched.IsZero() && time.Since(p.keysFetched) < keySetRefreshInterval {
+		return nil, fmt.Errorf("signing key %s not found", kid)
+	}
+
+	doc, err := p.discover(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := p.fetchKeys(ctx, doc.JWKSURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = keys
+	p.keysFetched = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("signing key %s not found", kid)
+}
+
+func (p *Provider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid != "" {
+		key, ok := p.keys[kid]
+		return key, ok
+	}
+
+	// tokens without a key id can only be verified if the provider has a single key
+	if len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, jwksURL string) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", jwksURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+
+	if err := p.getJSON(req, keySet); err != nil {
+		return nil, fmt.Errorf("failed getting oidc signing keys: %w", err)
+	}
+
+	res := make(map[string]crypto.PublicKey)
+
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+
+		// unsupported key types are skipped, since the key set may contain keys
+		// which are not used for ID tokens
+		if err != nil {
+			continue
+		}
+
+		res[jwk.Kid] = key
+	}
+
+	return res, nil
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(jwk.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func verifyAudience(claims jwt.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, elem := range aud {
+			if str, ok := elem.(string); ok && str == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *Provider) getJSON(req *http.Request, target interface{}) error {
+	resp, err := p.conf.HTTPClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL.String())
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,212 @@
+package oidc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/auth/oidc/oidctest"
+)
+
+func newTestProvider(t *testing.T) (*oidctest.Issuer, *oidc.Provider) {
+	iss, err := oidctest.NewIssuer("porter")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	t.Cleanup(iss.Close)
+
+	provider := oidc.NewProvider(&oidc.Config{
+		IssuerURL:    iss.URL(),
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/api/oauth/oidc/callback",
+	})
+
+	return iss, provider
+}
+
+func TestDiscover(t *testing.T) {
+	iss, provider := newTestProvider(t)
+
+	doc, err := provider.Discover(context.Background())
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if doc.TokenURL != iss.URL()+"/token" {
+		t.Errorf("unexpected token url: %s", doc.TokenURL)
+	}
+
+	url, err := provider.AuthCodeURL(context.Background(), "state", "nonce")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !strings.HasPrefix(url, iss.URL()+"/authorize?") || !strings.Contains(url, "nonce=nonce") {
+		t.Errorf("unexpected auth code url: %s", url)
+	}
+}
+
+func TestDiscoverWrongIssuerURL(t *testing.T) {
+	iss, err := oidctest.NewIssuer("porter")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	defer iss.Close()
+
+	provider := oidc.NewProvider(&oidc.Config{
+		IssuerURL: iss.URL() + "/other",
+		ClientID:  "porter",
+	})
+
+	if _, err := provider.Discover(context.Background()); err == nil {
+		t.Errorf("expected error for missing discovery document")
+	}
+}
+
+func TestExchangeAndVerify(t *testing.T) {
+	iss, provider := newTestProvider(t)
+
+	iss.Claims["sub"] = "user-1"
+	iss.Claims["email"] = "test@example.com"
+	iss.Claims["groups"] = []string{"eng", "ops"}
+
+	tok, err := provider.Exchange(context.Background(), iss.IssueCode("abc"))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	raw, err := oidc.RawIDToken(tok)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	idToken, err := provider.VerifyIDToken(context.Background(), raw, "abc")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if idToken.Subject != "user-1" {
+		t.Errorf("unexpected subject: %s", idToken.Subject)
+	}
+
+	if email := idToken.GetStringClaim("email"); email != "test@example.com" {
+		t.Errorf("unexpected email: %s", email)
+	}
+
+	if groups := idToken.GetStringListClaim("groups"); len(groups) != 2 || groups[1] != "ops" {
+		t.Errorf("unexpected groups: %v", groups)
+	}
+
+	// the nonce must match the nonce from the auth request
+	if _, err := provider.VerifyIDToken(context.Background(), raw, "other"); err == nil {
+		t.Errorf("expected error for mismatched nonce")
+	}
+}
+
+var invalidIDTokenTests = []struct {
+	description string
+	claims      map[string]interface{}
+}{
+	{
+		description: "wrong audience",
+		claims:      map[string]interface{}{"sub": "user-1", "aud": "other-client"},
+	},
+	{
+		description: "wrong issuer",
+		claims:      map[string]interface{}{"sub": "user-1", "iss": "https://other.example.com"},
+	},
+	{
+		description: "expired",
+		claims:      map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()},
+	},
+	{
+		description: "missing subject",
+		claims:      map[string]interface{}{},
+	},
+}
+
+func TestVerifyInvalidIDTokens(t *testing.T) {
+	iss, provider := newTestProvider(t)
+
+	for _, test := range invalidIDTokenTests {
+		raw, err := iss.SignIDToken(test.claims)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if _, err := provider.VerifyIDToken(context.Background(), raw, ""); err == nil {
+			t.Errorf("[ %s ]: expected error verifying id token", test.description)
+		}
+	}
+
+	// tokens signed by a different key should be rejected
+	other, err := oidctest.NewIssuer("porter")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	defer other.Close()
+
+	raw, err := other.SignIDToken(map[string]interface{}{"sub": "user-1", "iss": iss.URL()})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := provider.VerifyIDToken(context.Background(), raw, ""); err == nil {
+		t.Errorf("expected error for id token signed by unknown key")
+	}
+}
+
+func TestPopulateUserInfo(t *testing.T) {
+	iss, provider := newTestProvider(t)
+
+	iss.Claims["sub"] = "user-1"
+	iss.UserInfo["sub"] = "user-1"
+	iss.UserInfo["email"] = "test@example.com"
+	iss.UserInfo["email_verified"] = true
+
+	tok, err := provider.Exchange(context.Background(), iss.IssueCode(""))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	raw, err := oidc.RawIDToken(tok)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	idToken, err := provider.VerifyIDToken(context.Background(), raw, "")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := provider.PopulateUserInfo(context.Background(), idToken, tok); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if email := idToken.GetStringClaim("email"); email != "test@example.com" {
+		t.Errorf("unexpected email: %s", email)
+	}
+
+	if !idToken.GetBoolClaim("email_verified") {
+		t.Errorf("expected email to be verified")
+	}
+}
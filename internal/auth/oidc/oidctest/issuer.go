@@ -0,0 +1,219 @@
+// Package oidctest provides a stub OIDC issuer which can be used to test the OIDC
+// login flow without a real identity provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const stubKeyID = "stub-key"
+
+// Issuer is a stub OIDC issuer backed by an httptest.Server. It implements discovery,
+// the key set, the authorization endpoint, the token endpoint and the userinfo
+// endpoint. Every authorization code issues an ID token containing Claims.
+type Issuer struct {
+	Server   *httptest.Server
+	ClientID string
+
+	// Claims are added to every ID token that is issued, in addition to the iss,
+	// aud, exp, iat and nonce claims
+	Claims map[string]interface{}
+
+	// UserInfo is returned from the userinfo endpoint
+	UserInfo map[string]interface{}
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	nonces map[string]string
+}
+
+// NewIssuer starts a new stub issuer. The caller must call Close when done.
+func NewIssuer(clientID string) (*Issuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iss := &Issuer{
+		ClientID: clientID,
+		Claims:   make(map[string]interface{}),
+		UserInfo: make(map[string]interface{}),
+		key:      key,
+		nonces:   make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", iss.handleDiscovery)
+	mux.HandleFunc("/keys", iss.handleKeys)
+	mux.HandleFunc("/authorize", iss.handleAuthorize)
+	mux.HandleFunc("/token", iss.handleToken)
+	mux.HandleFunc("/userinfo", iss.handleUserInfo)
+
+	iss.Server = httptest.NewServer(mux)
+
+	return iss, nil
+}
+
+// URL returns the issuer URL
+func (iss *Issuer) URL() string {
+	return iss.Server.URL
+}
+
+// Close shuts down the issuer
+func (iss *Issuer) Close() {
+	iss.Server.Close()
+}
+
+// IssueCode returns an authorization code which can be exchanged for an ID token
+// with the given nonce, as if the user had logged in at the authorization endpoint
+func (iss *Issuer) IssueCode(nonce string) string {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	code := base64.RawURLEncoding.EncodeToString(b)
+	iss.nonces[code] = nonce
+
+	return code
+}
+
+// SignIDToken signs an ID token with the issuer's key. The standard claims are set
+// unless they are present in claims.
+func (iss *Issuer) SignIDToken(claims map[string]interface{}) (string, error) {
+	mapClaims := jwt.MapClaims{
+		"iss": iss.URL(),
+		"aud": iss.ClientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	for key, val := range claims {
+		mapClaims[key] = val
+	}
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	tok.Header["kid"] = stubKeyID
+
+	return tok.SignedString(iss.key)
+}
+
+func (iss *Issuer) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"issuer":                                iss.URL(),
+		"authorization_endpoint":                iss.URL() + "/authorize",
+		"token_endpoint":                        iss.URL() + "/token",
+		"userinfo_endpoint":                     iss.URL() + "/userinfo",
+		"jwks_uri":                              iss.URL() + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (iss *Issuer) handleKeys(w http.ResponseWriter, r *http.Request) {
+	pub := iss.key.PublicKey
+
+	writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": stubKeyID,
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	})
+}
+
+// handleAuthorize logs the user in immediately and redirects back to the client
+func (iss *Issuer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vals := redirect.Query()
+	vals.Set("code", iss.IssueCode(query.Get("nonce")))
+	vals.Set("state", query.Get("state"))
+	redirect.RawQuery = vals.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (iss *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	iss.mu.Lock()
+	nonce, ok := iss.nonces[r.PostForm.Get("code")]
+	delete(iss.nonces, r.PostForm.Get("code"))
+	iss.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := make(map[string]interface{})
+
+	for key, val := range iss.Claims {
+		claims[key] = val
+	}
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	idToken, err := iss.SignIDToken(claims)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (iss *Issuer) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer stub-access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, iss.UserInfo)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
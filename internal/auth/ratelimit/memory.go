@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// maxMemoryEntries is the number of entries after which expired entries are pruned
+// from a MemoryStore
+const maxMemoryEntries = 10000
+
+// MemoryStore is an in-memory Store, used when Redis is not enabled. Failures are
+// not shared between server replicas.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+
+	// now can be overwritten in tests
+	now func() time.Time
+}
+
+type memoryEntry struct {
+	failures     int64
+	resetAt      time.Time
+	blockedUntil time.Time
+}
+
+// NewMemoryStore returns a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) IncrFailures(key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entry, ok := s.entries[key]
+
+	if !ok {
+		s.prune(now)
+
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+
+	if entry.resetAt.Before(now) {
+		entry.failures = 0
+		entry.resetAt = now.Add(window)
+	}
+
+	entry.failures++
+
+	return entry.failures, nil
+}
+
+func (s *MemoryStore) ResetFailures(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+func (s *MemoryStore) Block(key string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+
+	if !ok {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+
+	entry.blockedUntil = s.now().Add(d)
+
+	return nil
+}
+
+func (s *MemoryStore) BlockedFor(key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+
+	if !ok {
+		return 0, nil
+	}
+
+	if d := entry.blockedUntil.Sub(s.now()); d > 0 {
+		return d, nil
+	}
+
+	return 0, nil
+}
+
+// prune removes entries which are neither counting failures nor blocked, once the
+// store has grown past maxMemoryEntries
+func (s *MemoryStore) prune(now time.Time) {
+	if len(s.entries) < maxMemoryEntries {
+		return
+	}
+
+	for key, entry := range s.entries {
+		if entry.resetAt.Before(now) && entry.blockedUntil.Before(now) {
+			delete(s.entries, key)
+		}
+	}
+}
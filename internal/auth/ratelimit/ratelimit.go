@@ -0,0 +1,259 @@
+// Package ratelimit throttles failed authentication attempts. Failures are counted
+// per client IP and per account, and once a key has used up its free attempts every
+// further failure blocks the key for an exponentially growing backoff. When a key
+// reaches its maximum number of attempts, it is locked out entirely.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// KeyKind is the kind of key that failures are counted against
+type KeyKind string
+
+const (
+	KeyKindIP      KeyKind = "ip"
+	KeyKindAccount KeyKind = "account"
+)
+
+// Store stores failure counts and blocks for keys. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// IncrFailures increments the number of failures for key and returns the new
+	// count. The count is reset once window has passed since the first failure.
+	IncrFailures(key string, window time.Duration) (int64, error)
+
+	// ResetFailures clears the failure count and any block for key
+	ResetFailures(key string) error
+
+	// Block blocks key for the duration d
+	Block(key string, d time.Duration) error
+
+	// BlockedFor returns the remaining time that key is blocked for, or 0 if the key
+	// is not blocked
+	BlockedFor(key string) (time.Duration, error)
+}
+
+// Policy configures how failures for a kind of key are throttled
+type Policy struct {
+	// FreeAttempts is the number of failures allowed within Window before backoff
+	// is applied
+	FreeAttempts int64
+
+	// MaxAttempts is the number of failures within Window after which the key is
+	// locked out for LockoutDuration
+	MaxAttempts int64
+
+	// Window is the period that failures are counted over
+	Window time.Duration
+
+	// BaseBackoff is the block applied after the first failure past FreeAttempts.
+	// Each further failure doubles the block, up to LockoutDuration.
+	BaseBackoff time.Duration
+
+	// LockoutDuration is how long a key is locked out for after MaxAttempts
+	LockoutDuration time.Duration
+}
+
+// backoff returns the time that a key should be blocked for after the given number
+// of failures, and whether the key is now locked out
+func (p *Policy) backoff(failures int64) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && failures >= p.MaxAttempts {
+		return p.LockoutDuration, true
+	}
+
+	if failures <= p.FreeAttempts {
+		return 0, false
+	}
+
+	exp := float64(failures - p.FreeAttempts - 1)
+	d := time.Duration(float64(p.BaseBackoff) * math.Pow(2, exp))
+
+	if d <= 0 || d > p.LockoutDuration {
+		d = p.LockoutDuration
+	}
+
+	return d, false
+}
+
+// LockoutEvent is emitted when a key reaches the maximum number of attempts
+type LockoutEvent struct {
+	Kind     KeyKind
+	Value    string
+	Action   string
+	Failures int64
+	Duration time.Duration
+}
+
+// Error is returned when an attempt is made with a blocked key
+type Error struct {
+	Kind       KeyKind
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("too many failed attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+// LimiterOpts are the options for creating a Limiter
+type LimiterOpts struct {
+	Store         Store
+	IPPolicy      *Policy
+	AccountPolicy *Policy
+
+	// OnLockout is called whenever a key is locked out. It is called synchronously,
+	// so it should not block.
+	OnLockout func(event *LockoutEvent)
+}
+
+// Limiter throttles authentication attempts for an action, like logging in, by IP
+// and account
+type Limiter struct {
+	store         Store
+	ipPolicy      *Policy
+	accountPolicy *Policy
+	onLockout     func(event *LockoutEvent)
+}
+
+// DefaultIPPolicy allows many more attempts than DefaultAccountPolicy, since many
+// users may share a single IP
+var DefaultIPPolicy = &Policy{
+	FreeAttempts:    20,
+	MaxAttempts:     100,
+	Window:          15 * time.Minute,
+	BaseBackoff:     time.Second,
+	LockoutDuration: 15 * time.Minute,
+}
+
+// DefaultAccountPolicy is the default policy for failures against a single account
+var DefaultAccountPolicy = &Policy{
+	FreeAttempts:    3,
+	MaxAttempts:     10,
+	Window:          15 * time.Minute,
+	BaseBackoff:     time.Second,
+	LockoutDuration: 15 * time.Minute,
+}
+
+// NewLimiter creates a new Limiter. Unset policies use the default policies.
+func NewLimiter(opts *LimiterOpts) *Limiter {
+	l := &Limiter{
+		store:         opts.Store,
+		ipPolicy:      opts.IPPolicy,
+		accountPolicy: opts.AccountPolicy,
+		onLockout:     opts.OnLockout,
+	}
+
+	if l.ipPolicy == nil {
+		l.ipPolicy = DefaultIPPolicy
+	}
+
+	if l.accountPolicy == nil {
+		l.accountPolicy = DefaultAccountPolicy
+	}
+
+	return l
+}
+
+// Check returns an *Error if either the IP or the account is blocked for the given
+// action. An empty ip or account is not checked.
+func (l *Limiter) Check(action, ip, account string) error {
+	for _, k := range l.keys(action, ip, account) {
+		d, err := l.store.BlockedFor(k.key)
+
+		if err != nil {
+			return err
+		}
+
+		if d > 0 {
+			return &Error{
+				Kind:       k.kind,
+				RetryAfter: d,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fail records a failed attempt for the IP and the account, applying backoff or a
+// lockout if the policy for either key has been exceeded
+func (l *Limiter) Fail(action, ip, account string) error {
+	for _, k := range l.keys(action, ip, account) {
+		failures, err := l.store.IncrFailures(k.key, k.policy.Window)
+
+		if err != nil {
+			return err
+		}
+
+		d, locked := k.policy.backoff(failures)
+
+		if d == 0 {
+			continue
+		}
+
+		if err := l.store.Block(k.key, d); err != nil {
+			return err
+		}
+
+		if locked && l.onLockout != nil {
+			l.onLockout(&LockoutEvent{
+				Kind:     k.kind,
+				Value:    k.value,
+				Action:   action,
+				Failures: failures,
+				Duration: d,
+			})
+		}
+	}
+
+	return nil
+}
+
+// Reset clears the failures for an account after a successful attempt. Failures
+// for the IP are not cleared, so that an attacker cannot reset their IP's failures
+// by logging in to their own account.
+func (l *Limiter) Reset(action, account string) error {
+	for _, k := range l.keys(action, "", account) {
+		if err := l.store.ResetFailures(k.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type limiterKey struct {
+	kind   KeyKind
+	value  string
+	key    string
+	policy *Policy
+}
+
+func (l *Limiter) keys(action, ip, account string) []limiterKey {
+	res := make([]limiterKey, 0)
+
+	if ip != "" {
+		res = append(res, limiterKey{
+			kind:   KeyKindIP,
+			value:  ip,
+			key:    fmt.Sprintf("%s:%s:%s", action, KeyKindIP, ip),
+			policy: l.ipPolicy,
+		})
+	}
+
+	if account != "" {
+		account = strings.ToLower(account)
+
+		res = append(res, limiterKey{
+			kind:   KeyKindAccount,
+			value:  account,
+			key:    fmt.Sprintf("%s:%s:%s", action, KeyKindAccount, account),
+			policy: l.accountPolicy,
+		})
+	}
+
+	return res
+}
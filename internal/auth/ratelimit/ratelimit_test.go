@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testPolicy = &Policy{
+	FreeAttempts:    2,
+	MaxAttempts:     5,
+	Window:          time.Hour,
+	BaseBackoff:     time.Second,
+	LockoutDuration: time.Minute,
+}
+
+var backoffTests = []struct {
+	failures int64
+	exp      time.Duration
+	locked   bool
+}{
+	{1, 0, false},
+	{2, 0, false},
+	{3, time.Second, false},
+	{4, 2 * time.Second, false},
+	{5, time.Minute, true},
+	{6, time.Minute, true},
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	for _, test := range backoffTests {
+		d, locked := testPolicy.backoff(test.failures)
+
+		if d != test.exp || locked != test.locked {
+			t.Errorf("backoff for %d failures incorrect: expected (%s, %t), got (%s, %t)",
+				test.failures, test.exp, test.locked, d, locked)
+		}
+	}
+
+	// the backoff should be capped at the lockout duration
+	capped := &Policy{
+		FreeAttempts:    0,
+		BaseBackoff:     time.Second,
+		LockoutDuration: 10 * time.Second,
+	}
+
+	if d, _ := capped.backoff(10); d != 10*time.Second {
+		t.Errorf("expected backoff to be capped at 10s, got %s", d)
+	}
+}
+
+func newTestLimiter(now *time.Time) (*Limiter, *[]*LockoutEvent) {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+
+	events := make([]*LockoutEvent, 0)
+
+	limiter := NewLimiter(&LimiterOpts{
+		Store:         store,
+		IPPolicy:      testPolicy,
+		AccountPolicy: testPolicy,
+		OnLockout: func(event *LockoutEvent) {
+			events = append(events, event)
+		},
+	})
+
+	return limiter, &events
+}
+
+func TestLimiterBackoffAndLockout(t *testing.T) {
+	now := time.Now()
+	limiter, events := newTestLimiter(&now)
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Fail("login", "", "user@example.com"); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if err := limiter.Check("login", "", "user@example.com"); err != nil {
+			t.Fatalf("expected free attempt %d not to be blocked, got %v", i+1, err)
+		}
+	}
+
+	// the third failure should block for the base backoff
+	limiter.Fail("login", "", "User@Example.com")
+
+	err := limiter.Check("login", "", "user@example.com")
+	var rlErr *Error
+
+	if !errors.As(err, &rlErr) || rlErr.Kind != KeyKindAccount || rlErr.RetryAfter != time.Second {
+		t.Fatalf("expected account to be blocked for 1s, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+
+	if err := limiter.Check("login", "", "user@example.com"); err != nil {
+		t.Fatalf("expected block to expire, got %v", err)
+	}
+
+	// other actions should not be affected
+	if err := limiter.Check("password_reset", "", "user@example.com"); err != nil {
+		t.Fatalf("expected other action not to be blocked, got %v", err)
+	}
+
+	limiter.Fail("login", "", "user@example.com")
+	limiter.Fail("login", "", "user@example.com")
+
+	if len(*events) != 1 || (*events)[0].Value != "user@example.com" || (*events)[0].Duration != time.Minute {
+		t.Fatalf("expected a single lockout event, got %v", *events)
+	}
+
+	now = now.Add(30 * time.Second)
+
+	if err := limiter.Check("login", "", "user@example.com"); err == nil {
+		t.Fatalf("expected account to be locked out")
+	}
+}
+
+func TestLimiterReset(t *testing.T) {
+	now := time.Now()
+	limiter, _ := newTestLimiter(&now)
+
+	for i := 0; i < 3; i++ {
+		limiter.Fail("login", "10.0.0.1", "user@example.com")
+	}
+
+	if err := limiter.Reset("login", "user@example.com"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the IP should still be blocked after the account is reset
+	err := limiter.Check("login", "10.0.0.1", "user@example.com")
+	var rlErr *Error
+
+	if !errors.As(err, &rlErr) || rlErr.Kind != KeyKindIP {
+		t.Fatalf("expected IP to be blocked, got %v", err)
+	}
+
+	if err := limiter.Check("login", "10.0.0.2", "user@example.com"); err != nil {
+		t.Fatalf("expected account to be reset, got %v", err)
+	}
+}
+
+func TestMemoryStoreWindow(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	store.IncrFailures("key", time.Minute)
+
+	if failures, _ := store.IncrFailures("key", time.Minute); failures != 2 {
+		t.Fatalf("expected 2 failures, got %d", failures)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if failures, _ := store.IncrFailures("key", time.Minute); failures != 1 {
+		t.Fatalf("expected failures to reset after window, got %d", failures)
+	}
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	redis "github.com/go-redis/redis/v8"
+)
+
+const redisKeyPrefix = "porter:ratelimit:"
+
+// RedisStore is a Store backed by Redis, so that failures are shared between server
+// replicas
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a new store using the given client
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client}
+}
+
+// incrFailuresScript increments the failures of a key, and sets the expiry of the
+// window in the same step so that the counter can't be left without an expiry. The
+// window starts at the first failure, and is also set if the counter has no expiry.
+var incrFailuresScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+
+if failures == 1 or redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+
+return failures
+`)
+
+func (s *RedisStore) IncrFailures(key string, window time.Duration) (int64, error) {
+	return incrFailuresScript.Run(
+		context.Background(),
+		s.client,
+		[]string{redisKeyPrefix + key + ":failures"},
+		window.Milliseconds(),
+	).Int64()
+}
+
+func (s *RedisStore) ResetFailures(key string) error {
+	return s.client.Del(
+		context.Background(),
+		redisKeyPrefix+key+":failures",
+		redisKeyPrefix+key+":blocked",
+	).Err()
+}
+
+func (s *RedisStore) Block(key string, d time.Duration) error {
+	return s.client.Set(context.Background(), redisKeyPrefix+key+":blocked", "1", d).Err()
+}
+
+func (s *RedisStore) BlockedFor(key string) (time.Duration, error) {
+	d, err := s.client.PTTL(context.Background(), redisKeyPrefix+key+":blocked").Result()
+
+	if err != nil {
+		return 0, err
+	}
+
+	// PTTL returns a negative duration if the key does not exist or has no expiry
+	if d < 0 {
+		return 0, nil
+	}
+
+	return d, nil
+}
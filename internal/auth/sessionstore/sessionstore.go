@@ -12,12 +12,20 @@ import (
 	"github.com/gorilla/sessions"
 
 	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/internal/logger"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 
 	"gorm.io/gorm"
 )
 
+// lastSeenUpdateInterval is the minimum amount of time between updates of the last
+// seen time of a session
+const lastSeenUpdateInterval = time.Minute
+
+const defaultCleanupInterval = time.Hour
+
 // structs
 
 // PGStore is a wrapper around gorilla/sessions store.
@@ -26,6 +34,9 @@ type PGStore struct {
 	Options *sessions.Options
 	Path    string
 	Repo    repository.SessionRepository
+
+	// TrustedProxies are used to determine the IP of the client of a session
+	TrustedProxies *requestutils.TrustedProxies
 }
 
 // Helpers
@@ -58,19 +69,34 @@ func (store *PGStore) MaxAge(age int) {
 
 // load fetches a session by ID from the database and decodes its content
 // into session.Values.
-func (store *PGStore) load(session *sessions.Session) error {
+func (store *PGStore) load(r *http.Request, session *sessions.Session) error {
 	res, err := store.Repo.SelectSession(&models.Session{Key: session.ID})
 
 	if err != nil {
 		return err
 	}
 
-	return securecookie.DecodeMulti(session.Name(), string(res.Data), &session.Values, store.Codecs...)
+	if err := securecookie.DecodeMulti(session.Name(), string(res.Data), &session.Values, store.Codecs...); err != nil {
+		return err
+	}
+
+	// the last seen time is only written periodically, to avoid a write on every request
+	if time.Since(res.LastSeenAt) > lastSeenUpdateInterval {
+		// failing to record activity should not fail the request, so the error is ignored
+		store.Repo.UpdateSessionActivity(&models.Session{
+			Key:        session.ID,
+			IPAddress:  requestutils.GetClientIP(r, store.TrustedProxies),
+			UserAgent:  r.UserAgent(),
+			LastSeenAt: time.Now(),
+		})
+	}
+
+	return nil
 }
 
 // save writes encoded session.Values to a database record.
 // writes to http_sessions table by default.
-func (store *PGStore) save(session *sessions.Session) error {
+func (store *PGStore) save(r *http.Request, session *sessions.Session) error {
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values, store.Codecs...)
 	if err != nil {
 		return err
@@ -90,9 +116,18 @@ func (store *PGStore) save(session *sessions.Session) error {
 	}
 
 	s := &models.Session{
-		Key:       session.ID,
-		Data:      []byte(encoded),
-		ExpiresAt: expiresOn,
+		Key:        session.ID,
+		Data:       []byte(encoded),
+		ExpiresAt:  expiresOn,
+		IPAddress:  requestutils.GetClientIP(r, store.TrustedProxies),
+		UserAgent:  r.UserAgent(),
+		LastSeenAt: time.Now(),
+	}
+
+	// the user id is stored alongside the session so that a user's sessions can be
+	// listed and revoked
+	if authenticated, _ := session.Values["authenticated"].(bool); authenticated {
+		s.UserID, _ = session.Values["user_id"].(uint)
 	}
 
 	repo := store.Repo
@@ -111,6 +146,7 @@ func (store *PGStore) save(session *sessions.Session) error {
 type NewStoreOpts struct {
 	SessionRepository repository.SessionRepository
 	CookieSecrets     []string
+	TrustedProxies    *requestutils.TrustedProxies
 }
 
 // NewStore takes an initialized db and session key pairs to create a session-store in postgres db.
@@ -130,7 +166,8 @@ func NewStore(opts *NewStoreOpts) (*PGStore, error) {
 			HttpOnly: true,
 			SameSite: http.SameSiteLaxMode,
 		},
-		Repo: opts.SessionRepository,
+		Repo:           opts.SessionRepository,
+		TrustedProxies: opts.TrustedProxies,
 	}
 
 	return dbStore, nil
@@ -171,7 +208,7 @@ func (store *PGStore) New(r *http.Request, name string) (*sessions.Session, erro
 	if c, errCookie := r.Cookie(name); errCookie == nil {
 		err = securecookie.DecodeMulti(name, c.Value, &session.ID, store.Codecs...)
 		if err == nil {
-			err = store.load(session)
+			err = store.load(r, session)
 
 			if err != nil {
 				if err == gorm.ErrRecordNotFound {
@@ -212,7 +249,7 @@ func (store *PGStore) Save(r *http.Request, w http.ResponseWriter, session *sess
 			), "=")
 	}
 
-	if err := store.save(session); err != nil {
+	if err := store.save(r, session); err != nil {
 		return err
 	}
 
@@ -225,3 +262,48 @@ func (store *PGStore) Save(r *http.Request, w http.ResponseWriter, session *sess
 	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
 	return nil
 }
+
+// Cleanup runs a background goroutine every interval that deletes expired sessions
+// from the database. The goroutine can be stopped by calling StopCleanup with the
+// returned channels.
+func (store *PGStore) Cleanup(interval time.Duration, l *logger.Logger) (chan<- struct{}, <-chan struct{}) {
+	if interval <= 0 {
+		interval = defaultCleanupInterval
+	}
+
+	quit, done := make(chan struct{}), make(chan struct{})
+	go store.cleanup(interval, l, quit, done)
+
+	return quit, done
+}
+
+// StopCleanup stops the background cleanup goroutine started by Cleanup
+func (store *PGStore) StopCleanup(quit chan<- struct{}, done <-chan struct{}) {
+	quit <- struct{}{}
+	<-done
+}
+
+func (store *PGStore) cleanup(interval time.Duration, l *logger.Logger, quit <-chan struct{}, done chan<- struct{}) {
+	ticker := time.NewTicker(interval)
+
+	defer func() {
+		ticker.Stop()
+		done <- struct{}{}
+	}()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+			count, err := store.Repo.DeleteExpiredSessions(time.Now())
+
+			if err != nil {
+				l.Error().Err(err).Msg("failed to delete expired sessions")
+				continue
+			}
+
+			l.Debug().Msgf("deleted %d expired sessions", count)
+		}
+	}
+}
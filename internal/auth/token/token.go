@@ -25,6 +25,10 @@ type Token struct {
 	ProjectID uint       `json:"project_id"`
 	IBy       uint       `json:"iby"`
 	IAt       *time.Time `json:"iat"`
+
+	// TokenID and Expiry are only set for stored API tokens, which can be revoked
+	TokenID string     `json:"token_id"`
+	Expiry  *time.Time `json:"exp"`
 }
 
 func GetTokenForUser(userID uint) (*Token, error) {
@@ -58,14 +62,49 @@ func GetTokenForAPI(userID, projID uint) (*Token, error) {
 	}, nil
 }
 
+// GetStoredTokenForAPI generates a token for a stored API token, identified by
+// tokenID. The expiry is optional.
+func GetStoredTokenForAPI(userID, projID uint, tokenID string, expiry *time.Time) (*Token, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("token id cannot be empty")
+	}
+
+	tok, err := GetTokenForAPI(userID, projID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tok.TokenID = tokenID
+	tok.Expiry = expiry
+
+	return tok, nil
+}
+
+// IsStored returns true if the token is backed by a stored API token
+func (t *Token) IsStored() bool {
+	return t.TokenID != ""
+}
+
 func (t *Token) EncodeToken(conf *TokenGeneratorConf) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	claims := jwt.MapClaims{
 		"sub_kind":   t.SubKind,
 		"sub":        t.Sub,
 		"iby":        t.IBy,
 		"iat":        fmt.Sprintf("%d", t.IAt.Unix()),
 		"project_id": t.ProjectID,
-	})
+	}
+
+	if t.TokenID != "" {
+		claims["token_id"] = t.TokenID
+	}
+
+	// the exp claim is verified by the jwt library when the token is parsed
+	if t.Expiry != nil {
+		claims["exp"] = t.Expiry.Unix()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// Sign and get the complete encoded token as a string using the secret
 	return token.SignedString([]byte(conf.TokenSecret))
@@ -105,13 +144,24 @@ func GetTokenFromEncoded(tokenString string, conf *TokenGeneratorConf) (*Token,
 
 		iat := time.Unix(iatUnix, 0)
 
-		return &Token{
+		res := &Token{
 			SubKind:   Subject(fmt.Sprintf("%v", claims["sub_kind"])),
 			Sub:       fmt.Sprintf("%v", claims["sub"]),
 			IBy:       uint(iby),
 			IAt:       &iat,
 			ProjectID: uint(projID),
-		}, nil
+		}
+
+		if tokenID, ok := claims["token_id"].(string); ok {
+			res.TokenID = tokenID
+		}
+
+		if exp, ok := claims["exp"].(float64); ok {
+			expiry := time.Unix(int64(exp), 0)
+			res.Expiry = &expiry
+		}
+
+		return res, nil
 	}
 
 	return nil, fmt.Errorf("invalid token")
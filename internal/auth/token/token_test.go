@@ -49,3 +49,69 @@ func TestGetAndEncodeTokenForUser(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestGetAndEncodeStoredTokenForAPI(t *testing.T) {
+	conf := &token.TokenGeneratorConf{
+		TokenSecret: "fakesecret",
+	}
+
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tok, err := token.GetStoredTokenForAPI(1, 2, "abcdef", &expiry)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokString, err := tok.EncodeToken(conf)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	gotToken, err := token.GetTokenFromEncoded(tokString, conf)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	gotToken.IAt = nil
+
+	expToken := &token.Token{
+		SubKind:   token.API,
+		Sub:       string(token.API),
+		ProjectID: 2,
+		IBy:       1,
+		TokenID:   "abcdef",
+		Expiry:    &expiry,
+	}
+
+	if diff := deep.Equal(expToken, gotToken); diff != nil {
+		t.Errorf("tokens not equal:")
+		t.Error(diff)
+	}
+}
+
+func TestExpiredStoredTokenForAPI(t *testing.T) {
+	conf := &token.TokenGeneratorConf{
+		TokenSecret: "fakesecret",
+	}
+
+	expiry := time.Now().Add(-1 * time.Hour)
+
+	tok, err := token.GetStoredTokenForAPI(1, 2, "abcdef", &expiry)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokString, err := tok.EncodeToken(conf)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := token.GetTokenFromEncoded(tokString, conf); err == nil {
+		t.Fatalf("expected error when decoding expired token")
+	}
+}
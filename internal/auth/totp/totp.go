@@ -0,0 +1,156 @@
+// Package totp implements time-based one-time passwords (RFC 6238) which are used
+// as a second authentication factor for basic (email/password) login.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/random"
+)
+
+const (
+	// Period is the number of seconds that a single code is valid for
+	Period = 30
+
+	// Digits is the number of digits in a generated code
+	Digits = 6
+
+	// Skew is the number of periods before and after the current period that
+	// are accepted, to account for clock drift between the server and the device
+	Skew = 1
+
+	secretSize = 20
+
+	recoveryCodeLength  = 10
+	recoveryCodeCharset = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+var b32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random shared secret
+func GenerateSecret() ([]byte, error) {
+	secret := make([]byte, secretSize)
+
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// EncodeSecret returns the base32 representation of the secret, which is the format
+// that authenticator apps expect when a secret is entered manually
+func EncodeSecret(secret []byte) string {
+	return b32NoPadding.EncodeToString(secret)
+}
+
+// ProvisioningURI returns an otpauth:// URI which can be rendered as a QR code and
+// scanned by an authenticator app
+func ProvisioningURI(issuer, account string, secret []byte) string {
+	vals := url.Values{}
+	vals.Set("secret", EncodeSecret(secret))
+	vals.Set("issuer", issuer)
+	vals.Set("algorithm", "SHA1")
+	vals.Set("digits", fmt.Sprintf("%d", Digits))
+	vals.Set("period", fmt.Sprintf("%d", Period))
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", issuer, account))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, vals.Encode())
+}
+
+// GenerateCode returns the code for the period containing t
+func GenerateCode(secret []byte, t time.Time) string {
+	return generateCodeForCounter(secret, uint64(t.Unix())/Period)
+}
+
+// Validate checks a user-supplied code against the secret at time t, accepting codes
+// from up to Skew periods before or after t
+func Validate(code string, secret []byte, t time.Time) bool {
+	_, ok := ValidateAfter(code, secret, t, 0)
+
+	return ok
+}
+
+// ValidateAfter checks a code like Validate, but only accepts codes from periods after
+// lastCounter, so that a code cannot be replayed once it has been accepted. It returns
+// the counter of the period which the code matched, which should be stored as the
+// lastCounter of the next call.
+func ValidateAfter(code string, secret []byte, t time.Time, lastCounter uint64) (uint64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+
+	if len(code) != Digits || len(secret) == 0 {
+		return 0, false
+	}
+
+	counter := uint64(t.Unix()) / Period
+
+	for i := -Skew; i <= Skew; i++ {
+		currCounter := uint64(int64(counter) + int64(i))
+
+		if currCounter <= lastCounter {
+			continue
+		}
+
+		expCode := generateCodeForCounter(secret, currCounter)
+
+		if subtle.ConstantTimeCompare([]byte(expCode), []byte(code)) == 1 {
+			return currCounter, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns n single-use recovery codes of the form xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	res := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		code, err := random.StringWithCharset(recoveryCodeLength, recoveryCodeCharset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, fmt.Sprintf("%s-%s", code[:recoveryCodeLength/2], code[recoveryCodeLength/2:]))
+	}
+
+	return res, nil
+}
+
+// NormalizeRecoveryCode lowercases a recovery code and strips whitespace, so that codes
+// can be compared regardless of how the user typed them
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
+
+func generateCodeForCounter(secret []byte, counter uint64) string {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(buf)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, see RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
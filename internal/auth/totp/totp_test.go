@@ -0,0 +1,130 @@
+package totp_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+// test vectors are from RFC 6238 appendix B, truncated to 6 digits
+var rfcSecret = []byte("12345678901234567890")
+
+var generateCodeTests = []struct {
+	unix int64
+	exp  string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+}
+
+func TestGenerateCode(t *testing.T) {
+	for _, test := range generateCodeTests {
+		if got := totp.GenerateCode(rfcSecret, time.Unix(test.unix, 0)); got != test.exp {
+			t.Errorf("code for time %d incorrect: expected %s, got %s", test.unix, test.exp, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	if !totp.Validate("005924", rfcSecret, now) {
+		t.Errorf("expected current code to be valid")
+	}
+
+	if !totp.Validate("005 924", rfcSecret, now) {
+		t.Errorf("expected code with whitespace to be valid")
+	}
+
+	prev := totp.GenerateCode(rfcSecret, now.Add(-totp.Period*time.Second))
+
+	if !totp.Validate(prev, rfcSecret, now) {
+		t.Errorf("expected code from previous period to be valid")
+	}
+
+	old := totp.GenerateCode(rfcSecret, now.Add(-3*totp.Period*time.Second))
+
+	if totp.Validate(old, rfcSecret, now) {
+		t.Errorf("expected code from 3 periods ago to be invalid")
+	}
+
+	if totp.Validate("", rfcSecret, now) || totp.Validate("00592", rfcSecret, now) {
+		t.Errorf("expected malformed codes to be invalid")
+	}
+
+	if totp.Validate("005924", nil, now) {
+		t.Errorf("expected code to be invalid for empty secret")
+	}
+}
+
+func TestValidateAfter(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	nowCounter := uint64(now.Unix()) / totp.Period
+
+	counter, ok := totp.ValidateAfter("005924", rfcSecret, now, 0)
+
+	if !ok || counter != nowCounter {
+		t.Fatalf("expected current code to be valid with counter %d, got %d", nowCounter, counter)
+	}
+
+	// once a code has been accepted, it cannot be replayed
+	if _, ok := totp.ValidateAfter("005924", rfcSecret, now, counter); ok {
+		t.Errorf("expected replayed code to be invalid")
+	}
+
+	// neither can codes from earlier periods within the skew
+	prev := totp.GenerateCode(rfcSecret, now.Add(-totp.Period*time.Second))
+
+	if _, ok := totp.ValidateAfter(prev, rfcSecret, now, counter); ok {
+		t.Errorf("expected code from before the last accepted code to be invalid")
+	}
+
+	next := totp.GenerateCode(rfcSecret, now.Add(totp.Period*time.Second))
+
+	if _, ok := totp.ValidateAfter(next, rfcSecret, now, counter); !ok {
+		t.Errorf("expected code from after the last accepted code to be valid")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := totp.ProvisioningURI("Porter", "test@test.it", rfcSecret)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Porter:test@test.it?") {
+		t.Errorf("unexpected uri prefix: %s", uri)
+	}
+
+	if !strings.Contains(uri, "secret="+totp.EncodeSecret(rfcSecret)) {
+		t.Errorf("uri does not contain encoded secret: %s", uri)
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := totp.GenerateRecoveryCodes(10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(codes) != 10 {
+		t.Fatalf("expected 10 codes, got %d", len(codes))
+	}
+
+	seen := make(map[string]bool)
+
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("malformed recovery code: %s", code)
+		}
+
+		if seen[code] {
+			t.Errorf("duplicate recovery code: %s", code)
+		}
+
+		seen[code] = true
+	}
+}
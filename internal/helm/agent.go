@@ -35,6 +35,10 @@ func (a *Agent) ListReleases(
 	namespace string,
 	filter *types.ReleaseListFilter,
 ) ([]*release.Release, error) {
+	if sqlDriver, ok := a.ActionConfig.Releases.Driver.(*SQLDriver); ok {
+		return sqlDriver.ListLatestReleases(namespace, filter.StatusFilter)
+	}
+
 	lsel := fmt.Sprintf("owner=helm,status in (%s)", strings.Join(filter.StatusFilter, ","))
 
 	// list secrets
@@ -153,6 +157,15 @@ type UpgradeReleaseConfig struct {
 
 	// Optional, if chart should be overriden
 	Chart *chart.Chart
+
+	// Optional, if set the manifest is applied instead of post-rendering the chart,
+	// which re-applies a stored revision without resolving its overlays and images
+	// again. The manifest is still checked against the deploy policy of the project.
+	Manifest string
+
+	// DryRun renders the upgraded release without applying it or storing a new
+	// revision
+	DryRun bool
 }
 
 // UpgradeRelease upgrades a specific release with new values.yaml
@@ -192,18 +205,29 @@ func (a *Agent) UpgradeReleaseByValues(
 
 	cmd := action.NewUpgrade(a.ActionConfig)
 	cmd.Namespace = rel.Namespace
+	cmd.DryRun = conf.DryRun
 
-	cmd.PostRenderer, err = NewPorterPostrenderer(
-		conf.Cluster,
-		conf.Repo,
-		a.K8sAgent,
-		rel.Namespace,
-		conf.Registries,
-		doAuth,
-	)
+	if conf.Manifest != "" {
+		cmd.PostRenderer, err = NewStoredManifestPostrenderer(conf.Cluster, conf.Repo, conf.Manifest)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		cmd.PostRenderer, err = NewPorterPostrenderer(
+			conf.Cluster,
+			conf.Repo,
+			a.K8sAgent,
+			rel.Namespace,
+			conf.Name,
+			conf.Registries,
+			doAuth,
+			conf.DryRun,
+		)
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	res, err := cmd.Run(conf.Name, ch, conf.Values)
@@ -211,36 +235,20 @@ func (a *Agent) UpgradeReleaseByValues(
 	if err != nil {
 		// refer: https://github.com/helm/helm/blob/release-3.8/pkg/action/action.go#L62
 		// issue tracker: https://github.com/helm/helm/issues/4558
-		if err.Error() == "another operation (install/upgrade/rollback) is in progress" {
-			secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace).List(
-				context.Background(),
-				v1.ListOptions{
-					LabelSelector: fmt.Sprintf("owner=helm,status in (pending-install, pending-upgrade, pending-rollback),name=%s", rel.Name),
-				},
-			)
+		//
+		// a dry run should not modify the pending release, so it just returns the error
+		if !conf.DryRun && err.Error() == "another operation (install/upgrade/rollback) is in progress" {
+			pendingKey, pendingCreatedAt, helmDriver, err := a.getPendingRevision(rel)
 
 			if err != nil {
 				return nil, fmt.Errorf("Upgrade failed: %w", err)
 			}
 
-			if len(secretList.Items) > 0 {
-				mostRecentSecret := secretList.Items[0]
-
-				for i := 1; i < len(secretList.Items); i += 1 {
-					oldVersion, _ := strconv.Atoi(mostRecentSecret.Labels["version"])
-					newVersion, _ := strconv.Atoi(secretList.Items[i].Labels["version"])
-
-					if oldVersion < newVersion {
-						mostRecentSecret = secretList.Items[i]
-					}
-				}
-
-				if time.Since(mostRecentSecret.CreationTimestamp.Time) >= time.Minute {
-					helmSecrets := driver.NewSecrets(a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace))
-
+			if pendingKey != "" {
+				if time.Since(pendingCreatedAt) >= time.Minute {
 					rel.Info.Status = release.StatusFailed
 
-					err = helmSecrets.Update(mostRecentSecret.GetName(), rel)
+					err = helmDriver.Update(pendingKey, rel)
 
 					if err != nil {
 						return nil, fmt.Errorf("Upgrade failed: %w", err)
@@ -267,6 +275,47 @@ func (a *Agent) UpgradeReleaseByValues(
 	return res, nil
 }
 
+// getPendingRevision returns the storage key and creation time of the most recent
+// pending revision of a release, along with the driver which stores it. The key is
+// empty if the release has no pending revisions.
+func (a *Agent) getPendingRevision(rel *release.Release) (string, time.Time, driver.Updator, error) {
+	if sqlDriver, ok := a.ActionConfig.Releases.Driver.(*SQLDriver); ok {
+		pending, err := sqlDriver.getPendingRevision(rel.Namespace, rel.Name)
+
+		if err != nil || pending == nil {
+			return "", time.Time{}, nil, err
+		}
+
+		return pending.Key, pending.CreatedAt, sqlDriver, nil
+	}
+
+	secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace).List(
+		context.Background(),
+		v1.ListOptions{
+			LabelSelector: fmt.Sprintf("owner=helm,status in (pending-install, pending-upgrade, pending-rollback),name=%s", rel.Name),
+		},
+	)
+
+	if err != nil || len(secretList.Items) == 0 {
+		return "", time.Time{}, nil, err
+	}
+
+	mostRecentSecret := secretList.Items[0]
+
+	for i := 1; i < len(secretList.Items); i += 1 {
+		oldVersion, _ := strconv.Atoi(mostRecentSecret.Labels["version"])
+		newVersion, _ := strconv.Atoi(secretList.Items[i].Labels["version"])
+
+		if oldVersion < newVersion {
+			mostRecentSecret = secretList.Items[i]
+		}
+	}
+
+	helmSecrets := driver.NewSecrets(a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace))
+
+	return mostRecentSecret.GetName(), mostRecentSecret.CreationTimestamp.Time, helmSecrets, nil
+}
+
 // InstallChartConfig is the config required to install a chart
 type InstallChartConfig struct {
 	Chart      *chart.Chart
@@ -276,6 +325,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// DryRun renders the release without installing it
+	DryRun bool
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -309,6 +361,7 @@ func (a *Agent) InstallChart(
 	cmd.ReleaseName = conf.Name
 	cmd.Namespace = conf.Namespace
 	cmd.Timeout = 300
+	cmd.DryRun = conf.DryRun
 
 	if err := checkIfInstallable(conf.Chart); err != nil {
 		return nil, err
@@ -321,8 +374,10 @@ func (a *Agent) InstallChart(
 		conf.Repo,
 		a.K8sAgent,
 		conf.Namespace,
+		conf.Name,
 		conf.Registries,
 		doAuth,
+		conf.DryRun,
 	)
 
 	if err != nil {
@@ -1,6 +1,8 @@
 package helm_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"helm.sh/helm/v3/pkg/storage/driver"
@@ -8,6 +10,8 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/release"
@@ -343,3 +347,156 @@ func TestRollbackRelease(t *testing.T) {
 		compareReleaseToStubs(t, []*release.Release{rel}, []releaseStub{tc.expRes})
 	}
 }
+
+func TestUpgradeReleaseDryRun(t *testing.T) {
+	agent := newAgentFixture(t, "default")
+
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: "v2",
+			Name:       "web",
+			Version:    "0.1.0",
+		},
+		Templates: []*chart.File{
+			{
+				Name: "templates/configmap.yaml",
+				Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\ndata:\n  replicas: \"{{ .Values.replicas }}\"\n"),
+			},
+		},
+	}
+
+	err := agent.ActionConfig.Releases.Create(&release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Chart:     ch,
+		Config:    map[string]interface{}{"replicas": 1},
+		Manifest:  "---\n# Source: web/templates/configmap.yaml\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\ndata:\n  replicas: \"1\"\n",
+		Info: &release.Info{
+			Status: release.StatusDeployed,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agent.ActionConfig.Releases.Driver.(*driver.Memory).SetNamespace("default")
+
+	rel, err := agent.UpgradeRelease(&helm.UpgradeReleaseConfig{
+		Name:   "web",
+		DryRun: true,
+	}, "replicas: 2", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(rel.Manifest, "replicas: \"2\"") {
+		t.Errorf("dry run manifest should be rendered with the new values, got %s", rel.Manifest)
+	}
+
+	// a dry run should not store a new revision
+	releases, err := agent.GetReleaseHistory("web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(releases) != 1 {
+		t.Errorf("expected 1 revision after dry run, got %d", len(releases))
+	}
+}
+
+func TestUpgradeReleaseStoredManifest(t *testing.T) {
+	agent := newAgentFixture(t, "default")
+
+	// the stored manifest differs from the rendered chart, as if an overlay had been
+	// applied when the revision was deployed
+	manifest := "---\n# Source: web/templates/configmap.yaml\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n  labels:\n    team: platform\n"
+
+	createStoredManifestRelease(t, agent, manifest)
+
+	rel, err := agent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+		Name:     "web",
+		Manifest: manifest,
+		DryRun:   true,
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(rel.Manifest, "team: platform") {
+		t.Errorf("stored manifest should be applied instead of the rendered chart, got %s", rel.Manifest)
+	}
+}
+
+func TestUpgradeReleaseStoredManifestDeployPolicy(t *testing.T) {
+	agent := newAgentFixture(t, "default")
+
+	manifest := "---\n# Source: web/templates/configmap.yaml\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n  labels:\n    team: platform\n"
+
+	createStoredManifestRelease(t, agent, manifest)
+
+	// the deploy policy was changed after the revision was deployed
+	repo := test.NewRepository(true)
+
+	_, err := repo.DeployPolicy().CreateDeployPolicy(&models.DeployPolicy{
+		ProjectID:      1,
+		RequiredLabels: "owner",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = agent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+		Name:     "web",
+		Cluster:  &models.Cluster{ProjectID: 1},
+		Repo:     repo,
+		Manifest: manifest,
+		DryRun:   true,
+	}, nil)
+
+	policyErr := &helm.DeployPolicyError{}
+
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected the stored manifest to be checked against the deploy policy, got %v", err)
+	}
+}
+
+// createStoredManifestRelease stores a deployed revision of the web release with the
+// given manifest
+func createStoredManifestRelease(t *testing.T, agent *helm.Agent, manifest string) {
+	t.Helper()
+
+	err := agent.ActionConfig.Releases.Create(&release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: "v2",
+				Name:       "web",
+				Version:    "0.1.0",
+			},
+			Templates: []*chart.File{
+				{
+					Name: "templates/configmap.yaml",
+					Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n"),
+				},
+			},
+		},
+		Manifest: manifest,
+		Info: &release.Info{
+			Status: release.StatusDeployed,
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agent.ActionConfig.Releases.Driver.(*driver.Memory).SetNamespace("default")
+}
@@ -23,7 +23,7 @@ type Form struct {
 	Cluster                   *models.Cluster `form:"required"`
 	Repo                      repository.Repository
 	DigitalOceanOAuth         *oauth2.Config
-	Storage                   string `json:"storage" form:"oneof=secret configmap memory" default:"secret"`
+	Storage                   string `json:"storage" form:"oneof=secret configmap memory sql" default:"secret"`
 	Namespace                 string `json:"namespace"`
 	AllowInClusterConnections bool
 }
@@ -46,6 +46,10 @@ func GetAgentOutOfClusterConfig(form *Form, l *logger.Logger) (*Agent, error) {
 		return nil, err
 	}
 
+	if form.Storage == "sql" {
+		return GetAgentFromK8sAgentWithSQLStorage(form.Repo.HelmRelease(), form.Cluster.ID, form.Namespace, l, k8sAgent)
+	}
+
 	return GetAgentFromK8sAgent(form.Storage, form.Namespace, l, k8sAgent)
 }
 
@@ -77,6 +81,30 @@ func GetAgentFromK8sAgent(stg string, ns string, l *logger.Logger, k8sAgent *kub
 	}, nil
 }
 
+// GetAgentFromK8sAgentWithSQLStorage creates a new Agent which stores the releases
+// of a cluster in Porter's database with the SQL driver
+func GetAgentFromK8sAgentWithSQLStorage(
+	repo repository.HelmReleaseRepository,
+	clusterID uint,
+	ns string,
+	l *logger.Logger,
+	k8sAgent *kubernetes.Agent,
+) (*Agent, error) {
+	agent, err := GetAgentFromK8sAgent("memory", ns, l, k8sAgent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewSQLDriver(repo, clusterID, ns)
+	d.Log = l.Printf
+
+	agent.ActionConfig.Releases = storage.Init(d)
+	agent.ActionConfig.Releases.Log = l.Printf
+
+	return agent, nil
+}
+
 // GetAgentInClusterConfig creates a new Agent from inside the cluster using
 // the underlying kubernetes.GetAgentInClusterConfig method
 func GetAgentInClusterConfig(form *Form, l *logger.Logger) (*Agent, error) {
@@ -0,0 +1,248 @@
+package helm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/porter-dev/porter/api/types"
+	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// manifestHead is the part of a manifest that identifies an object
+type manifestHead struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+type manifestObject struct {
+	head    manifestHead
+	content string
+}
+
+// DiffManifests computes the per-object diff between two rendered release manifests.
+// Objects are matched by kind, namespace and name, and the diffs are sorted in the
+// same order.
+func DiffManifests(oldManifest, newManifest string) ([]*types.ManifestDiff, error) {
+	return diffManifests(oldManifest, newManifest, "deployed", "new")
+}
+
+// DiffReleases computes the diff of the user-supplied values, chart version and
+// rendered manifest between two revisions of a release
+func DiffReleases(from, to *release.Release) (*types.GetReleaseDiffResponse, error) {
+	fromLabel := fmt.Sprintf("revision %d", from.Version)
+	toLabel := fmt.Sprintf("revision %d", to.Version)
+
+	manifestDiff, err := diffManifests(from.Manifest, to.Manifest, fromLabel, toLabel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.GetReleaseDiffResponse{
+		FromRevision: from.Version,
+		ToRevision:   to.Version,
+		ValuesDiff:   DiffValues(from.Config, to.Config),
+		ManifestDiff: manifestDiff,
+	}
+
+	if from.Chart != nil && from.Chart.Metadata != nil {
+		res.FromChartVersion = from.Chart.Metadata.Version
+	}
+
+	if to.Chart != nil && to.Chart.Metadata != nil {
+		res.ToChartVersion = to.Chart.Metadata.Version
+	}
+
+	return res, nil
+}
+
+// DiffValues computes the diff between two sets of values. Nested values are compared
+// by their dot-separated path, and only the values which were added, removed or changed
+// are returned, sorted by path.
+func DiffValues(oldValues, newValues map[string]interface{}) []*types.ValuesDiff {
+	oldFlat := make(map[string]interface{})
+	newFlat := make(map[string]interface{})
+
+	flattenValues("", oldValues, oldFlat)
+	flattenValues("", newValues, newFlat)
+
+	paths := make([]string, 0)
+
+	for path := range oldFlat {
+		paths = append(paths, path)
+	}
+
+	for path := range newFlat {
+		if _, exists := oldFlat[path]; !exists {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	res := make([]*types.ValuesDiff, 0)
+
+	for _, path := range paths {
+		oldVal, inOld := oldFlat[path]
+		newVal, inNew := newFlat[path]
+
+		diff := &types.ValuesDiff{
+			Path:     path,
+			OldValue: oldVal,
+			NewValue: newVal,
+		}
+
+		switch {
+		case !inOld:
+			diff.Status = types.ManifestDiffStatusAdded
+		case !inNew:
+			diff.Status = types.ManifestDiffStatusRemoved
+		case reflect.DeepEqual(oldVal, newVal):
+			continue
+		default:
+			diff.Status = types.ManifestDiffStatusChanged
+		}
+
+		res = append(res, diff)
+	}
+
+	return res
+}
+
+// flattenValues writes the leaf values of a values map to res, keyed by their
+// dot-separated path. Lists and empty maps are leaf values.
+func flattenValues(prefix string, values map[string]interface{}, res map[string]interface{}) {
+	for key, val := range values {
+		path := key
+
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := val.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(path, nested, res)
+		} else {
+			res[path] = val
+		}
+	}
+}
+
+func diffManifests(oldManifest, newManifest, fromFile, toFile string) ([]*types.ManifestDiff, error) {
+	oldObjects, err := splitManifestObjects(oldManifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s manifest: %w", fromFile, err)
+	}
+
+	newObjects, err := splitManifestObjects(newManifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s manifest: %w", toFile, err)
+	}
+
+	keys := make([]string, 0)
+
+	for key := range oldObjects {
+		keys = append(keys, key)
+	}
+
+	for key := range newObjects {
+		if _, exists := oldObjects[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	res := make([]*types.ManifestDiff, 0, len(keys))
+
+	for _, key := range keys {
+		oldObj, inOld := oldObjects[key]
+		newObj, inNew := newObjects[key]
+
+		var oldContent, newContent string
+		var head manifestHead
+
+		if inOld {
+			oldContent = oldObj.content
+			head = oldObj.head
+		}
+
+		if inNew {
+			newContent = newObj.content
+			head = newObj.head
+		}
+
+		diff := &types.ManifestDiff{
+			Kind:      head.Kind,
+			Namespace: head.Metadata.Namespace,
+			Name:      head.Metadata.Name,
+		}
+
+		switch {
+		case !inOld:
+			diff.Status = types.ManifestDiffStatusAdded
+		case !inNew:
+			diff.Status = types.ManifestDiffStatusRemoved
+		case oldContent == newContent:
+			diff.Status = types.ManifestDiffStatusUnchanged
+		default:
+			diff.Status = types.ManifestDiffStatusChanged
+		}
+
+		if diff.Status != types.ManifestDiffStatusUnchanged {
+			diff.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(oldContent),
+				B:        difflib.SplitLines(newContent),
+				FromFile: fromFile,
+				ToFile:   toFile,
+				Context:  3,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res = append(res, diff)
+	}
+
+	return res, nil
+}
+
+// splitManifestObjects splits a rendered manifest into its objects, keyed by kind,
+// namespace and name
+func splitManifestObjects(manifest string) (map[string]*manifestObject, error) {
+	res := make(map[string]*manifestObject)
+
+	for _, content := range releaseutil.SplitManifests(manifest) {
+		head := manifestHead{}
+
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil {
+			return nil, err
+		}
+
+		// skip documents which only contain comments, such as templates which rendered
+		// nothing
+		if head.Kind == "" {
+			continue
+		}
+
+		key := strings.Join([]string{head.Kind, head.Metadata.Namespace, head.Metadata.Name}, "/")
+
+		res[key] = &manifestObject{
+			head:    head,
+			content: strings.TrimSpace(content) + "\n",
+		}
+	}
+
+	return res, nil
+}
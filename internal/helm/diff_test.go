@@ -0,0 +1,180 @@
+package helm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const deployedManifest = `---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+spec:
+  ports:
+  - port: 80
+---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  replicas: 1
+---
+# Source: web/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-env
+  namespace: default
+`
+
+const newManifest = `---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+spec:
+  ports:
+  - port: 80
+---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+spec:
+  replicas: 2
+---
+# Source: web/templates/hpa.yaml
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: web
+  namespace: default
+`
+
+func TestDiffManifests(t *testing.T) {
+	assert := assert.New(t)
+
+	diffs, err := helm.DiffManifests(deployedManifest, newManifest)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := make(map[string]types.ManifestDiffStatus)
+
+	for _, diff := range diffs {
+		statuses[diff.Kind] = diff.Status
+
+		if diff.Status == types.ManifestDiffStatusUnchanged {
+			assert.Empty(diff.Diff, "unchanged objects should have no diff")
+		}
+
+		if diff.Kind == "Deployment" {
+			assert.True(strings.Contains(diff.Diff, "-  replicas: 1"), "diff should remove the old replica count")
+			assert.True(strings.Contains(diff.Diff, "+  replicas: 2"), "diff should add the new replica count")
+		}
+	}
+
+	assert.Equal(map[string]types.ManifestDiffStatus{
+		"ConfigMap":               types.ManifestDiffStatusRemoved,
+		"Deployment":              types.ManifestDiffStatusChanged,
+		"HorizontalPodAutoscaler": types.ManifestDiffStatusAdded,
+		"Service":                 types.ManifestDiffStatusUnchanged,
+	}, statuses)
+
+	// diffs should be sorted by kind, namespace and name
+	assert.Equal("ConfigMap", diffs[0].Kind)
+	assert.Equal("Service", diffs[3].Kind)
+}
+
+func TestDiffValues(t *testing.T) {
+	assert := assert.New(t)
+
+	diffs := helm.DiffValues(map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "gcr.io/project/web",
+			"tag":        "abc123",
+		},
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"web.example.com"},
+		},
+		"replicaCount": 1,
+	}, map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "gcr.io/project/web",
+			"tag":        "def456",
+		},
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"web.example.com", "www.example.com"},
+		},
+		"autoscaling": map[string]interface{}{
+			"enabled": true,
+		},
+	})
+
+	assert.Equal([]*types.ValuesDiff{
+		{Path: "autoscaling.enabled", Status: types.ManifestDiffStatusAdded, NewValue: true},
+		{Path: "image.tag", Status: types.ManifestDiffStatusChanged, OldValue: "abc123", NewValue: "def456"},
+		{
+			Path:     "ingress.hosts",
+			Status:   types.ManifestDiffStatusChanged,
+			OldValue: []interface{}{"web.example.com"},
+			NewValue: []interface{}{"web.example.com", "www.example.com"},
+		},
+		{Path: "replicaCount", Status: types.ManifestDiffStatusRemoved, OldValue: 1},
+	}, diffs)
+}
+
+func TestDiffReleases(t *testing.T) {
+	assert := assert.New(t)
+
+	from := &release.Release{
+		Version:  1,
+		Chart:    &chart.Chart{Metadata: &chart.Metadata{Name: "web", Version: "0.1.0"}},
+		Config:   map[string]interface{}{"replicaCount": 1},
+		Manifest: deployedManifest,
+	}
+
+	to := &release.Release{
+		Version:  3,
+		Chart:    &chart.Chart{Metadata: &chart.Metadata{Name: "web", Version: "0.2.0"}},
+		Config:   map[string]interface{}{"replicaCount": 2},
+		Manifest: newManifest,
+	}
+
+	diff, err := helm.DiffReleases(from, to)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(1, diff.FromRevision)
+	assert.Equal(3, diff.ToRevision)
+	assert.Equal("0.1.0", diff.FromChartVersion)
+	assert.Equal("0.2.0", diff.ToChartVersion)
+	assert.Len(diff.ValuesDiff, 1)
+	assert.Len(diff.ManifestDiff, 4)
+
+	for _, manifestDiff := range diff.ManifestDiff {
+		if manifestDiff.Kind == "Deployment" {
+			assert.True(strings.Contains(manifestDiff.Diff, "--- revision 1"), "diff should be labeled with the revisions")
+			assert.True(strings.Contains(manifestDiff.Diff, "+++ revision 3"), "diff should be labeled with the revisions")
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// driftIgnoredFields are the top-level fields of an object which are not compared.
+// Metadata is compared separately, and the stringData of secrets is never returned
+// by the API server.
+var driftIgnoredFields = map[string]bool{
+	"apiVersion": true,
+	"kind":       true,
+	"metadata":   true,
+	"status":     true,
+	"stringData": true,
+}
+
+// DetectDrift compares each object in the manifest of a release against the live
+// object in the cluster. Only the fields which are set in the manifest, along with the
+// labels and annotations of each object, are compared, so fields which are defaulted
+// or managed by the cluster do not cause drift.
+func DetectDrift(
+	rel *release.Release,
+	client dynamic.Interface,
+	mapper meta.RESTMapper,
+) (*types.GetReleaseDriftResponse, error) {
+	objects := grapher.ParseObjs(grapher.ImportMultiDocYAML([]byte(rel.Manifest)), rel.Namespace)
+
+	res := &types.GetReleaseDriftResponse{
+		Revision: rel.Version,
+		Objects:  make([]*types.ObjectDrift, 0, len(objects)),
+	}
+
+	for _, obj := range objects {
+		objDrift, err := detectObjectDrift(obj, client, mapper)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s %s: %w", obj.Kind, obj.Name, err)
+		}
+
+		if objDrift.Status != types.DriftStatusInSync {
+			res.Drifted = true
+		}
+
+		res.Objects = append(res.Objects, objDrift)
+	}
+
+	return res, nil
+}
+
+func detectObjectDrift(
+	obj grapher.Object,
+	client dynamic.Interface,
+	mapper meta.RESTMapper,
+) (*types.ObjectDrift, error) {
+	res := &types.ObjectDrift{
+		Kind:      obj.Kind,
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+		Status:    types.DriftStatusInSync,
+	}
+
+	apiVersion, _ := obj.RawYAML["apiVersion"].(string)
+	gv, err := schema.ParseGroupVersion(apiVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := mapper.RESTMapping(gv.WithKind(obj.Kind).GroupKind(), gv.Version)
+
+	// if the resource type no longer exists in the cluster, neither does the object
+	if meta.IsNoMatchError(err) {
+		res.Status = types.DriftStatusMissing
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var resourceClient dynamic.ResourceInterface = client.Resource(mapping.Resource)
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = client.Resource(mapping.Resource).Namespace(obj.Namespace)
+	} else {
+		res.Namespace = ""
+	}
+
+	live, err := resourceClient.Get(context.Background(), obj.Name, metav1.GetOptions{})
+
+	if errors.IsNotFound(err) {
+		res.Status = types.DriftStatusMissing
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*types.FieldDrift, 0)
+
+	for _, key := range sortedKeys(obj.RawYAML) {
+		if !driftIgnoredFields[key] {
+			diffFields(key, obj.RawYAML[key], live.Object[key], &fields)
+		}
+	}
+
+	expectedMetadata, _ := obj.RawYAML["metadata"].(map[string]interface{})
+	liveMetadata, _ := live.Object["metadata"].(map[string]interface{})
+
+	for _, key := range []string{"labels", "annotations"} {
+		if expected, ok := expectedMetadata[key]; ok {
+			diffFields("metadata."+key, expected, liveMetadata[key], &fields)
+		}
+	}
+
+	if len(fields) > 0 {
+		res.Status = types.DriftStatusDrifted
+		res.Fields = fields
+	}
+
+	return res, nil
+}
+
+// diffFields appends the fields of the expected value which do not match the live
+// value. Maps are compared by the keys of the expected map, and lists are compared
+// element-wise if they have the same length.
+func diffFields(path string, expected, live interface{}, res *[]*types.FieldDrift) {
+	// the API server drops empty fields
+	if live == nil && isEmptyValue(expected) {
+		return
+	}
+
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+
+		if !ok {
+			break
+		}
+
+		for _, key := range sortedKeys(exp) {
+			diffFields(path+"."+key, exp[key], liveMap[key], res)
+		}
+
+		return
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+
+		if !ok || len(liveList) != len(exp) {
+			break
+		}
+
+		for i := range exp {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), exp[i], liveList[i], res)
+		}
+
+		return
+	default:
+		if scalarsEqual(expected, live) {
+			return
+		}
+	}
+
+	*res = append(*res, &types.FieldDrift{
+		Path:     path,
+		Expected: expected,
+		Live:     live,
+	})
+}
+
+// scalarsEqual compares scalar values, treating numbers of different types and
+// equivalent resource quantities (such as "0.5" and "500m") as equal
+func scalarsEqual(expected, live interface{}) bool {
+	expectedNum, expectedIsNum := toFloat64(expected)
+	liveNum, liveIsNum := toFloat64(live)
+
+	if expectedIsNum && liveIsNum {
+		return expectedNum == liveNum
+	}
+
+	expectedStr, expectedIsStr := expected.(string)
+	liveStr, liveIsStr := live.(string)
+
+	if expectedIsStr && liveIsStr && expectedStr != liveStr {
+		expectedQuantity, expectedErr := k8sresource.ParseQuantity(expectedStr)
+		liveQuantity, liveErr := k8sresource.ParseQuantity(liveStr)
+
+		return expectedErr == nil && liveErr == nil && expectedQuantity.Cmp(liveQuantity) == 0
+	}
+
+	return reflect.DeepEqual(expected, live)
+}
+
+func toFloat64(val interface{}) (float64, bool) {
+	switch num := val.(type) {
+	case int:
+		return float64(num), true
+	case int32:
+		return float64(num), true
+	case int64:
+		return float64(num), true
+	case float32:
+		return float64(num), true
+	case float64:
+		return num, true
+	}
+
+	return 0, false
+}
+
+func isEmptyValue(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,205 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const driftManifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: gcr.io/project/web:abc123
+        resources:
+          requests:
+            cpu: "0.5"
+        env: []
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+---
+# Source: web/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-env
+data:
+  PORT: "80"
+---
+# Source: web/templates/role.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: web
+`
+
+func newDriftRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	return mapper
+}
+
+func TestDetectDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	// the deployment was scaled and its image was edited, and the service has fields
+	// which were defaulted by the API server
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"app": "web",
+			},
+			"annotations": map[string]interface{}{
+				"deployment.kubernetes.io/revision": "3",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(5),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":            "web",
+							"image":           "gcr.io/project/web:edited",
+							"imagePullPolicy": "IfNotPresent",
+							"resources": map[string]interface{}{
+								"requests": map[string]interface{}{
+									"cpu": "500m",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"clusterIP": "10.0.0.1",
+			"ports": []interface{}{
+				map[string]interface{}{
+					"port":     int64(80),
+					"protocol": "TCP",
+				},
+			},
+		},
+	}}
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment, service)
+
+	res, err := helm.DetectDrift(&release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   3,
+		Manifest:  driftManifest,
+	}, client, newDriftRESTMapper())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(3, res.Revision)
+	assert.True(res.Drifted)
+
+	if !assert.Len(res.Objects, 4) {
+		return
+	}
+
+	assert.Equal(&types.ObjectDrift{
+		Kind:      "Deployment",
+		Namespace: "default",
+		Name:      "web",
+		Status:    types.DriftStatusDrifted,
+		Fields: []*types.FieldDrift{
+			{Path: "spec.replicas", Expected: 2, Live: int64(5)},
+			{
+				Path:     "spec.template.spec.containers[0].image",
+				Expected: "gcr.io/project/web:abc123",
+				Live:     "gcr.io/project/web:edited",
+			},
+		},
+	}, res.Objects[0])
+
+	assert.Equal(types.DriftStatusInSync, res.Objects[1].Status, "defaulted fields should not cause drift")
+	assert.Empty(res.Objects[1].Fields)
+
+	assert.Equal(types.DriftStatusMissing, res.Objects[2].Status, "deleted objects should be missing")
+
+	// the resource type of the cluster role is not known to the mapper
+	assert.Equal(types.DriftStatusMissing, res.Objects[3].Status)
+}
+
+func TestDetectDriftInSync(t *testing.T) {
+	assert := assert.New(t)
+
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "web-env",
+			"namespace": "default",
+		},
+		"data": map[string]interface{}{
+			"PORT": "80",
+		},
+	}}
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), configMap)
+
+	res, err := helm.DetectDrift(&release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   1,
+		Manifest:  "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web-env\ndata:\n  PORT: \"80\"\n",
+	}, client, newDriftRESTMapper())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(res.Drifted)
+
+	if assert.Len(res.Objects, 1) {
+		assert.Equal(types.DriftStatusInSync, res.Objects[0].Status)
+	}
+}
@@ -80,6 +80,10 @@ type BasicAuthClient struct {
 
 // LoadRepoIndex uses an http request to get the index file and loads it
 func LoadRepoIndex(client *BasicAuthClient, repoURL string) (*repo.IndexFile, error) {
+	if IsOCIRepo(repoURL) {
+		return LoadRepoIndexOCI(client, repoURL)
+	}
+
 	trimmedRepoURL := strings.TrimSuffix(strings.TrimSpace(repoURL), "/")
 	indexURL := trimmedRepoURL + "/index.yaml"
 
@@ -127,18 +131,34 @@ func LoadRepoIndexPublic(repoURL string) (*repo.IndexFile, error) {
 
 // LoadChart uses an http request to fetch a chart from a remote Helm repo
 func LoadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string) (*chart.Chart, error) {
-	repoIndex, err := LoadRepoIndex(client, repoURL)
+	if IsOCIRepo(repoURL) {
+		return LoadChartOCI(client, repoURL, chartName, chartVersion)
+	}
+
+	data, _, err := downloadChart(client, repoURL, chartName, chartVersion)
 
 	if err != nil {
 		return nil, err
 	}
 
+	return chartloader.LoadArchive(bytes.NewReader(data))
+}
+
+// downloadChart downloads a chart archive from a remote Helm repo, and returns the
+// archive along with the URL it was downloaded from
+func downloadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string) ([]byte, string, error) {
+	repoIndex, err := LoadRepoIndex(client, repoURL)
+
+	if err != nil {
+		return nil, "", err
+	}
+
 	cv, err := repoIndex.Get(chartName, chartVersion)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	} else if len(cv.URLs) == 0 {
-		return nil, fmt.Errorf("%s:%s no valid download urls", chartName, chartVersion)
+		return nil, "", fmt.Errorf("%s:%s no valid download urls", chartName, chartVersion)
 	}
 
 	trimmedRepoURL := strings.TrimSuffix(strings.TrimSpace(repoURL), "/")
@@ -152,7 +172,7 @@ func LoadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string)
 	req, err := http.NewRequest("GET", chartURL, nil)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if client.Username != "" {
@@ -162,7 +182,7 @@ func LoadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string)
 	resp, err := http.DefaultClient.Do(req)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	defer resp.Body.Close()
@@ -170,10 +190,10 @@ func LoadChart(client *BasicAuthClient, repoURL, chartName, chartVersion string)
 	data, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return chartloader.LoadArchive(bytes.NewReader(data))
+	return data, chartURL, nil
 }
 
 // LoadChartPublic returns a Helm3 (v2) chart from a remote public repo.
@@ -202,3 +222,30 @@ func isValidURL(testURI string) bool {
 
 	return true
 }
+
+// RepoURLAnnotation is the chart annotation which stores the repo that a chart was
+// loaded from, so that the repo of a release can be recovered from its chart
+const RepoURLAnnotation = "porter.run/repo-url"
+
+// SetChartRepoURL records the repo that a chart was loaded from in its annotations
+func SetChartRepoURL(ch *chart.Chart, repoURL string) {
+	if ch.Metadata == nil || repoURL == "" {
+		return
+	}
+
+	if ch.Metadata.Annotations == nil {
+		ch.Metadata.Annotations = make(map[string]string)
+	}
+
+	ch.Metadata.Annotations[RepoURLAnnotation] = repoURL
+}
+
+// GetChartRepoURL returns the repo that a chart was loaded from, or an empty string
+// if the chart was not loaded by Porter
+func GetChartRepoURL(ch *chart.Chart) string {
+	if ch == nil || ch.Metadata == nil {
+		return ""
+	}
+
+	return ch.Metadata.Annotations[RepoURLAnnotation]
+}
@@ -0,0 +1,324 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	chartloader "helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+	hapichart "k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/repo"
+	"oras.land/oras-go/pkg/registry/remote/auth"
+)
+
+// OCIScheme is the prefix of chart repositories which are stored in an OCI registry
+const OCIScheme = "oci://"
+
+// IsOCIRepo returns true if the repo URL points to an OCI registry, in the form
+// oci://host/path
+func IsOCIRepo(repoURL string) bool {
+	return strings.HasPrefix(strings.TrimSpace(repoURL), OCIScheme)
+}
+
+// GetOCIHost returns the host of an OCI repo URL
+func GetOCIHost(repoURL string) string {
+	host, _ := splitOCIRepoURL(repoURL)
+
+	return host
+}
+
+// LoadChartOCI pulls a chart from an OCI registry. Charts are stored in the
+// repository ${repoURL}/${chartName}, and are tagged with their version. If
+// chartVersion is an empty string or "latest", the latest stable version is pulled.
+func LoadChartOCI(client *BasicAuthClient, repoURL, chartName, chartVersion string) (*chart.Chart, error) {
+	res, err := pullChartOCI(client, repoURL, chartName, chartVersion, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return chartloader.LoadArchive(bytes.NewReader(res.Chart.Data))
+}
+
+// pullChartOCI pulls a chart archive from an OCI registry, along with its provenance
+// file if withProv is set. Charts without a provenance file are still pulled.
+func pullChartOCI(client *BasicAuthClient, repoURL, chartName, chartVersion string, withProv bool) (*registry.PullResult, error) {
+	regClient, cleanup, err := newOCIRegistryClient(client, repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	ref := getOCIChartRef(repoURL, chartName)
+
+	if chartVersion == "" || chartVersion == "latest" {
+		tags, err := regClient.Tags(ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		chartVersion = getLatestStableVersion(tags)
+
+		if chartVersion == "" {
+			return nil, fmt.Errorf("no versions of chart %s found in %s", chartName, repoURL)
+		}
+	}
+
+	// OCI tags do not support "+", so helm stores semver build metadata with "_"
+	return regClient.Pull(
+		fmt.Sprintf("%s:%s", ref, strings.ReplaceAll(chartVersion, "+", "_")),
+		registry.PullOptWithChart(true),
+		registry.PullOptWithProv(withProv),
+		registry.PullOptIgnoreMissingProv(true),
+	)
+}
+
+// LoadRepoIndexOCI builds an index file for the charts in an OCI registry. OCI
+// registries do not have an index, so the repositories under the path of the repo URL
+// are listed through the registry catalog, and the semver tags of each repository are
+// used as the chart versions. Registries which do not support the catalog API cannot
+// be listed, but charts can still be loaded from them by name.
+func LoadRepoIndexOCI(client *BasicAuthClient, repoURL string) (*repo.IndexFile, error) {
+	host, path := splitOCIRepoURL(repoURL)
+
+	chartNames, err := listOCIRepositories(client, host, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	regClient, cleanup, err := newOCIRegistryClient(client, repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	index := repo.NewIndexFile()
+
+	for _, chartName := range chartNames {
+		ref := getOCIChartRef(repoURL, chartName)
+
+		tags, err := regClient.Tags(ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			index.Entries[chartName] = append(index.Entries[chartName], &repo.ChartVersion{
+				Metadata: &hapichart.Metadata{
+					Name:    chartName,
+					Version: tag,
+				},
+				URLs: []string{fmt.Sprintf("%s%s:%s", OCIScheme, ref, strings.ReplaceAll(tag, "+", "_"))},
+			})
+		}
+	}
+
+	index.SortEntries()
+
+	return index, nil
+}
+
+func splitOCIRepoURL(repoURL string) (host, path string) {
+	trimmed := strings.Trim(strings.TrimPrefix(strings.TrimSpace(repoURL), OCIScheme), "/")
+
+	if i := strings.Index(trimmed, "/"); i >= 0 {
+		return trimmed[:i], trimmed[i+1:]
+	}
+
+	return trimmed, ""
+}
+
+func getOCIChartRef(repoURL, chartName string) string {
+	return strings.Trim(strings.TrimPrefix(strings.TrimSpace(repoURL), OCIScheme), "/") + "/" + chartName
+}
+
+// newOCIRegistryClient creates a helm registry client for the host of the repo URL.
+// The helm registry client reads credentials from a docker config file, so the
+// credentials are written to a temporary file which is removed by the returned
+// cleanup function.
+func newOCIRegistryClient(client *BasicAuthClient, repoURL string) (*registry.Client, func(), error) {
+	credsFile, err := ioutil.TempFile("", "porter-oci-*.json")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		os.Remove(credsFile.Name())
+	}
+
+	auths := make(map[string]interface{})
+
+	if client != nil && client.Username != "" {
+		auths[GetOCIHost(repoURL)] = map[string]string{
+			"auth": base64.StdEncoding.EncodeToString([]byte(client.Username + ":" + client.Password)),
+		}
+	}
+
+	err = json.NewEncoder(credsFile).Encode(map[string]interface{}{
+		"auths": auths,
+	})
+
+	credsFile.Close()
+
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	regClient, err := registry.NewClient(registry.ClientOptCredentialsFile(credsFile.Name()))
+
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return regClient, cleanup, nil
+}
+
+// listOCIRepositories lists the names of the repositories under a path in an OCI
+// registry, relative to that path
+func listOCIRepositories(client *BasicAuthClient, host, path string) ([]string, error) {
+	authClient := &auth.Client{
+		Cache: auth.NewCache(),
+		Credential: func(ctx context.Context, reg string) (auth.Credential, error) {
+			if client == nil {
+				return auth.EmptyCredential, nil
+			}
+
+			return auth.Credential{
+				Username: client.Username,
+				Password: client.Password,
+			}, nil
+		},
+	}
+
+	ctx := auth.WithScopes(context.Background(), auth.ScopeRegistryCatalog)
+	prefix := ""
+
+	if path != "" {
+		prefix = path + "/"
+	}
+
+	res := make([]string, 0)
+	catalogURL := fmt.Sprintf("%s://%s/v2/_catalog", getOCIScheme(host), host)
+
+	for catalogURL != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", catalogURL, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := authClient.Do(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := &struct {
+			Repositories []string `json:"repositories"`
+		}{}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+
+			return nil, fmt.Errorf("could not list the charts in registry %s, which may not support listing repositories: %s", host, resp.Status)
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(catalog)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repository := range catalog.Repositories {
+			// only repositories directly under the path are read as charts
+			if name := strings.TrimPrefix(repository, prefix); strings.HasPrefix(repository, prefix) && !strings.Contains(name, "/") {
+				res = append(res, name)
+			}
+		}
+
+		catalogURL, err = getNextPageURL(resp, host)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// getNextPageURL reads the next page of a paginated registry response from the Link
+// header, in the form <url>; rel="next"
+func getNextPageURL(resp *http.Response, host string) (string, error) {
+	link := resp.Header.Get("Link")
+
+	if link == "" {
+		return "", nil
+	}
+
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+
+	if start < 0 || end < start {
+		return "", fmt.Errorf("invalid link header: %s", link)
+	}
+
+	next, err := url.Parse(link[start+1 : end])
+
+	if err != nil {
+		return "", err
+	}
+
+	if next.Host == "" {
+		next.Scheme = getOCIScheme(host)
+		next.Host = host
+	}
+
+	return next.String(), nil
+}
+
+// getOCIScheme returns the scheme used to reach a registry. Like helm, registries on
+// localhost are reached over plain HTTP.
+func getOCIScheme(host string) string {
+	if strings.HasPrefix(host, "localhost:") || host == "localhost" {
+		return "http"
+	}
+
+	return "https"
+}
+
+// getLatestStableVersion returns the first version without a prerelease from a list
+// of versions sorted in descending order, or the first version if all of them are
+// prereleases
+func getLatestStableVersion(versions []string) string {
+	for _, version := range versions {
+		if v, err := semver.NewVersion(version); err == nil && v.Prerelease() == "" {
+			return version
+		}
+	}
+
+	if len(versions) > 0 {
+		return versions[0]
+	}
+
+	return ""
+}
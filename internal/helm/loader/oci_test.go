@@ -0,0 +1,101 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOCIRepo(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(loader.IsOCIRepo("oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts"))
+	assert.True(loader.IsOCIRepo(" oci://ghcr.io/porter-dev"))
+	assert.False(loader.IsOCIRepo("https://charts.getporter.dev"))
+
+	assert.Equal("123456789012.dkr.ecr.us-east-1.amazonaws.com", loader.GetOCIHost("oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts"))
+	assert.Equal("localhost:5000", loader.GetOCIHost("oci://localhost:5000/"))
+}
+
+// newTestRegistry serves the catalog and tag list endpoints of an OCI registry. The
+// catalog is split into pages of a single repository.
+func newTestRegistry(t *testing.T, tags map[string][]string) *httptest.Server {
+	repositories := []string{"charts/web", "charts/redis", "charts/nested/worker", "images/web"}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/_catalog":
+			i := 0
+
+			if last := r.URL.Query().Get("last"); last != "" {
+				for j, repository := range repositories {
+					if repository == last {
+						i = j + 1
+					}
+				}
+			}
+
+			if i+1 < len(repositories) {
+				w.Header().Set("Link", fmt.Sprintf(`</v2/_catalog?last=%s&n=1>; rel="next"`, repositories[i]))
+			}
+
+			json.NewEncoder(w).Encode(map[string][]string{
+				"repositories": repositories[i : i+1],
+			})
+		case strings.HasSuffix(r.URL.Path, "/tags/list"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/"), "/tags/list")
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name": name,
+				"tags": tags[name],
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestLoadRepoIndexOCI(t *testing.T) {
+	assert := assert.New(t)
+
+	server := newTestRegistry(t, map[string][]string{
+		"charts/web":   {"0.1.0", "0.2.0", "latest", "0.3.0-rc.1"},
+		"charts/redis": {"14.6.2"},
+	})
+
+	defer server.Close()
+
+	// registries on localhost are reached over plain HTTP
+	host := strings.Replace(server.Listener.Addr().String(), "127.0.0.1", "localhost", 1)
+
+	index, err := loader.LoadRepoIndex(&loader.BasicAuthClient{}, fmt.Sprintf("oci://%s/charts", host))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// only repositories directly under the repo path are charts, and only semver tags
+	// are chart versions
+	assert.Len(index.Entries, 2)
+
+	if assert.Contains(index.Entries, "web") {
+		versions := make([]string, 0)
+
+		for _, cv := range index.Entries["web"] {
+			versions = append(versions, cv.Version)
+		}
+
+		assert.Equal([]string{"0.3.0-rc.1", "0.2.0", "0.1.0"}, versions)
+		assert.Equal(fmt.Sprintf("oci://%s/charts/web:0.2.0", host), index.Entries["web"][1].URLs[0])
+	}
+
+	if assert.Contains(index.Entries, "redis") {
+		assert.Equal("14.6.2", index.Entries["redis"][0].Version)
+	}
+}
@@ -0,0 +1,165 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/crypto/openpgp" //nolint
+	"helm.sh/helm/v3/pkg/chart"
+	chartloader "helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+// ProvenanceError is returned when a chart is not signed, or when it does not match
+// its provenance file
+type ProvenanceError struct {
+	Chart string
+	Err   error
+}
+
+func (e *ProvenanceError) Error() string {
+	return fmt.Sprintf("chart %s failed provenance verification: %v", e.Chart, e.Err)
+}
+
+func (e *ProvenanceError) Unwrap() error {
+	return e.Err
+}
+
+// ParseKeyring parses a PGP public keyring, either ASCII-armored (the output of
+// "gpg --export --armor") or binary
+func ParseKeyring(keyring []byte) (openpgp.EntityList, error) {
+	res, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyring))
+
+	if err != nil {
+		res, err = openpgp.ReadKeyRing(bytes.NewReader(keyring))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse keyring: %w", err)
+	} else if len(res) == 0 {
+		return nil, fmt.Errorf("keyring does not contain any keys")
+	}
+
+	return res, nil
+}
+
+// LoadVerifiedChart loads a chart like LoadChart, and verifies the chart archive
+// against its provenance (.prov) file with the keyring. Charts which are not signed
+// by a key in the keyring, or which do not match their provenance file, are not
+// loaded and a ProvenanceError is returned.
+func LoadVerifiedChart(
+	client *BasicAuthClient,
+	repoURL, chartName, chartVersion string,
+	keyring []byte,
+) (*chart.Chart, error) {
+	var data, prov []byte
+	var filename string
+
+	if IsOCIRepo(repoURL) {
+		res, err := pullChartOCI(client, repoURL, chartName, chartVersion, true)
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = res.Chart.Data
+		prov = res.Prov.Data
+		filename = fmt.Sprintf("%s-%s.tgz", res.Chart.Meta.Name, res.Chart.Meta.Version)
+	} else {
+		var chartURL string
+		var err error
+
+		data, chartURL, err = downloadChart(client, repoURL, chartName, chartVersion)
+
+		if err != nil {
+			return nil, err
+		}
+
+		prov, err = downloadProvenance(client, chartURL+".prov")
+
+		if err != nil {
+			return nil, err
+		}
+
+		filename = path.Base(chartURL)
+	}
+
+	if len(prov) == 0 {
+		return nil, &ProvenanceError{chartName, fmt.Errorf("chart is not signed")}
+	}
+
+	if _, err := VerifyChart(data, prov, filename, keyring); err != nil {
+		return nil, &ProvenanceError{chartName, err}
+	}
+
+	return chartloader.LoadArchive(bytes.NewReader(data))
+}
+
+// VerifyChart verifies a chart archive against its provenance file with the keyring.
+// The filename is the name of the archive in the provenance file.
+func VerifyChart(data, prov []byte, filename string, keyring []byte) (*provenance.Verification, error) {
+	ring, err := ParseKeyring(keyring)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the helm signatory only verifies files on disk
+	dir, err := ioutil.TempDir("", "porter-prov-")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	chartPath := filepath.Join(dir, filepath.Base(filename))
+	provPath := chartPath + ".prov"
+
+	if err := ioutil.WriteFile(chartPath, data, 0600); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(provPath, prov, 0600); err != nil {
+		return nil, err
+	}
+
+	sig := &provenance.Signatory{KeyRing: ring}
+
+	return sig.Verify(chartPath, provPath)
+}
+
+// downloadProvenance downloads the provenance file of a chart, and returns an empty
+// file if the chart is not signed
+func downloadProvenance(client *BasicAuthClient, provURL string) ([]byte, error) {
+	req, err := http.NewRequest("GET", provURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Username != "" {
+		req.SetBasicAuth(client.Username, client.Password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download provenance file %s: %s", provURL, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
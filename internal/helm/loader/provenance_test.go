@@ -0,0 +1,205 @@
+package loader_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"       //nolint
+	"golang.org/x/crypto/openpgp/armor" //nolint
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+// newTestKey generates a PGP key, and returns the key along with its armored public
+// keyring
+func newTestKey(t *testing.T, name string) (*openpgp.Entity, []byte) {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	w.Close()
+
+	return entity, buf.Bytes()
+}
+
+// packageTestChart packages a chart with the given description, and signs the archive
+// with the key if one is passed
+func packageTestChart(t *testing.T, description string, signer *openpgp.Entity) (data, prov []byte) {
+	dir := t.TempDir()
+
+	path, err := chartutil.Save(&chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion:  chart.APIVersionV2,
+			Name:        "web",
+			Version:     "0.1.0",
+			Description: description,
+		},
+	}, dir)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	data, err = ioutil.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if signer != nil {
+		sig := &provenance.Signatory{Entity: signer, KeyRing: openpgp.EntityList{signer}}
+		signed, err := sig.ClearSign(path)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		prov = []byte(signed)
+	}
+
+	return data, prov
+}
+
+// newTestChartRepo serves an index containing a single chart, along with its archive
+// and provenance file if one is passed
+func newTestChartRepo(t *testing.T, data, prov []byte) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	index := repo.NewIndexFile()
+
+	if err := index.MustAdd(&chart.Metadata{
+		APIVersion: chart.APIVersionV2,
+		Name:       "web",
+		Version:    "0.1.0",
+	}, "web-0.1.0.tgz", server.URL, ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	indexBytes, err := yaml.Marshal(index)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(indexBytes)
+	})
+
+	mux.HandleFunc("/web-0.1.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	})
+
+	mux.HandleFunc("/web-0.1.0.tgz.prov", func(w http.ResponseWriter, r *http.Request) {
+		if prov == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(prov)
+	})
+
+	return server
+}
+
+func TestLoadVerifiedChart(t *testing.T) {
+	assert := assert.New(t)
+
+	signer, keyring := newTestKey(t, "porter")
+	_, otherKeyring := newTestKey(t, "other")
+
+	data, prov := packageTestChart(t, "signed", signer)
+	tamperedData, _ := packageTestChart(t, "tampered", nil)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		prov      []byte
+		keyring   []byte
+		expectErr bool
+	}{
+		{"signed chart", data, prov, keyring, false},
+		{"signed by a key not in the keyring", data, prov, otherKeyring, true},
+		{"tampered chart", tamperedData, prov, keyring, true},
+		{"unsigned chart", data, nil, keyring, true},
+	}
+
+	for _, test := range tests {
+		server := newTestChartRepo(t, test.data, test.prov)
+
+		ch, err := loader.LoadVerifiedChart(&loader.BasicAuthClient{}, server.URL, "web", "0.1.0", test.keyring)
+
+		server.Close()
+
+		if test.expectErr {
+			var provErr *loader.ProvenanceError
+
+			if assert.Error(err, test.name) {
+				assert.True(errors.As(err, &provErr), "%s: expected a provenance error, got %v", test.name, err)
+			}
+
+			continue
+		}
+
+		if assert.NoError(err, test.name) {
+			assert.Equal("signed", ch.Metadata.Description, test.name)
+		}
+	}
+}
+
+func TestVerifyChart(t *testing.T) {
+	assert := assert.New(t)
+
+	signer, keyring := newTestKey(t, "porter")
+	data, prov := packageTestChart(t, "signed", signer)
+
+	verification, err := loader.VerifyChart(data, prov, filepath.Join("charts", "web-0.1.0.tgz"), keyring)
+
+	if assert.NoError(err) {
+		assert.Equal("web-0.1.0.tgz", verification.FileName)
+	}
+
+	_, err = loader.VerifyChart(data, prov, "web-0.2.0.tgz", keyring)
+	assert.Error(err, "archive with another file name should not be verified")
+}
+
+func TestParseKeyring(t *testing.T) {
+	assert := assert.New(t)
+
+	_, keyring := newTestKey(t, "porter")
+
+	ring, err := loader.ParseKeyring(keyring)
+
+	if assert.NoError(err) {
+		assert.Len(ring, 1)
+	}
+
+	_, err = loader.ParseKeyring([]byte("not a keyring"))
+	assert.Error(err)
+
+	_, err = loader.ParseKeyring(nil)
+	assert.Error(err)
+}
@@ -2,18 +2,30 @@ package helm
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
 	"github.com/porter-dev/porter/internal/repository"
 	"golang.org/x/oauth2"
 	"gopkg.in/yaml.v2"
+	"gorm.io/gorm"
 	"helm.sh/helm/v3/pkg/postrender"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	sigsyaml "sigs.k8s.io/yaml"
 
 	"github.com/docker/distribution/reference"
 )
@@ -21,6 +33,9 @@ import (
 type PorterPostrenderer struct {
 	DockerSecretsPostRenderer       *DockerSecretsPostRenderer
 	EnvironmentVariablePostrenderer *EnvironmentVariablePostrenderer
+	OverlayPostrenderer             *OverlayPostrenderer
+	ImageDigestPostrenderer         *ImageDigestPostrenderer
+	DeployPolicyPostrenderer        *DeployPolicyPostrenderer
 }
 
 func NewPorterPostrenderer(
@@ -28,8 +43,10 @@ func NewPorterPostrenderer(
 	repo repository.Repository,
 	agent *kubernetes.Agent,
 	namespace string,
+	releaseName string,
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
+	dryRun bool,
 ) (postrender.PostRenderer, error) {
 	var dockerSecretsPostrenderer *DockerSecretsPostRenderer
 	var err error
@@ -40,6 +57,8 @@ func NewPorterPostrenderer(
 		if err != nil {
 			return nil, err
 		}
+
+		dockerSecretsPostrenderer.DryRun = dryRun
 	}
 
 	envVarPostrenderer, err := NewEnvironmentVariablePostrenderer()
@@ -48,9 +67,56 @@ func NewPorterPostrenderer(
 		return nil, err
 	}
 
+	var overlayPostrenderer *OverlayPostrenderer
+	var imageDigestPostrenderer *ImageDigestPostrenderer
+	var deployPolicyPostrenderer *DeployPolicyPostrenderer
+
+	if cluster != nil && repo != nil {
+		overlays, err := repo.ManifestOverlay().ListManifestOverlays(cluster.ID, namespace, releaseName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(overlays) > 0 {
+			overlayPostrenderer, err = NewOverlayPostrenderer(overlays)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		policy, err := repo.DeployPolicy().ReadDeployPolicy(cluster.ProjectID)
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		if err == nil && policy.PinImageDigests {
+			imageDigestPostrenderer, err = NewImageDigestPostrenderer(
+				newRegistryImageDigestResolver(repo, regs, doAuth),
+			)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err == nil {
+			deployPolicyPostrenderer, err = NewDeployPolicyPostrenderer(policy.ToDeployPolicyType())
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &PorterPostrenderer{
 		DockerSecretsPostRenderer:       dockerSecretsPostrenderer,
 		EnvironmentVariablePostrenderer: envVarPostrenderer,
+		OverlayPostrenderer:             overlayPostrenderer,
+		ImageDigestPostrenderer:         imageDigestPostrenderer,
+		DeployPolicyPostrenderer:        deployPolicyPostrenderer,
 	}, nil
 }
 
@@ -67,6 +133,36 @@ func (p *PorterPostrenderer) Run(
 
 	renderedManifests, err = p.EnvironmentVariablePostrenderer.Run(renderedManifests)
 
+	if err != nil {
+		return nil, err
+	}
+
+	// overlays are applied last, so that they can override the changes of the other
+	// post-renderers
+	if p.OverlayPostrenderer != nil {
+		renderedManifests, err = p.OverlayPostrenderer.Run(renderedManifests)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// images are pinned after the overlays are applied, since overlays can change the
+	// images of containers
+	if p.ImageDigestPostrenderer != nil {
+		renderedManifests, err = p.ImageDigestPostrenderer.Run(renderedManifests)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the deploy policy is checked last, so that the checked manifests are the manifests
+	// which are installed
+	if p.DeployPolicyPostrenderer != nil {
+		renderedManifests, err = p.DeployPolicyPostrenderer.Run(renderedManifests)
+	}
+
 	return renderedManifests, err
 }
 
@@ -84,6 +180,9 @@ type DockerSecretsPostRenderer struct {
 	Namespace string
 	DOAuth    *oauth2.Config
 
+	// DryRun adds the image pull secrets to the pod specs without creating them
+	DryRun bool
+
 	registries map[string]*models.Registry
 
 	podSpecs  []resource
@@ -102,38 +201,13 @@ func NewDockerSecretsPostRenderer(
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
 ) (*DockerSecretsPostRenderer, error) {
-	// Registries is a map of registry URLs to registry ids
-	registries := make(map[string]*models.Registry)
-
-	for _, reg := range regs {
-		regURL := reg.URL
-
-		if !strings.Contains(regURL, "http") {
-			regURL = "https://" + regURL
-		}
-
-		parsedRegURL, err := url.Parse(regURL)
-
-		if err != nil {
-			continue
-		}
-
-		addReg := parsedRegURL.Host
-
-		if parsedRegURL.Path != "" {
-			addReg += "/" + strings.Trim(parsedRegURL.Path, "/")
-		}
-
-		registries[addReg] = reg
-	}
-
 	return &DockerSecretsPostRenderer{
 		Cluster:    cluster,
 		Repo:       repo,
 		Agent:      agent,
 		Namespace:  namespace,
 		DOAuth:     doAuth,
-		registries: registries,
+		registries: getRegistriesByName(regs),
 		podSpecs:   make([]resource, 0),
 		resources:  make([]resource, 0),
 	}, nil
@@ -200,6 +274,7 @@ func (d *DockerSecretsPostRenderer) Run(
 					Agent:      d.Agent,
 					Namespace:  d.Namespace,
 					DOAuth:     d.DOAuth,
+					DryRun:     d.DryRun,
 					registries: d.registries,
 					podSpecs:   make([]resource, 0),
 					resources:  make([]resource, 0),
@@ -219,15 +294,25 @@ func (d *DockerSecretsPostRenderer) Run(
 	}
 
 	// create the necessary secrets
-	secrets, err := d.Agent.CreateImagePullSecrets(
-		d.Repo,
-		d.Namespace,
-		linkedRegs,
-		d.DOAuth,
-	)
+	var secrets map[string]string
 
-	if err != nil {
-		return renderedManifests, nil
+	if d.DryRun {
+		secrets = make(map[string]string)
+
+		for key, reg := range linkedRegs {
+			secrets[key] = kubernetes.GetImagePullSecretName(reg)
+		}
+	} else {
+		secrets, err = d.Agent.CreateImagePullSecrets(
+			d.Repo,
+			d.Namespace,
+			linkedRegs,
+			d.DOAuth,
+		)
+
+		if err != nil {
+			return renderedManifests, nil
+		}
 	}
 
 	d.updatePodSpecs(secrets)
@@ -759,7 +844,592 @@ func (e *EnvironmentVariablePostrenderer) updatePodSpecs() error {
 	return nil
 }
 
+// OverlayPostrenderer applies the user-defined manifest overlays of a release to the
+// rendered manifests. Overlays are applied in order, and each overlay patches every
+// object which matches its kind and, if set, its name.
+type OverlayPostrenderer struct {
+	overlays []*models.ManifestOverlay
+}
+
+func NewOverlayPostrenderer(overlays []*models.ManifestOverlay) (*OverlayPostrenderer, error) {
+	return &OverlayPostrenderer{
+		overlays: overlays,
+	}, nil
+}
+
+func (o *OverlayPostrenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	resources, err := decodeRenderedManifests(renderedManifests)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, res := range resources {
+		kind, _ := res["kind"].(string)
+		name, _ := getNestedResource(res, "metadata")["name"].(string)
+
+		for _, overlay := range o.overlays {
+			if overlay.Kind != kind || (overlay.Name != "" && overlay.Name != name) {
+				continue
+			}
+
+			res, err = applyManifestOverlay(res, overlay)
+
+			// a patch which cannot be applied fails the deploy, since the object would
+			// otherwise be missing the fields which the overlay sets
+			if err != nil {
+				return nil, fmt.Errorf("could not apply %s overlay to %s %s: %w", overlay.PatchType, kind, name, err)
+			}
+		}
+
+		resources[i] = res
+	}
+
+	modifiedManifests = bytes.NewBuffer([]byte{})
+	encoder := yaml.NewEncoder(modifiedManifests)
+	defer encoder.Close()
+
+	for _, resource := range resources {
+		err = encoder.Encode(resource)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
+
+// EncodeManifestOverlayPatch validates the patch of an overlay and returns its JSON.
+// JSON patches must be a list of operations, and strategic merge patches must be an
+// object.
+func EncodeManifestOverlayPatch(overlay *types.ManifestOverlay) ([]byte, error) {
+	patch, err := json.Marshal(overlay.Patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch overlay.PatchType {
+	case types.ManifestOverlayPatchTypeJSON:
+		if _, err := jsonpatch.DecodePatch(patch); err != nil {
+			return nil, fmt.Errorf("json patch must be a list of operations: %w", err)
+		}
+	case types.ManifestOverlayPatchTypeStrategicMerge:
+		if _, ok := overlay.Patch.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("strategic merge patch must be an object")
+		}
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", overlay.PatchType)
+	}
+
+	return patch, nil
+}
+
+// applyManifestOverlay patches a single object. Strategic merge patches of kinds which
+// are not built into Kubernetes, such as custom resources, are applied as JSON merge
+// patches, since their patch strategies are not known.
+func applyManifestOverlay(res resource, overlay *models.ManifestOverlay) (resource, error) {
+	resYAML, err := yaml.Marshal(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resJSON, err := sigsyaml.YAMLToJSON(resYAML)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var patchedJSON []byte
+
+	switch overlay.PatchType {
+	case types.ManifestOverlayPatchTypeJSON:
+		patch, err := jsonpatch.DecodePatch(overlay.Patch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		patchedJSON, err = patch.Apply(resJSON)
+
+		if err != nil {
+			return nil, err
+		}
+	case types.ManifestOverlayPatchTypeStrategicMerge:
+		apiVersion, _ := res["apiVersion"].(string)
+		kind, _ := res["kind"].(string)
+
+		obj, err := scheme.Scheme.New(schema.FromAPIVersionAndKind(apiVersion, kind))
+
+		if runtime.IsNotRegisteredError(err) {
+			patchedJSON, err = jsonpatch.MergePatch(resJSON, overlay.Patch)
+		} else if err == nil {
+			patchedJSON, err = strategicpatch.StrategicMergePatch(resJSON, overlay.Patch, obj)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", overlay.PatchType)
+	}
+
+	patchedYAML, err := sigsyaml.JSONToYAML(patchedJSON)
+
+	if err != nil {
+		return nil, err
+	}
+
+	patched := make(resource)
+
+	if err := yaml.Unmarshal(patchedYAML, &patched); err != nil {
+		return nil, err
+	}
+
+	return patched, nil
+}
+
+// StoredManifestPostrenderer replaces the rendered manifests with the manifest of a
+// stored revision, so that the revision can be re-applied exactly as it was deployed.
+// The stored manifest already contains the overlays, image pull secrets and pinned
+// images of the revision, so they are not applied again. The deploy policy of the
+// project is checked again, since it may have changed after the revision was deployed.
+type StoredManifestPostrenderer struct {
+	manifest string
+
+	DeployPolicyPostrenderer *DeployPolicyPostrenderer
+}
+
+func NewStoredManifestPostrenderer(
+	cluster *models.Cluster,
+	repo repository.Repository,
+	manifest string,
+) (*StoredManifestPostrenderer, error) {
+	res := &StoredManifestPostrenderer{
+		manifest: manifest,
+	}
+
+	if cluster != nil && repo != nil {
+		policy, err := repo.DeployPolicy().ReadDeployPolicy(cluster.ProjectID)
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		if err == nil {
+			res.DeployPolicyPostrenderer, err = NewDeployPolicyPostrenderer(policy.ToDeployPolicyType())
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (s *StoredManifestPostrenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	modifiedManifests = bytes.NewBufferString(s.manifest)
+
+	if s.DeployPolicyPostrenderer != nil {
+		return s.DeployPolicyPostrenderer.Run(modifiedManifests)
+	}
+
+	return modifiedManifests, nil
+}
+
+// DeployPolicyPostrenderer checks the rendered manifests against the deploy policy of a
+// project. It does not modify the manifests: if any object violates the policy, the
+// post-renderer returns a DeployPolicyError which lists every violation.
+type DeployPolicyPostrenderer struct {
+	policy *types.DeployPolicy
+}
+
+// DeployPolicyError is returned when the rendered manifests violate a deploy policy
+type DeployPolicyError struct {
+	Violations []string
+}
+
+func (e *DeployPolicyError) Error() string {
+	return fmt.Sprintf(
+		"release violates the deploy policy of the project:\n  - %s",
+		strings.Join(e.Violations, "\n  - "),
+	)
+}
+
+func NewDeployPolicyPostrenderer(policy *types.DeployPolicy) (*DeployPolicyPostrenderer, error) {
+	return &DeployPolicyPostrenderer{
+		policy: policy,
+	}, nil
+}
+
+func (d *DeployPolicyPostrenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	bufCopy := bytes.NewBuffer(renderedManifests.Bytes())
+
+	resources, err := decodeRenderedManifests(bufCopy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]string, 0)
+
+	for _, res := range resources {
+		violations = append(violations, d.getViolations(res)...)
+	}
+
+	if len(violations) > 0 {
+		return nil, &DeployPolicyError{violations}
+	}
+
+	return renderedManifests, nil
+}
+
+func (d *DeployPolicyPostrenderer) getViolations(res resource) []string {
+	violations := make([]string, 0)
+
+	kind, _ := res["kind"].(string)
+	name, _ := getNestedResource(res, "metadata")["name"].(string)
+
+	// manifests of list type will have an items field, items should
+	// be recursively checked
+	if items, isList := res["items"].([]interface{}); isList {
+		for _, item := range items {
+			if itemRes, ok := item.(resource); ok {
+				violations = append(violations, d.getViolations(itemRes)...)
+			}
+		}
+
+		return violations
+	}
+
+	addViolation := func(format string, a ...interface{}) {
+		violations = append(violations, fmt.Sprintf("%s %s: %s", kind, name, fmt.Sprintf(format, a...)))
+	}
+
+	labels := getNestedResource(res, "metadata", "labels")
+
+	for _, label := range d.policy.RequiredLabels {
+		if _, exists := labels[label]; !exists {
+			addViolation("missing required label %q", label)
+		}
+	}
+
+	podSpec := getPodSpecFromResource(kind, res)
+
+	if podSpec == nil {
+		return violations
+	}
+
+	if d.policy.ForbidHostPath {
+		volumes, _ := podSpec["volumes"].([]interface{})
+
+		for _, volume := range volumes {
+			if _volume, ok := volume.(resource); ok && _volume["hostPath"] != nil {
+				addViolation("volume %q uses a hostPath", _volume["name"])
+			}
+		}
+	}
+
+	for _, container := range getAllContainers(podSpec) {
+		containerName, _ := container["name"].(string)
+
+		if d.policy.ForbidPrivileged {
+			if privileged, _ := getNestedResource(container, "securityContext")["privileged"].(bool); privileged {
+				addViolation("container %q is privileged", containerName)
+			}
+		}
+
+		if d.policy.RequireResourceLimits {
+			limits := getNestedResource(container, "resources", "limits")
+
+			for _, limit := range []string{"cpu", "memory"} {
+				if _, exists := limits[limit]; !exists {
+					addViolation("container %q does not set a %s limit", containerName, limit)
+				}
+			}
+		}
+
+		if image, _ := container["image"].(string); len(d.policy.AllowedRegistries) > 0 && !d.isImageAllowed(image) {
+			addViolation("container %q uses image %q, which is not from an allowed registry", containerName, image)
+		}
+	}
+
+	return violations
+}
+
+// isImageAllowed checks if an image is from one of the allowed registries of the policy.
+// Registries match on the path of the image, so "gcr.io/project" allows the image
+// "gcr.io/project/web:latest" but not "gcr.io/other-project/web:latest".
+func (d *DeployPolicyPostrenderer) isImageAllowed(image string) bool {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return false
+	}
+
+	imageName := named.Name()
+
+	for _, reg := range d.policy.AllowedRegistries {
+		reg = strings.TrimPrefix(strings.TrimPrefix(reg, "https://"), "http://")
+		reg = strings.Trim(reg, "/")
+
+		// images from docker hub are normalized to the docker.io domain
+		if reg == "index.docker.io" || strings.HasPrefix(reg, "index.docker.io/") {
+			reg = strings.Replace(reg, "index.docker.io", "docker.io", 1)
+		}
+
+		if imageName == reg || strings.HasPrefix(imageName, reg+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImageDigestPostrenderer pins the image of every container to the digest which its tag
+// points to, in the form name:tag@digest. The tag is kept, so the manifest of each
+// release revision records both the tag and the digest which was deployed.
+type ImageDigestPostrenderer struct {
+	resolveDigest func(image string) (string, error)
+
+	// digests caches the resolved digest of each image
+	digests map[string]string
+}
+
+func NewImageDigestPostrenderer(
+	resolveDigest func(image string) (string, error),
+) (*ImageDigestPostrenderer, error) {
+	return &ImageDigestPostrenderer{
+		resolveDigest: resolveDigest,
+		digests:       make(map[string]string),
+	}, nil
+}
+
+func (i *ImageDigestPostrenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	resources, err := decodeRenderedManifests(renderedManifests)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, podSpec := range getAllPodSpecs(resources) {
+		for _, container := range getAllContainers(podSpec) {
+			image, ok := container["image"].(string)
+
+			if !ok {
+				continue
+			}
+
+			pinnedImage, err := i.pinImage(image)
+
+			// an image which cannot be pinned fails the deploy, rather than deploying
+			// an image which cannot be audited or rolled back
+			if err != nil {
+				return nil, fmt.Errorf("could not resolve the digest of image %s: %w", image, err)
+			}
+
+			container["image"] = pinnedImage
+		}
+	}
+
+	modifiedManifests = bytes.NewBuffer([]byte{})
+	encoder := yaml.NewEncoder(modifiedManifests)
+	defer encoder.Close()
+
+	for _, resource := range resources {
+		err = encoder.Encode(resource)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
+
+func (i *ImageDigestPostrenderer) pinImage(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return "", err
+	}
+
+	// images which are already pinned to a digest are left as-is
+	if _, ok := named.(reference.Digested); ok {
+		return image, nil
+	}
+
+	digest, ok := i.digests[image]
+
+	if !ok {
+		digest, err = i.resolveDigest(image)
+
+		if err != nil {
+			return "", err
+		}
+
+		i.digests[image] = digest
+	}
+
+	return image + "@" + digest, nil
+}
+
+// newRegistryImageDigestResolver resolves the digests of images through the registries
+// linked to the project. Images from other registries are resolved anonymously, which
+// only succeeds for public images.
+func newRegistryImageDigestResolver(
+	repo repository.Repository,
+	regs []*models.Registry,
+	doAuth *oauth2.Config,
+) func(image string) (string, error) {
+	registries := getRegistriesByName(regs)
+
+	return func(image string) (string, error) {
+		regName, err := getRegNameFromImageRef(image)
+
+		if err != nil {
+			return "", err
+		}
+
+		if reg, ok := registries[regName]; ok {
+			return (*registry.Registry)(reg).GetImageDigest(image, repo, doAuth)
+		}
+
+		return registry.GetPublicImageDigest(image)
+	}
+}
+
+// GetImageDigests reads the images of a release manifest which were pinned to the
+// digest of their tag on deploy
+func GetImageDigests(manifest string) []*types.ImageDigest {
+	res := make([]*types.ImageDigest, 0)
+
+	resources, err := decodeRenderedManifests(bytes.NewBufferString(manifest))
+
+	if err != nil {
+		return res
+	}
+
+	seen := make(map[string]bool)
+
+	for _, podSpec := range getAllPodSpecs(resources) {
+		for _, container := range getAllContainers(podSpec) {
+			image, _ := container["image"].(string)
+
+			named, err := reference.ParseNormalizedNamed(image)
+
+			if err != nil {
+				continue
+			}
+
+			digested, ok := named.(reference.Digested)
+
+			if !ok || seen[image] {
+				continue
+			}
+
+			seen[image] = true
+
+			res = append(res, &types.ImageDigest{
+				Image:  image[:strings.LastIndex(image, "@")],
+				Digest: digested.Digest().String(),
+			})
+		}
+	}
+
+	return res
+}
+
 // HELPERS
+
+// getRegistriesByName returns a map of registry names, in the form returned by
+// getRegNameFromImageRef, to registries
+func getRegistriesByName(regs []*models.Registry) map[string]*models.Registry {
+	registries := make(map[string]*models.Registry)
+
+	for _, reg := range regs {
+		regURL := reg.URL
+
+		if !strings.Contains(regURL, "http") {
+			regURL = "https://" + regURL
+		}
+
+		parsedRegURL, err := url.Parse(regURL)
+
+		if err != nil {
+			continue
+		}
+
+		addReg := parsedRegURL.Host
+
+		if parsedRegURL.Path != "" {
+			addReg += "/" + strings.Trim(parsedRegURL.Path, "/")
+		}
+
+		registries[addReg] = reg
+	}
+
+	return registries
+}
+
+// getAllPodSpecs returns the pod specs of the resources, including the resources of
+// list kinds
+func getAllPodSpecs(resources []resource) []resource {
+	podSpecs := make([]resource, 0)
+
+	for _, res := range resources {
+		if items, isList := res["items"].([]interface{}); isList {
+			itemResources := make([]resource, 0)
+
+			for _, item := range items {
+				if itemRes, ok := item.(resource); ok {
+					itemResources = append(itemResources, itemRes)
+				}
+			}
+
+			podSpecs = append(podSpecs, getAllPodSpecs(itemResources)...)
+
+			continue
+		}
+
+		kind, _ := res["kind"].(string)
+
+		if podSpec := getPodSpecFromResource(kind, res); podSpec != nil {
+			podSpecs = append(podSpecs, podSpec)
+		}
+	}
+
+	return podSpecs
+}
+
+// getAllContainers returns the init containers and containers of a pod spec
+func getAllContainers(podSpec resource) []resource {
+	res := make([]resource, 0)
+
+	for _, key := range []string{"initContainers", "containers"} {
+		containers, _ := podSpec[key].([]interface{})
+
+		for _, container := range containers {
+			if _container, ok := container.(resource); ok {
+				res = append(res, _container)
+			}
+		}
+	}
+
+	return res
+}
+
 func getPodSpecFromResource(kind string, res resource) resource {
 	switch kind {
 	case "Pod":
@@ -0,0 +1,383 @@
+package helm_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const overlayManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        ports:
+        - containerPort: 80
+      tolerations:
+      - key: spot
+        operator: Exists
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 1
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: web
+spec:
+  dnsNames:
+  - web.example.com
+`
+
+func runOverlays(t *testing.T, overlays ...*types.ManifestOverlay) ([]map[string]interface{}, error) {
+	t.Helper()
+
+	stored := make([]*models.ManifestOverlay, 0)
+
+	for _, overlay := range overlays {
+		patch, err := helm.EncodeManifestOverlayPatch(overlay)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stored = append(stored, &models.ManifestOverlay{
+			Kind:      overlay.Kind,
+			Name:      overlay.Name,
+			PatchType: overlay.PatchType,
+			Patch:     patch,
+		})
+	}
+
+	postrenderer, err := helm.NewOverlayPostrenderer(stored)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := postrenderer.Run(bytes.NewBufferString(overlayManifests))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]interface{}, 0)
+	decoder := yaml.NewDecoder(out)
+
+	for {
+		obj := make(map[string]interface{})
+
+		if err := decoder.Decode(&obj); err != nil {
+			break
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+func TestOverlayPostrendererStrategicMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	objs, err := runOverlays(t, &types.ManifestOverlay{
+		Kind:      "Deployment",
+		Name:      "web",
+		PatchType: types.ManifestOverlayPatchTypeStrategicMerge,
+		Patch: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"team": "platform",
+				},
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "web",
+								"resources": map[string]interface{}{
+									"limits": map[string]interface{}{"memory": "256Mi"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, &types.ManifestOverlay{
+		Kind:      "Certificate",
+		PatchType: types.ManifestOverlayPatchTypeStrategicMerge,
+		Patch: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName": "web-tls",
+			},
+		},
+	})
+
+	if !assert.NoError(err) || !assert.Len(objs, 3) {
+		return
+	}
+
+	web := objs[0]
+
+	assert.Equal(map[interface{}]interface{}{"app": "web", "team": "platform"}, web["metadata"].(map[interface{}]interface{})["labels"])
+
+	// containers are merged by name, so the image and ports of the container are kept
+	podSpec := web["spec"].(map[interface{}]interface{})["template"].(map[interface{}]interface{})["spec"].(map[interface{}]interface{})
+	containers := podSpec["containers"].([]interface{})
+
+	if assert.Len(containers, 1) {
+		container := containers[0].(map[interface{}]interface{})
+
+		assert.Equal("nginx", container["image"])
+		assert.NotNil(container["ports"])
+		assert.Equal(map[interface{}]interface{}{"limits": map[interface{}]interface{}{"memory": "256Mi"}}, container["resources"])
+	}
+
+	// overlays targeting a name do not patch other objects of the kind
+	assert.Nil(objs[1]["metadata"].(map[interface{}]interface{})["labels"])
+
+	// custom resources are patched with a JSON merge patch
+	assert.Equal(map[interface{}]interface{}{
+		"dnsNames":   []interface{}{"web.example.com"},
+		"secretName": "web-tls",
+	}, objs[2]["spec"])
+}
+
+func TestOverlayPostrendererJSONPatch(t *testing.T) {
+	assert := assert.New(t)
+
+	objs, err := runOverlays(t, &types.ManifestOverlay{
+		Kind:      "Deployment",
+		PatchType: types.ManifestOverlayPatchTypeJSON,
+		Patch: []interface{}{
+			map[string]interface{}{"op": "replace", "path": "/spec/replicas", "value": 3},
+		},
+	})
+
+	if assert.NoError(err) && assert.Len(objs, 3) {
+		assert.Equal(3, objs[0]["spec"].(map[interface{}]interface{})["replicas"])
+		assert.Equal(3, objs[1]["spec"].(map[interface{}]interface{})["replicas"])
+	}
+
+	// patches which cannot be applied fail the render
+	_, err = runOverlays(t, &types.ManifestOverlay{
+		Kind:      "Deployment",
+		Name:      "worker",
+		PatchType: types.ManifestOverlayPatchTypeJSON,
+		Patch: []interface{}{
+			map[string]interface{}{"op": "remove", "path": "/spec/template/spec/tolerations"},
+		},
+	})
+
+	assert.Error(err)
+}
+
+func TestEncodeManifestOverlayPatch(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := helm.EncodeManifestOverlayPatch(&types.ManifestOverlay{
+		Kind:      "Deployment",
+		PatchType: types.ManifestOverlayPatchTypeJSON,
+		Patch:     map[string]interface{}{"spec": map[string]interface{}{}},
+	})
+
+	assert.Error(err, "json patches must be a list")
+
+	_, err = helm.EncodeManifestOverlayPatch(&types.ManifestOverlay{
+		Kind:      "Deployment",
+		PatchType: types.ManifestOverlayPatchTypeStrategicMerge,
+		Patch:     []interface{}{},
+	})
+
+	assert.Error(err, "strategic merge patches must be an object")
+}
+
+const policyManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    team: platform
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: gcr.io/project/web:abc123
+        resources:
+          limits:
+            cpu: 500m
+            memory: 256Mi
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: setup
+        image: busybox
+        securityContext:
+          privileged: true
+      containers:
+      - name: agent
+        image: gcr.io/other-project/agent:latest
+        resources:
+          limits:
+            cpu: 100m
+      volumes:
+      - name: logs
+        hostPath:
+          path: /var/log
+`
+
+func TestDeployPolicyPostrenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	postrenderer, err := helm.NewDeployPolicyPostrenderer(&types.DeployPolicy{
+		RequireResourceLimits: true,
+		ForbidPrivileged:      true,
+		ForbidHostPath:        true,
+		RequiredLabels:        []string{"team"},
+		AllowedRegistries:     []string{"gcr.io/project", "https://index.docker.io/library/"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = postrenderer.Run(bytes.NewBufferString(policyManifests))
+
+	policyErr := &helm.DeployPolicyError{}
+
+	if !assert.ErrorAs(err, &policyErr) {
+		return
+	}
+
+	assert.Equal([]string{
+		`DaemonSet agent: missing required label "team"`,
+		`DaemonSet agent: volume "logs" uses a hostPath`,
+		`DaemonSet agent: container "setup" is privileged`,
+		`DaemonSet agent: container "setup" does not set a cpu limit`,
+		`DaemonSet agent: container "setup" does not set a memory limit`,
+		`DaemonSet agent: container "agent" does not set a memory limit`,
+		`DaemonSet agent: container "agent" uses image "gcr.io/other-project/agent:latest", which is not from an allowed registry`,
+	}, policyErr.Violations)
+
+	// manifests which follow the policy are not modified
+	manifests := strings.Split(policyManifests, "---\n")[0]
+
+	out, err := postrenderer.Run(bytes.NewBufferString(manifests))
+
+	if assert.NoError(err) {
+		assert.Equal(manifests, out.String())
+	}
+}
+
+const digestManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: migrate
+        image: gcr.io/project/web:abc123
+      containers:
+      - name: web
+        image: gcr.io/project/web:abc123
+      - name: proxy
+        image: nginx
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: batch/v1
+  kind: Job
+  metadata:
+    name: backup
+  spec:
+    template:
+      spec:
+        containers:
+        - name: backup
+          image: busybox@sha256:1111111111111111111111111111111111111111111111111111111111111111
+`
+
+func TestImageDigestPostrenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	digests := map[string]string{
+		"gcr.io/project/web:abc123": "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		"nginx":                     "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+	}
+
+	resolved := make([]string, 0)
+
+	postrenderer, err := helm.NewImageDigestPostrenderer(func(image string) (string, error) {
+		resolved = append(resolved, image)
+
+		if digest, ok := digests[image]; ok {
+			return digest, nil
+		}
+
+		return "", fmt.Errorf("manifest unknown")
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := postrenderer.Run(bytes.NewBufferString(digestManifests))
+
+	if !assert.NoError(err) {
+		return
+	}
+
+	// each image is resolved once, and images which are already pinned are not resolved
+	assert.Equal([]string{"gcr.io/project/web:abc123", "nginx"}, resolved)
+
+	assert.Equal([]*types.ImageDigest{
+		{
+			Image:  "gcr.io/project/web:abc123",
+			Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		},
+		{
+			Image:  "nginx",
+			Digest: "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+		},
+		{
+			Image:  "busybox",
+			Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		},
+	}, helm.GetImageDigests(out.String()))
+
+	// images which cannot be resolved fail the render
+	_, err = postrenderer.Run(bytes.NewBufferString(strings.Replace(digestManifests, "image: nginx", "image: nginx:unknown", 1)))
+
+	assert.Error(err)
+}
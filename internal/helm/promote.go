@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"encoding/json"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// GetPromotedValues computes the values of a release promoted from another cluster
+// or namespace. The overrides are merged over a copy of the source values, and the
+// image tag is set if it is not empty. The source values are not modified.
+func GetPromotedValues(
+	sourceValues map[string]interface{},
+	overrides map[string]interface{},
+	imageTag string,
+) (map[string]interface{}, error) {
+	// the source values are copied through JSON, which is how they are stored by Helm
+	bytes, err := json.Marshal(sourceValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]interface{})
+
+	if err := json.Unmarshal(bytes, &res); err != nil {
+		return nil, err
+	}
+
+	if len(overrides) > 0 {
+		// values in the first table take precedence over values in the second
+		res = chartutil.CoalesceTables(overrides, res)
+	}
+
+	if imageTag != "" {
+		image, ok := res["image"].(map[string]interface{})
+
+		if !ok {
+			image = make(map[string]interface{})
+			res["image"] = image
+		}
+
+		image["tag"] = imageTag
+	}
+
+	return res, nil
+}
+
+// GetSyncedEnvGroups returns the names of the env groups which are synced to a
+// release through container.env.synced
+func GetSyncedEnvGroups(values map[string]interface{}) []string {
+	res := make([]string, 0)
+
+	for _, section := range getSyncedEnvSections(values) {
+		if name, ok := section["name"].(string); ok && name != "" {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// SetSyncedEnvGroupVersions sets the versions of the env groups which are synced to
+// a release. Env groups which are not in the versions map are not changed.
+func SetSyncedEnvGroupVersions(values map[string]interface{}, versions map[string]uint) {
+	for _, section := range getSyncedEnvSections(values) {
+		name, _ := section["name"].(string)
+
+		if version, ok := versions[name]; ok {
+			section["version"] = version
+		}
+	}
+}
+
+func getSyncedEnvSections(values map[string]interface{}) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0)
+
+	container, ok := values["container"].(map[string]interface{})
+
+	if !ok {
+		return res
+	}
+
+	env, ok := container["env"].(map[string]interface{})
+
+	if !ok {
+		return res
+	}
+
+	synced, ok := env["synced"].([]interface{})
+
+	if !ok {
+		return res
+	}
+
+	for _, sectionInter := range synced {
+		if section, ok := sectionInter.(map[string]interface{}); ok {
+			res = append(res, section)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,76 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPromotedValues(t *testing.T) {
+	assert := assert.New(t)
+
+	source := map[string]interface{}{
+		"replicaCount": 1,
+		"image": map[string]interface{}{
+			"repository": "gcr.io/project/web",
+			"tag":        "abc123",
+		},
+		"ingress": map[string]interface{}{
+			"enabled": true,
+			"hosts":   []interface{}{"staging.example.com"},
+		},
+	}
+
+	overrides := map[string]interface{}{
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"example.com"},
+		},
+	}
+
+	values, err := helm.GetPromotedValues(source, overrides, "def456")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(float64(1), values["replicaCount"])
+	assert.Equal(map[string]interface{}{
+		"repository": "gcr.io/project/web",
+		"tag":        "def456",
+	}, values["image"])
+	assert.Equal(map[string]interface{}{
+		"enabled": true,
+		"hosts":   []interface{}{"example.com"},
+	}, values["ingress"])
+
+	// the source values should not be modified
+	assert.Equal("abc123", source["image"].(map[string]interface{})["tag"])
+	assert.Equal([]interface{}{"staging.example.com"}, source["ingress"].(map[string]interface{})["hosts"])
+}
+
+func TestSyncedEnvGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	values := map[string]interface{}{
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"synced": []interface{}{
+					map[string]interface{}{"name": "shared", "version": float64(3)},
+					map[string]interface{}{"name": "database", "version": float64(1)},
+				},
+			},
+		},
+	}
+
+	assert.Equal([]string{"shared", "database"}, helm.GetSyncedEnvGroups(values))
+
+	helm.SetSyncedEnvGroupVersions(values, map[string]uint{"shared": 7})
+
+	synced := values["container"].(map[string]interface{})["env"].(map[string]interface{})["synced"].([]interface{})
+
+	assert.Equal(uint(7), synced[0].(map[string]interface{})["version"])
+	assert.Equal(float64(1), synced[1].(map[string]interface{})["version"])
+
+	assert.Empty(helm.GetSyncedEnvGroups(map[string]interface{}{}))
+}
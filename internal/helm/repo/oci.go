@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// GetOCICredentials returns the credentials for an OCI chart repository in a project.
+// The credentials of a helm repo with the same URL are used if one exists. Otherwise,
+// the credentials of a registry in the project with the same host are used, so that
+// charts can be pulled from the registries which Porter already manages. If neither
+// exists, the chart repository is read anonymously.
+func GetOCICredentials(
+	repo repository.Repository,
+	projectID uint,
+	repoURL string,
+	doAuth *oauth2.Config, // only required if using DOCR
+) (*loader.BasicAuthClient, error) {
+	hrs, err := repo.HelmRepo().ListHelmReposByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedRepoURL := NormalizeURL(repoURL)
+
+	for _, hr := range hrs {
+		if hr.BasicAuthIntegrationID != 0 && NormalizeURL(hr.RepoURL) == trimmedRepoURL {
+			basic, err := repo.BasicIntegration().ReadBasicIntegration(projectID, hr.BasicAuthIntegrationID)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &loader.BasicAuthClient{
+				Username: string(basic.Username),
+				Password: string(basic.Password),
+			}, nil
+		}
+	}
+
+	regs, err := repo.Registry().ListRegistriesByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	host := loader.GetOCIHost(repoURL)
+
+	for _, reg := range regs {
+		if getRegistryHost(reg.URL) != host {
+			continue
+		}
+
+		_reg := registry.Registry(*reg)
+
+		dockerConfigJSON, err := _reg.GetDockerConfigJSON(repo, doAuth)
+
+		if err != nil {
+			return nil, err
+		}
+
+		conf := &configfile.ConfigFile{}
+
+		if err := json.Unmarshal(dockerConfigJSON, conf); err != nil {
+			return nil, err
+		}
+
+		for _, authConfig := range conf.AuthConfigs {
+			if authConfig.Username != "" {
+				return &loader.BasicAuthClient{
+					Username: authConfig.Username,
+					Password: authConfig.Password,
+				}, nil
+			}
+
+			decoded, err := base64.StdEncoding.DecodeString(authConfig.Auth)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if parts := strings.SplitN(string(decoded), ":", 2); len(parts) == 2 {
+				return &loader.BasicAuthClient{
+					Username: parts[0],
+					Password: parts[1],
+				}, nil
+			}
+		}
+	}
+
+	return &loader.BasicAuthClient{}, nil
+}
+
+func getRegistryHost(registryURL string) string {
+	if !strings.Contains(registryURL, "http") {
+		registryURL = "https://" + registryURL
+	}
+
+	parsedURL, err := url.Parse(registryURL)
+
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Host
+}
@@ -0,0 +1,88 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/helm/repo"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOCICredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	testRepo := test.NewRepository(true)
+
+	helmRepoBasic, err := testRepo.BasicIntegration().CreateBasicIntegration(&ints.BasicIntegration{
+		ProjectID: 1,
+		Username:  []byte("harbor-user"),
+		Password:  []byte("harbor-password"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registryBasic, err := testRepo.BasicIntegration().CreateBasicIntegration(&ints.BasicIntegration{
+		ProjectID: 1,
+		Username:  []byte("registry-user"),
+		Password:  []byte("registry-password"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testRepo.HelmRepo().CreateHelmRepo(&models.HelmRepo{
+		ProjectID:              1,
+		Name:                   "harbor",
+		RepoURL:                "oci://harbor.example.com/charts/",
+		BasicAuthIntegrationID: helmRepoBasic.ID,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testRepo.Registry().CreateRegistry(&models.Registry{
+		ProjectID:          1,
+		Name:               "private",
+		URL:                "registry.example.com/porter",
+		BasicIntegrationID: registryBasic.ID,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the credentials of a helm repo with the same url are used first
+	client, err := repo.GetOCICredentials(testRepo, 1, "oci://harbor.example.com/charts", nil)
+
+	if assert.NoError(err) {
+		assert.Equal(&loader.BasicAuthClient{Username: "harbor-user", Password: "harbor-password"}, client)
+	}
+
+	// otherwise, the credentials of a registry with the same host are used
+	client, err = repo.GetOCICredentials(testRepo, 1, "oci://registry.example.com/charts", nil)
+
+	if assert.NoError(err) {
+		assert.Equal(&loader.BasicAuthClient{Username: "registry-user", Password: "registry-password"}, client)
+	}
+
+	// other registries are read anonymously
+	client, err = repo.GetOCICredentials(testRepo, 1, "oci://ghcr.io/porter-dev", nil)
+
+	if assert.NoError(err) {
+		assert.Equal(&loader.BasicAuthClient{}, client)
+	}
+
+	// helm repos and registries in other projects are not used
+	client, err = repo.GetOCICredentials(testRepo, 2, "oci://registry.example.com/charts", nil)
+
+	if assert.NoError(err) {
+		assert.Equal(&loader.BasicAuthClient{}, client)
+	}
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NormalizeURL trims whitespace and trailing slashes from a chart repo URL, so that
+// URLs which point to the same chart repo can be compared
+func NormalizeURL(repoURL string) string {
+	return strings.TrimRight(strings.TrimSpace(repoURL), "/")
+}
+
+// MatchHelmRepo returns the helm repo which serves the chart repo URL, or nil if no
+// helm repo matches. A helm repo matches if it has the same host as the chart repo URL
+// and its path is a prefix of the chart repo URL's path. If several helm repos match,
+// the one with the longest path is returned.
+func MatchHelmRepo(hrs []*models.HelmRepo, repoURL string) *models.HelmRepo {
+	host, repoPath := splitRepoURL(repoURL)
+
+	if host == "" {
+		return nil
+	}
+
+	var res *models.HelmRepo
+	var resPath string
+
+	for _, hr := range hrs {
+		hrHost, hrPath := splitRepoURL(hr.RepoURL)
+
+		if hrHost != host || !isPathPrefix(hrPath, repoPath) {
+			continue
+		}
+
+		if res == nil || len(hrPath) > len(resPath) {
+			res = hr
+			resPath = hrPath
+		}
+	}
+
+	return res
+}
+
+// HostRequiresProvenance returns true if a helm repo with the same host as the chart
+// repo URL requires provenance verification
+func HostRequiresProvenance(hrs []*models.HelmRepo, repoURL string) bool {
+	host, _ := splitRepoURL(repoURL)
+
+	if host == "" {
+		return false
+	}
+
+	for _, hr := range hrs {
+		if hrHost, _ := splitRepoURL(hr.RepoURL); hr.VerifyProvenance && hrHost == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitRepoURL returns the lowercased host and the cleaned path of a chart repo URL
+func splitRepoURL(repoURL string) (string, string) {
+	repoURL = NormalizeURL(repoURL)
+
+	if !strings.Contains(repoURL, "://") {
+		repoURL = "https://" + repoURL
+	}
+
+	parsedURL, err := url.Parse(repoURL)
+
+	if err != nil {
+		return "", ""
+	}
+
+	return strings.ToLower(parsedURL.Host), strings.TrimRight(path.Clean("/"+parsedURL.Path), "/")
+}
+
+func isPathPrefix(prefix, p string) bool {
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
@@ -0,0 +1,325 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"golang.org/x/oauth2"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// RolloutConfig configures a canary or blue-green rollout
+type RolloutConfig struct {
+	Strategy types.RolloutStrategy
+
+	// Steps are the percentages of traffic sent to the canary, in order. A blue-green
+	// rollout has a single step which sends all traffic to the canary.
+	Steps []uint
+
+	// StepInterval is how long each step runs before the canary is checked
+	StepInterval time.Duration
+
+	// MaxErrorRate is the highest percentage of 5xx responses from the canary for
+	// which the rollout continues
+	MaxErrorRate float64
+
+	// HealthDeadline is how long the canary pods have to become ready at each step
+	HealthDeadline time.Duration
+}
+
+// RolloutObserver is called with the progress of a rollout
+type RolloutObserver func(status types.EventStatus, info string)
+
+// PrepareRollout renders an upgrade without applying it, and creates a canary for
+// the rendered revision. The release must render a single deployment, with a service
+// which is exposed through an NGINX ingress.
+func (a *Agent) PrepareRollout(
+	conf *UpgradeReleaseConfig,
+	doAuth *oauth2.Config,
+) (*kubernetes.Canary, error) {
+	dryRunConf := *conf
+	dryRunConf.DryRun = true
+
+	rel, err := a.UpgradeReleaseByValues(&dryRunConf, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := getCanaryForRelease(rel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.K8sAgent.CreateCanary(canary); err != nil {
+		return nil, err
+	}
+
+	return canary, nil
+}
+
+// RunRollout shifts traffic to the canary in steps. Between steps, it checks that
+// the canary pods are healthy and that the error rate of the canary is below the
+// maximum. If every step passes, the release is upgraded and the canary is removed.
+// Otherwise, the rollout is aborted and the canary is removed without upgrading the
+// release. If the upgraded stable pods do not become healthy, the release is rolled
+// back before the canary is removed.
+func (a *Agent) RunRollout(
+	canary *kubernetes.Canary,
+	conf *UpgradeReleaseConfig,
+	doAuth *oauth2.Config,
+	rolloutConf *RolloutConfig,
+	observe RolloutObserver,
+) (*release.Release, error) {
+	stableName := canary.StableDeploymentName()
+	stableReplicas := int32(1)
+
+	stable, err := a.K8sAgent.Clientset.AppsV1().Deployments(canary.Namespace).Get(
+		context.TODO(),
+		stableName,
+		v1.GetOptions{},
+	)
+
+	if err == nil && stable.Spec.Replicas != nil && *stable.Spec.Replicas > 0 {
+		stableReplicas = *stable.Spec.Replicas
+	}
+
+	steps := rolloutConf.Steps
+
+	if rolloutConf.Strategy == types.RolloutStrategyBlueGreen {
+		steps = []uint{100}
+	}
+
+	canaryControllers := []grapher.Object{{Kind: "Deployment", Name: canary.Deployment.Name}}
+
+	for _, weight := range steps {
+		replicas := stableReplicas
+
+		// canary replicas are scaled with the traffic they receive
+		if rolloutConf.Strategy == types.RolloutStrategyCanary {
+			replicas = int32(math.Ceil(float64(stableReplicas) * float64(weight) / 100))
+		}
+
+		if err := a.K8sAgent.ScaleCanary(canary, replicas); err != nil {
+			return nil, a.abortRollout(canary, observe, err)
+		}
+
+		if err := a.K8sAgent.WaitForControllersHealthy(canary.Namespace, canaryControllers, rolloutConf.HealthDeadline); err != nil {
+			return nil, a.abortRollout(canary, observe, err)
+		}
+
+		if err := a.K8sAgent.SetCanaryWeight(canary, weight); err != nil {
+			return nil, a.abortRollout(canary, observe, err)
+		}
+
+		observe(types.EventStatusInProgress, fmt.Sprintf("sending %d%% of traffic to the new revision", weight))
+
+		time.Sleep(rolloutConf.StepInterval)
+
+		if err := a.checkCanary(canary, canaryControllers, rolloutConf, observe); err != nil {
+			return nil, a.abortRollout(canary, observe, err)
+		}
+	}
+
+	observe(types.EventStatusInProgress, "promoting the new revision")
+
+	prevRel, err := a.GetRelease(conf.Name, 0, false)
+
+	if err != nil {
+		return nil, a.abortRollout(canary, observe, err)
+	}
+
+	rel, err := a.UpgradeReleaseByValues(conf, doAuth)
+
+	if err != nil {
+		return nil, a.abortRollout(canary, observe, err)
+	}
+
+	// the canary keeps serving traffic until the stable pods have been updated. If the
+	// stable pods do not become healthy, the release is rolled back to the revision
+	// before the rollout, and the canary is only removed once the rollback succeeds.
+	stableControllers := []grapher.Object{{Kind: "Deployment", Name: stableName}}
+
+	if healthErr := a.K8sAgent.WaitForControllersHealthy(canary.Namespace, stableControllers, rolloutConf.HealthDeadline); healthErr != nil {
+		if err := a.RollbackRelease(conf.Name, prevRel.Version); err != nil {
+			err = fmt.Errorf(
+				"revision %d is not healthy and could not be rolled back to revision %d, so the canary is still serving traffic: %s: %w",
+				rel.Version, prevRel.Version, healthErr.Error(), err,
+			)
+
+			observe(types.EventStatusFailed, err.Error())
+
+			return nil, err
+		}
+
+		return nil, a.abortRollout(canary, observe, fmt.Errorf(
+			"revision %d was rolled back to revision %d: %w",
+			rel.Version, prevRel.Version, healthErr,
+		))
+	}
+
+	if err := a.K8sAgent.SetCanaryWeight(canary, 0); err != nil {
+		return nil, err
+	}
+
+	if err := a.K8sAgent.DeleteCanary(canary); err != nil {
+		return nil, err
+	}
+
+	observe(types.EventStatusSuccess, fmt.Sprintf("revision %d was promoted", rel.Version))
+
+	return rel, nil
+}
+
+// checkCanary checks that the canary pods are healthy and that the error rate of
+// the canary is below the maximum
+func (a *Agent) checkCanary(
+	canary *kubernetes.Canary,
+	canaryControllers []grapher.Object,
+	rolloutConf *RolloutConfig,
+	observe RolloutObserver,
+) error {
+	if err := a.K8sAgent.WaitForControllersHealthy(canary.Namespace, canaryControllers, rolloutConf.HealthDeadline); err != nil {
+		return err
+	}
+
+	promSvc, found, err := prometheus.GetPrometheusService(a.K8sAgent.Clientset)
+
+	if err != nil || !found {
+		observe(types.EventStatusInProgress, "prometheus was not found, so the error rate of the new revision was not checked")
+		return nil
+	}
+
+	errorRate, err := prometheus.QueryNGINXErrorRate(
+		a.K8sAgent.Clientset,
+		promSvc,
+		canary.Namespace,
+		canary.IngressName(),
+		rolloutConf.StepInterval,
+	)
+
+	if err != nil {
+		observe(types.EventStatusInProgress, fmt.Sprintf("could not query the error rate of the new revision: %s", err.Error()))
+		return nil
+	}
+
+	if errorRate > rolloutConf.MaxErrorRate {
+		return fmt.Errorf("error rate of %.2f%% is above the maximum of %.2f%%", errorRate, rolloutConf.MaxErrorRate)
+	}
+
+	return nil
+}
+
+// abortRollout removes the canary and reports the reason that the rollout was aborted
+func (a *Agent) abortRollout(canary *kubernetes.Canary, observe RolloutObserver, reason error) error {
+	err := fmt.Errorf("rollout aborted: %w", reason)
+
+	if deleteErr := a.K8sAgent.DeleteCanary(canary); deleteErr != nil {
+		err = fmt.Errorf("%s, and the canary could not be removed: %s", err.Error(), deleteErr.Error())
+	}
+
+	observe(types.EventStatusFailed, err.Error())
+
+	return err
+}
+
+// getCanaryForRelease finds the deployment of a rendered release, and the service
+// and ingress which expose it
+func getCanaryForRelease(rel *release.Release) (*kubernetes.Canary, error) {
+	deployments := make([]*appsv1.Deployment, 0)
+	services := make([]*corev1.Service, 0)
+	ingresses := make([]runtime.Object, 0)
+
+	for _, content := range releaseutil.SplitManifests(rel.Manifest) {
+		obj, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(content), nil, nil)
+
+		// objects of kinds which are not registered in the scheme are ignored
+		if err != nil {
+			continue
+		}
+
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			deployments = append(deployments, o)
+		case *corev1.Service:
+			services = append(services, o)
+		case *networkingv1.Ingress, *networkingv1beta1.Ingress:
+			ingresses = append(ingresses, o)
+		}
+	}
+
+	if len(deployments) != 1 {
+		return nil, fmt.Errorf("canary and blue-green rollouts require a release with exactly one deployment, found %d", len(deployments))
+	}
+
+	deployment := deployments[0]
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+
+	var service *corev1.Service
+
+	for _, svc := range services {
+		if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			service = svc
+			break
+		}
+	}
+
+	if service == nil {
+		return nil, fmt.Errorf("canary and blue-green rollouts require a service for deployment %s", deployment.Name)
+	}
+
+	for _, ingress := range ingresses {
+		if ingressHasBackend(ingress, service.Name) {
+			return kubernetes.NewCanary(rel.Namespace, deployment, service, ingress)
+		}
+	}
+
+	return nil, fmt.Errorf("canary and blue-green rollouts require an NGINX ingress for service %s", service.Name)
+}
+
+func ingressHasBackend(ingress runtime.Object, serviceName string) bool {
+	switch ing := ingress.(type) {
+	case *networkingv1.Ingress:
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil && path.Backend.Service.Name == serviceName {
+					return true
+				}
+			}
+		}
+	case *networkingv1beta1.Ingress:
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.ServiceName == serviceName {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
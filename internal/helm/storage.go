@@ -9,9 +9,9 @@ package helm
 // - postgres
 //
 // This file implements first-class support for the first three driver types
-// and integrates with the logger.
-//
-// TODO -- include support for SQL storage...
+// and integrates with the logger. Instead of Helm's postgres driver, releases can be
+// stored in Porter's own database with the SQL driver in storage_sql.go, which is
+// selected per cluster.
 
 import (
 	"github.com/porter-dev/porter/internal/logger"
@@ -0,0 +1,608 @@
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SQLDriverName is the name of the SQL storage driver
+const SQLDriverName = "SQL"
+
+// sqlStreamInterval is how often the releases of a cluster are polled when
+// streaming releases from the SQL driver
+const sqlStreamInterval = 2 * time.Second
+
+// SQLDriver is a Helm storage driver which stores the releases of a cluster in
+// Porter's database, instead of in secrets in the cluster
+type SQLDriver struct {
+	repo      repository.HelmReleaseRepository
+	clusterID uint
+	namespace string
+
+	Log func(string, ...interface{})
+}
+
+// NewSQLDriver returns a SQL storage driver for the releases of a cluster. An
+// empty namespace reads releases in all namespaces.
+func NewSQLDriver(repo repository.HelmReleaseRepository, clusterID uint, namespace string) *SQLDriver {
+	return &SQLDriver{
+		repo:      repo,
+		clusterID: clusterID,
+		namespace: namespace,
+		Log:       func(_ string, _ ...interface{}) {},
+	}
+}
+
+// NewSQLStorageDriver returns a storage using the SQL driver.
+func NewSQLStorageDriver(
+	repo repository.HelmReleaseRepository,
+	clusterID uint,
+	namespace string,
+) *storage.Storage {
+	return storage.Init(NewSQLDriver(repo, clusterID, namespace))
+}
+
+// Name returns the name of the driver
+func (d *SQLDriver) Name() string {
+	return SQLDriverName
+}
+
+// Get returns the release with the given key
+func (d *SQLDriver) Get(key string) (*release.Release, error) {
+	model, err := d.repo.ReadHelmRelease(d.clusterID, d.namespace, key)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, driver.ErrReleaseNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get: failed to get %q: %w", key, err)
+	}
+
+	return decodeSQLRelease(model.Body)
+}
+
+// List returns the releases which match the filter
+func (d *SQLDriver) List(filter func(*release.Release) bool) ([]*release.Release, error) {
+	revisions, err := d.repo.ListHelmReleases(d.clusterID, d.namespace, &repository.HelmReleaseFilter{
+		Owner: "helm",
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("list: failed to list: %w", err)
+	}
+
+	res := make([]*release.Release, 0)
+
+	for _, model := range revisions {
+		rel, err := decodeSQLRelease(model.Body)
+
+		if err != nil {
+			d.Log("list: failed to decode release: %v: %s", model.Key, err)
+			continue
+		}
+
+		if filter(rel) {
+			res = append(res, rel)
+		}
+	}
+
+	return res, nil
+}
+
+// Query returns the releases which match all of the labels. The labels which
+// Helm queries by are name, owner, status and version.
+func (d *SQLDriver) Query(labels map[string]string) ([]*release.Release, error) {
+	filter := &repository.HelmReleaseFilter{}
+
+	for label, val := range labels {
+		switch label {
+		case "name":
+			filter.Name = val
+		case "owner":
+			filter.Owner = val
+		case "status":
+			filter.Statuses = []string{val}
+		case "version":
+			version, err := strconv.Atoi(val)
+
+			if err != nil {
+				return nil, fmt.Errorf("query: invalid version %q: %w", val, err)
+			}
+
+			filter.Version = version
+		default:
+			return nil, fmt.Errorf("query: unsupported label %q", label)
+		}
+	}
+
+	revisions, err := d.repo.ListHelmReleases(d.clusterID, d.namespace, filter)
+
+	if err != nil {
+		return nil, fmt.Errorf("query: failed to query with labels: %w", err)
+	}
+
+	if len(revisions) == 0 {
+		return nil, driver.ErrReleaseNotFound
+	}
+
+	res := make([]*release.Release, 0, len(revisions))
+
+	for _, model := range revisions {
+		rel, err := decodeSQLRelease(model.Body)
+
+		if err != nil {
+			d.Log("query: failed to decode release: %s", err)
+			continue
+		}
+
+		res = append(res, rel)
+	}
+
+	return res, nil
+}
+
+// Create stores a new release, or returns ErrReleaseExists if a release with the
+// same key already exists in its namespace
+func (d *SQLDriver) Create(key string, rls *release.Release) error {
+	model := &models.HelmRelease{
+		ClusterID: d.clusterID,
+		Namespace: d.releaseNamespace(rls),
+		Key:       key,
+	}
+
+	if err := setSQLRelease(model, rls); err != nil {
+		return fmt.Errorf("create: failed to encode release %q: %w", rls.Name, err)
+	}
+
+	// the unique index on the key rejects concurrent creates of the same revision,
+	// so the existence of the key is not checked before creating it
+	if _, err := d.repo.CreateHelmRelease(model); errors.Is(err, repository.ErrHelmReleaseExists) {
+		return driver.ErrReleaseExists
+	} else if err != nil {
+		return fmt.Errorf("create: failed to create: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing release, or returns ErrReleaseNotFound if there is
+// no release with the key in its namespace
+func (d *SQLDriver) Update(key string, rls *release.Release) error {
+	model, err := d.repo.ReadHelmRelease(d.clusterID, d.releaseNamespace(rls), key)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return driver.ErrReleaseNotFound
+	} else if err != nil {
+		return fmt.Errorf("update: failed to get %q: %w", key, err)
+	}
+
+	if err := setSQLRelease(model, rls); err != nil {
+		return fmt.Errorf("update: failed to encode release %q: %w", rls.Name, err)
+	}
+
+	if _, err := d.repo.UpdateHelmRelease(model); err != nil {
+		return fmt.Errorf("update: failed to update: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the release with the given key and returns it. A driver which
+// reads all namespaces only deletes a key which is stored in a single namespace.
+func (d *SQLDriver) Delete(key string) (*release.Release, error) {
+	namespace := d.namespace
+
+	if namespace == "" {
+		revisions, err := d.repo.ListHelmReleaseMetadata(d.clusterID, "", &repository.HelmReleaseFilter{
+			Key: key,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("delete: failed to get %q: %w", key, err)
+		} else if len(revisions) == 0 {
+			return nil, driver.ErrReleaseNotFound
+		} else if len(revisions) > 1 {
+			return nil, fmt.Errorf("delete: %q is stored in %d namespaces", key, len(revisions))
+		}
+
+		namespace = revisions[0].Namespace
+	}
+
+	model, err := d.repo.ReadHelmRelease(d.clusterID, namespace, key)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, driver.ErrReleaseNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("delete: failed to get %q: %w", key, err)
+	}
+
+	rel, err := decodeSQLRelease(model.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.repo.DeleteHelmRelease(model); err != nil {
+		return nil, fmt.Errorf("delete: failed to delete: %w", err)
+	}
+
+	return rel, nil
+}
+
+// releaseNamespace returns the namespace a release is stored in, which is the
+// namespace of the release itself rather than the namespace the driver reads
+func (d *SQLDriver) releaseNamespace(rls *release.Release) string {
+	if rls.Namespace != "" {
+		return rls.Namespace
+	}
+
+	return d.namespace
+}
+
+// ListLatestReleases returns the latest revision of each release in a namespace
+// with one of the given statuses. Only the bodies of the latest revisions are read
+// and decoded.
+func (d *SQLDriver) ListLatestReleases(namespace string, statuses []string) ([]*release.Release, error) {
+	revisions, err := d.repo.ListHelmReleaseMetadata(d.clusterID, namespace, &repository.HelmReleaseFilter{
+		Owner:    "helm",
+		Statuses: statuses,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	latest := latestSQLRevisions(revisions)
+	res := make([]*release.Release, 0, len(latest))
+
+	for _, model := range latest {
+		rel, err := d.readRevision(model)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		} else if err != nil {
+			d.Log("list: failed to read release: %v: %s", model.Key, err)
+			continue
+		}
+
+		res = append(res, rel)
+	}
+
+	return res, nil
+}
+
+// readRevision reads and decodes the body of a revision which was listed without
+// its body
+func (d *SQLDriver) readRevision(meta *models.HelmRelease) (*release.Release, error) {
+	model, err := d.repo.ReadHelmRelease(d.clusterID, meta.Namespace, meta.Key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSQLRelease(model.Body)
+}
+
+// getPendingRevision returns the latest pending revision of a release, or nil if
+// the release has no pending revisions
+func (d *SQLDriver) getPendingRevision(namespace, name string) (*models.HelmRelease, error) {
+	revisions, err := d.repo.ListHelmReleaseMetadata(d.clusterID, namespace, &repository.HelmReleaseFilter{
+		Name:  name,
+		Owner: "helm",
+		Statuses: []string{
+			release.StatusPendingInstall.String(),
+			release.StatusPendingUpgrade.String(),
+			release.StatusPendingRollback.String(),
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if latest := latestSQLRevisions(revisions); len(latest) > 0 {
+		return latest[0], nil
+	}
+
+	return nil, nil
+}
+
+// StreamReleases polls the releases of the cluster and writes an ADD, UPDATE or
+// DELETE message to the websocket whenever a revision is created, modified or
+// deleted. Revisions are filtered by the label selectors and by the names in the
+// chart list, matching the behavior of streaming releases from secrets. Only the
+// metadata of the revisions is polled, and bodies are only read for revisions which
+// were created or modified.
+func (d *SQLDriver) StreamReleases(
+	chartList []string,
+	selectors string,
+	rw *websocket.WebsocketSafeReadWriter,
+) error {
+	selector, err := labels.Parse(selectors)
+
+	if err != nil {
+		return err
+	}
+
+	errorchan := make(chan error, 2)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// TODO: add method to alert on panic
+				return
+			}
+		}()
+
+		// listens for websocket closing handshake
+		for {
+			if _, _, err := rw.ReadMessage(); err != nil {
+				errorchan <- nil
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	closeStream := func() {
+		once.Do(func() {
+			rw.Close()
+		})
+	}
+
+	defer closeStream()
+
+	// seen maps the key of each streamed revision to its last update time and the
+	// decoded release, which is sent again when the revision is deleted
+	type seenRevision struct {
+		updatedAt time.Time
+		rel       *release.Release
+	}
+
+	seen := make(map[string]*seenRevision)
+	ticker := time.NewTicker(sqlStreamInterval)
+
+	defer ticker.Stop()
+
+	for {
+		revisions, err := d.repo.ListHelmReleaseMetadata(d.clusterID, d.namespace, nil)
+
+		if err != nil {
+			return err
+		}
+
+		current := make(map[string]bool)
+
+		for _, model := range revisions {
+			if !selector.Matches(sqlReleaseLabels(model)) ||
+				(len(chartList) > 0 && !containsString(chartList, model.Name)) {
+				continue
+			}
+
+			id := fmt.Sprintf("%s/%s", model.Namespace, model.Key)
+			current[id] = true
+
+			prev, exists := seen[id]
+
+			if exists && prev.updatedAt.Equal(model.UpdatedAt) {
+				continue
+			}
+
+			rel, err := d.readRevision(model)
+
+			// the revision was deleted after it was listed
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				delete(current, id)
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			eventType := "ADD"
+
+			if exists {
+				eventType = "UPDATE"
+			}
+
+			seen[id] = &seenRevision{model.UpdatedAt, rel}
+
+			rw.WriteJSON(kubernetes.Message{
+				EventType: eventType,
+				Object:    rel,
+			})
+		}
+
+		for id, prev := range seen {
+			if !current[id] {
+				delete(seen, id)
+
+				rw.WriteJSON(kubernetes.Message{
+					EventType: "DELETE",
+					Object:    prev.rel,
+				})
+			}
+		}
+
+		select {
+		case err := <-errorchan:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// MigrateReleases copies every revision stored by one driver to the drivers
+// returned by to, which returns the driver for the namespace of each revision. If
+// overwrite is set, revisions which already exist in the target are updated,
+// otherwise they are skipped. Revisions are not deleted from the source, so a cluster
+// can be switched back to its previous storage. It returns the number of copied
+// revisions.
+func MigrateReleases(
+	from driver.Driver,
+	to func(namespace string) driver.Driver,
+	overwrite bool,
+) (int, error) {
+	rels, err := from.List(func(_ *release.Release) bool { return true })
+
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+
+	for _, rel := range rels {
+		key := getReleaseStorageKey(rel.Name, rel.Version)
+		target := to(rel.Namespace)
+
+		err := target.Create(key, rel)
+
+		if errors.Is(err, driver.ErrReleaseExists) {
+			if !overwrite {
+				continue
+			}
+
+			err = target.Update(key, rel)
+		}
+
+		if err != nil {
+			return migrated, fmt.Errorf("could not migrate revision %d of release %s/%s: %w", rel.Version, rel.Namespace, rel.Name, err)
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// getReleaseStorageKey returns the key which Helm stores a revision of a release
+// under
+func getReleaseStorageKey(name string, version int) string {
+	return fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, version)
+}
+
+// latestSQLRevisions keeps only the latest revision of each release
+func latestSQLRevisions(revisions []*models.HelmRelease) []*models.HelmRelease {
+	latestMap := make(map[string]int)
+	res := make([]*models.HelmRelease, 0)
+
+	for _, model := range revisions {
+		id := fmt.Sprintf("%s/%s", model.Namespace, model.Name)
+
+		if i, exists := latestMap[id]; !exists {
+			latestMap[id] = len(res)
+			res = append(res, model)
+		} else if res[i].Version < model.Version {
+			res[i] = model
+		}
+	}
+
+	return res
+}
+
+func sqlReleaseLabels(model *models.HelmRelease) labels.Set {
+	return labels.Set{
+		"name":    model.Name,
+		"owner":   model.Owner,
+		"status":  model.Status,
+		"version": strconv.Itoa(model.Version),
+	}
+}
+
+// setSQLRelease sets the labels and the encoded body of a release on the model
+func setSQLRelease(model *models.HelmRelease, rls *release.Release) error {
+	body, err := encodeSQLRelease(rls)
+
+	if err != nil {
+		return err
+	}
+
+	model.Name = rls.Name
+	model.Owner = "helm"
+	model.Version = rls.Version
+	model.Body = body
+
+	if rls.Info != nil {
+		model.Status = rls.Info.Status.String()
+	}
+
+	return nil
+}
+
+// encodeSQLRelease gzips the JSON of a release. Unlike the secret driver, the
+// body is not base64-encoded, since it is stored as bytes.
+func encodeSQLRelease(rls *release.Release) ([]byte, error) {
+	data, err := json.Marshal(rls)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeSQLRelease(body []byte) (*release.Release, error) {
+	r, err := gzip.NewReader(bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rls := &release.Release{}
+
+	if err := json.Unmarshal(data, rls); err != nil {
+		return nil, err
+	}
+
+	return rls, nil
+}
+
+func containsString(list []string, val string) bool {
+	for _, item := range list {
+		if item == val {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package helm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newSQLTestRelease(name, namespace string, version int, status release.Status) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   version,
+		Info: &release.Info{
+			Status: status,
+		},
+	}
+}
+
+func TestSQLDriver(t *testing.T) {
+	assert := assert.New(t)
+
+	repo := test.NewHelmReleaseRepository(true)
+	d := helm.NewSQLDriver(repo, 1, "default")
+
+	// releases of other clusters and namespaces should not be read
+	other := helm.NewSQLDriver(repo, 2, "default")
+	staging := helm.NewSQLDriver(repo, 1, "staging")
+
+	assert.NoError(other.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "default", 1, release.StatusDeployed)))
+	assert.NoError(staging.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "staging", 1, release.StatusDeployed)))
+
+	assert.NoError(d.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "default", 1, release.StatusSuperseded)))
+	assert.NoError(d.Create("sh.helm.release.v1.web.v2", newSQLTestRelease("web", "default", 2, release.StatusDeployed)))
+	assert.NoError(d.Create("sh.helm.release.v1.api.v1", newSQLTestRelease("api", "default", 1, release.StatusFailed)))
+
+	err := d.Create("sh.helm.release.v1.web.v2", newSQLTestRelease("web", "default", 2, release.StatusDeployed))
+	assert.ErrorIs(err, driver.ErrReleaseExists)
+
+	rel, err := d.Get("sh.helm.release.v1.web.v2")
+
+	if assert.NoError(err) {
+		assert.Equal("web", rel.Name)
+		assert.Equal(2, rel.Version)
+	}
+
+	_, err = d.Get("sh.helm.release.v1.web.v3")
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+
+	rels, err := d.Query(map[string]string{"name": "web", "owner": "helm", "status": "deployed"})
+
+	if assert.NoError(err) && assert.Len(rels, 1) {
+		assert.Equal(2, rels[0].Version)
+	}
+
+	_, err = d.Query(map[string]string{"name": "worker", "owner": "helm"})
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+
+	_, err = d.Query(map[string]string{"createdAt": "1"})
+	assert.Error(err, "unsupported labels should not be ignored")
+
+	rels, err = d.List(func(rel *release.Release) bool { return rel.Name == "web" })
+
+	if assert.NoError(err) {
+		assert.Len(rels, 2)
+	}
+
+	assert.NoError(d.Update("sh.helm.release.v1.api.v1", newSQLTestRelease("api", "default", 1, release.StatusDeployed)))
+
+	err = d.Update("sh.helm.release.v1.api.v2", newSQLTestRelease("api", "default", 2, release.StatusDeployed))
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+
+	// only the latest revision of each release is listed
+	rels, err = d.ListLatestReleases("", []string{"deployed"})
+
+	if assert.NoError(err) {
+		versions := make(map[string]int)
+
+		for _, rel := range rels {
+			versions[rel.Namespace+"/"+rel.Name] = rel.Version
+		}
+
+		assert.Equal(map[string]int{
+			"default/web": 2,
+			"default/api": 1,
+			"staging/web": 1,
+		}, versions)
+	}
+
+	rel, err = d.Delete("sh.helm.release.v1.web.v2")
+
+	if assert.NoError(err) {
+		assert.Equal(2, rel.Version)
+	}
+
+	_, err = d.Get("sh.helm.release.v1.web.v2")
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+}
+
+func TestSQLDriverAllNamespaces(t *testing.T) {
+	assert := assert.New(t)
+
+	repo := test.NewHelmReleaseRepository(true)
+	d := helm.NewSQLDriver(repo, 1, "")
+
+	// the same key is stored separately in the namespace of each release
+	assert.NoError(d.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "default", 1, release.StatusDeployed)))
+	assert.NoError(d.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "staging", 1, release.StatusDeployed)))
+
+	err := d.Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "staging", 1, release.StatusDeployed))
+	assert.ErrorIs(err, driver.ErrReleaseExists)
+
+	assert.NoError(d.Update("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "staging", 1, release.StatusSuperseded)))
+
+	rel, err := helm.NewSQLDriver(repo, 1, "staging").Get("sh.helm.release.v1.web.v1")
+
+	if assert.NoError(err) {
+		assert.Equal(release.StatusSuperseded, rel.Info.Status)
+	}
+
+	rel, err = helm.NewSQLDriver(repo, 1, "default").Get("sh.helm.release.v1.web.v1")
+
+	if assert.NoError(err) {
+		assert.Equal(release.StatusDeployed, rel.Info.Status, "the release in another namespace should not be updated")
+	}
+
+	// a key which is stored in several namespaces is not deleted
+	_, err = d.Delete("sh.helm.release.v1.web.v1")
+	assert.Error(err)
+
+	assert.NoError(d.Create("sh.helm.release.v1.api.v1", newSQLTestRelease("api", "staging", 1, release.StatusDeployed)))
+
+	rel, err = d.Delete("sh.helm.release.v1.api.v1")
+
+	if assert.NoError(err) {
+		assert.Equal("staging", rel.Namespace)
+	}
+
+	_, err = d.Delete("sh.helm.release.v1.api.v1")
+	assert.ErrorIs(err, driver.ErrReleaseNotFound)
+}
+
+func TestMigrateReleases(t *testing.T) {
+	assert := assert.New(t)
+
+	clientset := fake.NewSimpleClientset()
+	repo := test.NewHelmReleaseRepository(true)
+
+	secretsDriver := func(namespace string) driver.Driver {
+		return driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
+	}
+
+	sqlDriver := func(namespace string) driver.Driver {
+		return helm.NewSQLDriver(repo, 1, namespace)
+	}
+
+	assert.NoError(secretsDriver("default").Create("sh.helm.release.v1.web.v1", newSQLTestRelease("web", "default", 1, release.StatusSuperseded)))
+	assert.NoError(secretsDriver("default").Create("sh.helm.release.v1.web.v2", newSQLTestRelease("web", "default", 2, release.StatusDeployed)))
+	assert.NoError(secretsDriver("staging").Create("sh.helm.release.v1.api.v1", newSQLTestRelease("api", "staging", 1, release.StatusDeployed)))
+
+	migrated, err := helm.MigrateReleases(secretsDriver(""), sqlDriver, true)
+
+	if assert.NoError(err) {
+		assert.Equal(3, migrated)
+	}
+
+	rel, err := sqlDriver("staging").Get("sh.helm.release.v1.api.v1")
+
+	if assert.NoError(err) {
+		assert.Equal("api", rel.Name)
+	}
+
+	// existing revisions are skipped unless they are overwritten
+	assert.NoError(sqlDriver("default").Update("sh.helm.release.v1.web.v2", newSQLTestRelease("web", "default", 2, release.StatusSuperseded)))
+	assert.NoError(sqlDriver("default").Create("sh.helm.release.v1.web.v3", newSQLTestRelease("web", "default", 3, release.StatusDeployed)))
+
+	migrated, err = helm.MigrateReleases(secretsDriver(""), sqlDriver, false)
+
+	if assert.NoError(err) {
+		assert.Equal(0, migrated)
+	}
+
+	rel, err = sqlDriver("default").Get("sh.helm.release.v1.web.v2")
+
+	if assert.NoError(err) {
+		assert.Equal(release.StatusSuperseded, rel.Info.Status)
+	}
+
+	// migrating back updates the secrets which are out of date
+	migrated, err = helm.MigrateReleases(sqlDriver(""), secretsDriver, true)
+
+	if assert.NoError(err) {
+		assert.Equal(4, migrated)
+	}
+
+	secret, err := clientset.CoreV1().Secrets("default").Get(context.Background(), "sh.helm.release.v1.web.v3", metav1.GetOptions{})
+
+	if assert.NoError(err) {
+		assert.Equal("deployed", secret.Labels["status"])
+	}
+
+	rel, err = secretsDriver("default").Get("sh.helm.release.v1.web.v2")
+
+	if assert.NoError(err) {
+		assert.Equal(release.StatusSuperseded, rel.Info.Status)
+	}
+}
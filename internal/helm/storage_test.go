@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/repository/test"
 	"helm.sh/helm/v3/pkg/storage"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -80,3 +81,9 @@ func TestNewConfigMapStorageDriver(t *testing.T) {
 func TestNewMemoryStorageDriver(t *testing.T) {
 	testStorageDriver(t, "memory")
 }
+
+func TestNewSQLStorageDriver(t *testing.T) {
+	repo := test.NewHelmReleaseRepository(true)
+
+	testDriver(t, helm.NewSQLStorageDriver(repo, 1, "default"))
+}
@@ -1272,6 +1272,34 @@ func (a *Agent) RunWebsocketTask(task func() error) error {
 	}
 }
 
+// getControllerInformer spins up an informer depending on kind, or returns nil if the
+// kind is not supported
+func getControllerInformer(factory informers.SharedInformerFactory, kind string) cache.SharedInformer {
+	// convert to lowercase for robustness
+	switch strings.ToLower(kind) {
+	case "deployment":
+		return factory.Apps().V1().Deployments().Informer()
+	case "statefulset":
+		return factory.Apps().V1().StatefulSets().Informer()
+	case "replicaset":
+		return factory.Apps().V1().ReplicaSets().Informer()
+	case "daemonset":
+		return factory.Apps().V1().DaemonSets().Informer()
+	case "controllerrevision":
+		return factory.Apps().V1().ControllerRevisions().Informer()
+	case "job":
+		return factory.Batch().V1().Jobs().Informer()
+	case "cronjob":
+		return factory.Batch().V1beta1().CronJobs().Informer()
+	case "namespace":
+		return factory.Core().V1().Namespaces().Informer()
+	case "pod":
+		return factory.Core().V1().Pods().Informer()
+	}
+
+	return nil
+}
+
 // StreamControllerStatus streams controller status. Supports Deployment, StatefulSet, ReplicaSet, and DaemonSet
 // TODO: Support Jobs
 func (a *Agent) StreamControllerStatus(kind string, selectors string, rw *websocket.WebsocketSafeReadWriter) error {
@@ -1289,27 +1317,7 @@ func (a *Agent) StreamControllerStatus(kind string, selectors string, rw *websoc
 			informers.WithTweakListOptions(tweakListOptionsFunc),
 		)
 
-		var informer cache.SharedInformer
-
-		// Spins up an informer depending on kind. Convert to lowercase for robustness
-		switch strings.ToLower(kind) {
-		case "deployment":
-			informer = factory.Apps().V1().Deployments().Informer()
-		case "statefulset":
-			informer = factory.Apps().V1().StatefulSets().Informer()
-		case "replicaset":
-			informer = factory.Apps().V1().ReplicaSets().Informer()
-		case "daemonset":
-			informer = factory.Apps().V1().DaemonSets().Informer()
-		case "job":
-			informer = factory.Batch().V1().Jobs().Informer()
-		case "cronjob":
-			informer = factory.Batch().V1beta1().CronJobs().Informer()
-		case "namespace":
-			informer = factory.Core().V1().Namespaces().Informer()
-		case "pod":
-			informer = factory.Core().V1().Pods().Informer()
-		}
+		informer := getControllerInformer(factory, kind)
 
 		stopper := make(chan struct{})
 		errorchan := make(chan error)
@@ -1649,6 +1657,12 @@ func (a *Agent) StreamHelmReleases(namespace string, chartList []string, selecto
 	return a.RunWebsocketTask(run)
 }
 
+// GetImagePullSecretName returns the name of the image pull secret which Porter
+// creates for a registry
+func GetImagePullSecretName(reg *models.Registry) string {
+	return fmt.Sprintf("porter-%s-%d", reg.ToRegistryType().Service, reg.ID)
+}
+
 // CreateImagePullSecrets will create the required image pull secrets and
 // return a map from the registry name to the name of the secret.
 func (a *Agent) CreateImagePullSecrets(
@@ -1668,7 +1682,7 @@ func (a *Agent) CreateImagePullSecrets(
 			return nil, err
 		}
 
-		secretName := fmt.Sprintf("porter-%s-%d", val.ToRegistryType().Service, val.ID)
+		secretName := GetImagePullSecretName(val)
 
 		secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(
 			context.TODO(),
@@ -0,0 +1,326 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	canarySuffix = "-canary"
+
+	nginxCanaryAnnotation       = "nginx.ingress.kubernetes.io/canary"
+	nginxCanaryWeightAnnotation = "nginx.ingress.kubernetes.io/canary-weight"
+)
+
+// ErrCanaryExists is returned when the canary of a release already exists, which
+// means that a rollout of the release is in progress
+var ErrCanaryExists = fmt.Errorf("canary already exists")
+
+// Canary is a set of objects which serve a new revision of a release alongside the
+// stable objects. Traffic is shifted to the canary with the NGINX ingress canary
+// annotations.
+type Canary struct {
+	Namespace  string
+	Deployment *appsv1.Deployment
+	Service    *v1.Service
+
+	// only one of the ingresses is set, depending on the API version of the stable
+	// ingress
+	Ingress        *networkingv1.Ingress
+	IngressV1beta1 *networkingv1beta1.Ingress
+}
+
+// NewCanary creates the canary objects from the rendered deployment, service and
+// ingress of a new revision. The canary pods are labeled so that they are not
+// selected by the stable service.
+func NewCanary(
+	namespace string,
+	deployment *appsv1.Deployment,
+	service *v1.Service,
+	ingress runtime.Object,
+) (*Canary, error) {
+	if deployment.Spec.Selector == nil || len(deployment.Spec.Selector.MatchLabels) == 0 {
+		return nil, fmt.Errorf("deployment %s has no selector labels", deployment.Name)
+	}
+
+	// the canary labels are the selector labels with a suffix appended to each value
+	canaryLabels := make(map[string]string)
+
+	for key, val := range deployment.Spec.Selector.MatchLabels {
+		canaryLabels[key] = val + canarySuffix
+	}
+
+	canaryDeployment := deployment.DeepCopy()
+	canaryDeployment.ObjectMeta = getCanaryObjectMeta(deployment.ObjectMeta, namespace)
+	canaryDeployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: canaryLabels}
+	canaryDeployment.Spec.Template.Labels = copyLabels(deployment.Spec.Template.Labels)
+
+	for key, val := range canaryLabels {
+		canaryDeployment.Spec.Template.Labels[key] = val
+	}
+
+	// the canary is scaled up at the first step of the rollout
+	replicas := int32(0)
+	canaryDeployment.Spec.Replicas = &replicas
+
+	canaryService := service.DeepCopy()
+	canaryService.ObjectMeta = getCanaryObjectMeta(service.ObjectMeta, namespace)
+	canaryService.Spec.Selector = copyLabels(service.Spec.Selector)
+	canaryService.Spec.ClusterIP = ""
+	canaryService.Spec.ClusterIPs = nil
+
+	for key := range canaryService.Spec.Selector {
+		if val, ok := canaryLabels[key]; ok {
+			canaryService.Spec.Selector[key] = val
+		}
+	}
+
+	for i := range canaryService.Spec.Ports {
+		canaryService.Spec.Ports[i].NodePort = 0
+	}
+
+	canary := &Canary{
+		Namespace:  namespace,
+		Deployment: canaryDeployment,
+		Service:    canaryService,
+	}
+
+	switch ing := ingress.(type) {
+	case *networkingv1.Ingress:
+		canary.Ingress = ing.DeepCopy()
+		canary.Ingress.ObjectMeta = getCanaryIngressObjectMeta(ing.ObjectMeta, namespace)
+
+		for i, rule := range canary.Ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			for j, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil && path.Backend.Service.Name == service.Name {
+					canary.Ingress.Spec.Rules[i].HTTP.Paths[j].Backend.Service.Name = canaryService.Name
+				}
+			}
+		}
+	case *networkingv1beta1.Ingress:
+		canary.IngressV1beta1 = ing.DeepCopy()
+		canary.IngressV1beta1.ObjectMeta = getCanaryIngressObjectMeta(ing.ObjectMeta, namespace)
+
+		for i, rule := range canary.IngressV1beta1.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			for j, path := range rule.HTTP.Paths {
+				if path.Backend.ServiceName == service.Name {
+					canary.IngressV1beta1.Spec.Rules[i].HTTP.Paths[j].Backend.ServiceName = canaryService.Name
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ingress type %T", ingress)
+	}
+
+	return canary, nil
+}
+
+// IngressName returns the name of the canary ingress
+func (c *Canary) IngressName() string {
+	if c.Ingress != nil {
+		return c.Ingress.Name
+	}
+
+	return c.IngressV1beta1.Name
+}
+
+// StableDeploymentName returns the name of the deployment which the canary replaces
+func (c *Canary) StableDeploymentName() string {
+	return strings.TrimSuffix(c.Deployment.Name, canarySuffix)
+}
+
+// CreateCanary creates the objects of a canary. It returns ErrCanaryExists if the
+// canary deployment already exists.
+func (a *Agent) CreateCanary(canary *Canary) error {
+	_, err := a.Clientset.AppsV1().Deployments(canary.Namespace).Create(
+		context.TODO(),
+		canary.Deployment,
+		metav1.CreateOptions{},
+	)
+
+	if errors.IsAlreadyExists(err) {
+		return ErrCanaryExists
+	} else if err != nil {
+		return err
+	}
+
+	_, err = a.Clientset.CoreV1().Services(canary.Namespace).Create(
+		context.TODO(),
+		canary.Service,
+		metav1.CreateOptions{},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if canary.Ingress != nil {
+		_, err = a.Clientset.NetworkingV1().Ingresses(canary.Namespace).Create(
+			context.TODO(),
+			canary.Ingress,
+			metav1.CreateOptions{},
+		)
+	} else {
+		_, err = a.Clientset.NetworkingV1beta1().Ingresses(canary.Namespace).Create(
+			context.TODO(),
+			canary.IngressV1beta1,
+			metav1.CreateOptions{},
+		)
+	}
+
+	return err
+}
+
+// ScaleCanary sets the number of replicas of the canary deployment
+func (a *Agent) ScaleCanary(canary *Canary, replicas int32) error {
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+
+	_, err := a.Clientset.AppsV1().Deployments(canary.Namespace).Patch(
+		context.TODO(),
+		canary.Deployment.Name,
+		types.MergePatchType,
+		[]byte(patch),
+		metav1.PatchOptions{},
+	)
+
+	return err
+}
+
+// SetCanaryWeight sets the percentage of traffic which the ingress controller sends
+// to the canary
+func (a *Agent) SetCanaryWeight(canary *Canary, weight uint) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%d"}}}`, nginxCanaryWeightAnnotation, weight))
+
+	var err error
+
+	if canary.Ingress != nil {
+		_, err = a.Clientset.NetworkingV1().Ingresses(canary.Namespace).Patch(
+			context.TODO(),
+			canary.Ingress.Name,
+			types.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	} else {
+		_, err = a.Clientset.NetworkingV1beta1().Ingresses(canary.Namespace).Patch(
+			context.TODO(),
+			canary.IngressV1beta1.Name,
+			types.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	}
+
+	return err
+}
+
+// DeleteCanary deletes the objects of a canary. Objects which do not exist are
+// ignored.
+func (a *Agent) DeleteCanary(canary *Canary) error {
+	var err error
+
+	if canary.Ingress != nil {
+		err = a.Clientset.NetworkingV1().Ingresses(canary.Namespace).Delete(
+			context.TODO(),
+			canary.Ingress.Name,
+			metav1.DeleteOptions{},
+		)
+	} else {
+		err = a.Clientset.NetworkingV1beta1().Ingresses(canary.Namespace).Delete(
+			context.TODO(),
+			canary.IngressV1beta1.Name,
+			metav1.DeleteOptions{},
+		)
+	}
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	err = a.Clientset.CoreV1().Services(canary.Namespace).Delete(
+		context.TODO(),
+		canary.Service.Name,
+		metav1.DeleteOptions{},
+	)
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	err = a.Clientset.AppsV1().Deployments(canary.Namespace).Delete(
+		context.TODO(),
+		canary.Deployment.Name,
+		metav1.DeleteOptions{},
+	)
+
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func getCanaryObjectMeta(meta metav1.ObjectMeta, namespace string) metav1.ObjectMeta {
+	labels := copyLabels(meta.Labels)
+
+	// the canary objects are not managed by Helm, so they should not be treated as
+	// part of the release
+	for key := range labels {
+		if strings.HasPrefix(key, "helm.sh/") || key == "app.kubernetes.io/managed-by" {
+			delete(labels, key)
+		}
+	}
+
+	return metav1.ObjectMeta{
+		Name:      meta.Name + canarySuffix,
+		Namespace: namespace,
+		Labels:    labels,
+	}
+}
+
+func getCanaryIngressObjectMeta(meta metav1.ObjectMeta, namespace string) metav1.ObjectMeta {
+	res := getCanaryObjectMeta(meta, namespace)
+	res.Annotations = make(map[string]string)
+
+	for key, val := range meta.Annotations {
+		// certificates are issued for the stable ingress, which shares the canary's hosts
+		if strings.HasPrefix(key, "cert-manager.io/") {
+			continue
+		}
+
+		res.Annotations[key] = val
+	}
+
+	res.Annotations[nginxCanaryAnnotation] = "true"
+	res.Annotations[nginxCanaryWeightAnnotation] = "0"
+
+	return res
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	res := make(map[string]string)
+
+	for key, val := range labels {
+		res[key] = val
+	}
+
+	return res
+}
@@ -0,0 +1,183 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCanaryFixture(t *testing.T) *kubernetes.Canary {
+	t.Helper()
+
+	replicas := int32(3)
+	podLabels := map[string]string{"app.kubernetes.io/name": "web", "app.kubernetes.io/instance": "web"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "Helm", "helm.sh/chart": "web-0.1.0"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Selector:  podLabels,
+			ClusterIP: "10.0.0.1",
+			Ports:     []v1.ServicePort{{Port: 80, NodePort: 30080}},
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":    "nginx",
+				"cert-manager.io/cluster-issuer": "letsencrypt-prod",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{Name: "web"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	canary, err := kubernetes.NewCanary("default", deployment, service, ingress)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return canary
+}
+
+func TestNewCanary(t *testing.T) {
+	canary := newCanaryFixture(t)
+
+	if canary.Deployment.Name != "web-canary" || canary.StableDeploymentName() != "web" {
+		t.Errorf("unexpected canary deployment name %s", canary.Deployment.Name)
+	}
+
+	if *canary.Deployment.Spec.Replicas != 0 {
+		t.Errorf("expected canary to start with 0 replicas, got %d", *canary.Deployment.Spec.Replicas)
+	}
+
+	if _, exists := canary.Deployment.Labels["helm.sh/chart"]; exists {
+		t.Errorf("expected helm labels to be removed from canary deployment")
+	}
+
+	// the stable service should not select the canary pods
+	for key, val := range canary.Deployment.Spec.Template.Labels {
+		if val != "web-canary" {
+			t.Errorf("expected label %s of canary pods to be web-canary, got %s", key, val)
+		}
+	}
+
+	for key, val := range canary.Service.Spec.Selector {
+		if canary.Deployment.Spec.Template.Labels[key] != val {
+			t.Errorf("expected canary service to select canary pods, got %s=%s", key, val)
+		}
+	}
+
+	if canary.Service.Spec.ClusterIP != "" || canary.Service.Spec.Ports[0].NodePort != 0 {
+		t.Errorf("expected allocated service fields to be cleared")
+	}
+
+	if canary.Ingress.Annotations["nginx.ingress.kubernetes.io/canary"] != "true" ||
+		canary.Ingress.Annotations["nginx.ingress.kubernetes.io/canary-weight"] != "0" {
+		t.Errorf("expected canary annotations to be set, got %v", canary.Ingress.Annotations)
+	}
+
+	if _, exists := canary.Ingress.Annotations["cert-manager.io/cluster-issuer"]; exists {
+		t.Errorf("expected cert-manager annotations to be removed from canary ingress")
+	}
+
+	if backend := canary.Ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name; backend != "web-canary" {
+		t.Errorf("expected canary ingress to point to web-canary, got %s", backend)
+	}
+}
+
+func TestCanaryLifecycle(t *testing.T) {
+	agent := newAgentFixture(t)
+	canary := newCanaryFixture(t)
+
+	if err := agent.CreateCanary(canary); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := agent.CreateCanary(canary); !errors.Is(err, kubernetes.ErrCanaryExists) {
+		t.Errorf("expected ErrCanaryExists, got %v", err)
+	}
+
+	if err := agent.ScaleCanary(canary, 2); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := agent.SetCanaryWeight(canary, 25); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	deployment, err := agent.Clientset.AppsV1().Deployments("default").Get(context.TODO(), "web-canary", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if *deployment.Spec.Replicas != 2 {
+		t.Errorf("expected 2 canary replicas, got %d", *deployment.Spec.Replicas)
+	}
+
+	ingress, err := agent.Clientset.NetworkingV1().Ingresses("default").Get(context.TODO(), "web-canary", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if weight := ingress.Annotations["nginx.ingress.kubernetes.io/canary-weight"]; weight != "25" {
+		t.Errorf("expected canary weight 25, got %s", weight)
+	}
+
+	if err := agent.DeleteCanary(canary); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// deleting a canary which was already deleted is not an error
+	if err := agent.DeleteCanary(canary); err != nil {
+		t.Errorf("expected no error deleting a deleted canary, got %v", err)
+	}
+
+	if _, err := agent.Clientset.CoreV1().Services("default").Get(context.TODO(), "web-canary", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected canary service to be deleted")
+	}
+}
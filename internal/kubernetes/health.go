@@ -0,0 +1,339 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ControllerHealthError is returned when a controller does not become healthy
+type ControllerHealthError struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+func (e *ControllerHealthError) Error() string {
+	return fmt.Sprintf("%s %s is not healthy: %s", strings.ToLower(e.Kind), e.Name, e.Reason)
+}
+
+// WaitForControllersHealthy watches the controllers of a release until each of them
+// has rolled out and all of their pods are ready. It returns a ControllerHealthError
+// as soon as a pod of a controller is crash looping, or if the controllers are not
+// healthy when the deadline passes.
+//
+// Only deployments, statefulsets and daemonsets are watched: other controllers, such
+// as jobs, are not expected to stay ready.
+func (a *Agent) WaitForControllersHealthy(
+	namespace string,
+	controllers []grapher.Object,
+	deadline time.Duration,
+) error {
+	watched := make([]grapher.Object, 0)
+
+	for _, controller := range controllers {
+		switch strings.ToLower(controller.Kind) {
+		case "deployment", "statefulset", "daemonset":
+			watched = append(watched, controller)
+		}
+	}
+
+	if len(watched) == 0 {
+		return nil
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		a.Clientset,
+		0,
+		informers.WithNamespace(namespace),
+	)
+
+	// each change to a watched object triggers a new health check
+	changed := make(chan struct{}, 1)
+
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { notify() },
+		UpdateFunc: func(oldObj, newObj interface{}) { notify() },
+		DeleteFunc: func(obj interface{}) { notify() },
+	}
+
+	stores := make(map[string]cache.Store)
+
+	for _, kind := range []string{"pod", "deployment", "replicaset", "statefulset", "daemonset", "controllerrevision"} {
+		informer := getControllerInformer(factory, kind)
+		informer.AddEventHandler(handler)
+		stores[kind] = informer.GetStore()
+	}
+
+	stopper := make(chan struct{})
+	defer close(stopper)
+
+	factory.Start(stopper)
+
+	timeout := time.After(deadline)
+
+	for {
+		select {
+		case <-changed:
+			pending, err := getControllersHealth(namespace, watched, stores)
+
+			if err == nil {
+				return nil
+			} else if !pending {
+				return err
+			}
+		case <-timeout:
+			_, err := getControllersHealth(namespace, watched, stores)
+
+			if err == nil {
+				return nil
+			}
+
+			err.Reason = fmt.Sprintf("not healthy after %s: %s", deadline, err.Reason)
+
+			return err
+		}
+	}
+}
+
+// getControllersHealth checks the controllers against the objects in the informer
+// stores. It returns nil if all controllers are healthy. Otherwise, it returns the
+// health error of the first unhealthy controller, and whether that controller may
+// still become healthy.
+func getControllersHealth(
+	namespace string,
+	controllers []grapher.Object,
+	stores map[string]cache.Store,
+) (bool, *ControllerHealthError) {
+	for _, controller := range controllers {
+		kind := strings.ToLower(controller.Kind)
+
+		obj, exists, err := stores[kind].GetByKey(fmt.Sprintf("%s/%s", namespace, controller.Name))
+
+		healthErr := &ControllerHealthError{
+			Kind: controller.Kind,
+			Name: controller.Name,
+		}
+
+		if err != nil || !exists {
+			healthErr.Reason = "waiting for the controller to be created"
+			return true, healthErr
+		}
+
+		var reason string
+		var selector *metav1.LabelSelector
+
+		switch c := obj.(type) {
+		case *appsv1.Deployment:
+			selector = c.Spec.Selector
+			reason, err = getDeploymentRolloutStatus(c)
+		case *appsv1.StatefulSet:
+			selector = c.Spec.Selector
+			reason = getStatefulSetRolloutStatus(c)
+		case *appsv1.DaemonSet:
+			selector = c.Spec.Selector
+			reason = getDaemonSetRolloutStatus(c)
+		}
+
+		if err != nil {
+			healthErr.Reason = err.Error()
+			return false, healthErr
+		}
+
+		// a crash looping pod of the new revision will not become ready, so the
+		// controller is unhealthy even if the rollout is still in progress. Pods of
+		// previous revisions are ignored, since they are replaced by the rollout.
+		revisionLabel, revisionHash := getUpdateRevision(namespace, obj, stores)
+
+		if crashReason := getCrashLoopingPod(namespace, selector, revisionLabel, revisionHash, stores["pod"]); crashReason != "" {
+			healthErr.Reason = crashReason
+			return false, healthErr
+		}
+
+		if reason != "" {
+			healthErr.Reason = reason
+			return true, healthErr
+		}
+	}
+
+	return false, nil
+}
+
+// getDeploymentRolloutStatus returns the reason that a deployment has not rolled out,
+// or an error if the rollout has failed
+func getDeploymentRolloutStatus(d *appsv1.Deployment) (string, error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "waiting for the rollout to be observed", nil
+	}
+
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return "", fmt.Errorf("the rollout exceeded its progress deadline")
+		}
+	}
+
+	replicas := int32(1)
+
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	if d.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("%d of %d replicas have been updated", d.Status.UpdatedReplicas, replicas), nil
+	}
+
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas), nil
+	}
+
+	if d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas), nil
+	}
+
+	return "", nil
+}
+
+// getStatefulSetRolloutStatus returns the reason that a statefulset has not rolled out
+func getStatefulSetRolloutStatus(s *appsv1.StatefulSet) string {
+	if s.Generation > s.Status.ObservedGeneration {
+		return "waiting for the rollout to be observed"
+	}
+
+	replicas := int32(1)
+
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	if s.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("%d of %d replicas are ready", s.Status.ReadyReplicas, replicas)
+	}
+
+	if s.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		s.Status.UpdateRevision != s.Status.CurrentRevision && s.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("%d of %d replicas have been updated", s.Status.UpdatedReplicas, replicas)
+	}
+
+	return ""
+}
+
+// getDaemonSetRolloutStatus returns the reason that a daemonset has not rolled out
+func getDaemonSetRolloutStatus(d *appsv1.DaemonSet) string {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "waiting for the rollout to be observed"
+	}
+
+	if d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("%d of %d pods have been updated", d.Status.UpdatedNumberScheduled, d.Status.DesiredNumberScheduled)
+	}
+
+	if d.Status.NumberAvailable < d.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("%d of %d updated pods are available", d.Status.NumberAvailable, d.Status.DesiredNumberScheduled)
+	}
+
+	return ""
+}
+
+// the annotation which the deployment controller sets to the revision of a deployment
+// and of its replicasets
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// getUpdateRevision returns the label and the hash which identify the pods of the
+// revision that a controller is rolling out, or empty strings if the revision isn't
+// known yet. The hash of a deployment is read from its current replicaset, and the
+// hash of a daemonset from its latest controller revision.
+func getUpdateRevision(namespace string, obj interface{}, stores map[string]cache.Store) (string, string) {
+	switch c := obj.(type) {
+	case *appsv1.Deployment:
+		revision := c.Annotations[deploymentRevisionAnnotation]
+
+		if revision == "" {
+			return "", ""
+		}
+
+		for _, rsObj := range stores["replicaset"].List() {
+			rs, ok := rsObj.(*appsv1.ReplicaSet)
+
+			if ok && rs.Namespace == namespace && metav1.IsControlledBy(rs, c) &&
+				rs.Annotations[deploymentRevisionAnnotation] == revision {
+				return appsv1.DefaultDeploymentUniqueLabelKey, rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+			}
+		}
+	case *appsv1.StatefulSet:
+		return appsv1.ControllerRevisionHashLabelKey, c.Status.UpdateRevision
+	case *appsv1.DaemonSet:
+		var latest *appsv1.ControllerRevision
+
+		for _, crObj := range stores["controllerrevision"].List() {
+			cr, ok := crObj.(*appsv1.ControllerRevision)
+
+			if ok && cr.Namespace == namespace && metav1.IsControlledBy(cr, c) &&
+				(latest == nil || cr.Revision > latest.Revision) {
+				latest = cr
+			}
+		}
+
+		if latest != nil {
+			return appsv1.ControllerRevisionHashLabelKey, latest.Labels[appsv1.ControllerRevisionHashLabelKey]
+		}
+	}
+
+	return "", ""
+}
+
+// getCrashLoopingPod returns a description of the first pod of the revision which
+// matches the selector with a crash looping container, or an empty string if there
+// is none. Pods are only checked once the hash of the revision is known.
+func getCrashLoopingPod(
+	namespace string,
+	selector *metav1.LabelSelector,
+	revisionLabel, revisionHash string,
+	podStore cache.Store,
+) string {
+	if selector == nil || revisionHash == "" {
+		return ""
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+
+	if err != nil {
+		return ""
+	}
+
+	for _, obj := range podStore.List() {
+		pod, ok := obj.(*v1.Pod)
+
+		if !ok || pod.Namespace != namespace || !labelSelector.Matches(labels.Set(pod.Labels)) ||
+			pod.Labels[revisionLabel] != revisionHash {
+			continue
+		}
+
+		// the pod is shared with the informer cache, so its statuses are copied
+		statuses := make([]v1.ContainerStatus, 0)
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+				return fmt.Sprintf("container %s of pod %s is crash looping", status.Name, pod.Name)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+package kubernetes_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDeploymentFixture(updated, available int32) *appsv1.Deployment {
+	replicas := int32(2)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			UID:       "web-uid",
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": "2",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          updated,
+			UpdatedReplicas:   updated,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+// newReplicaSetFixture returns a replicaset of the web deployment for a revision,
+// whose pods are labeled with the hash
+func newReplicaSetFixture(revision, hash string) *appsv1.ReplicaSet {
+	controller := true
+
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-" + hash,
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web", "pod-template-hash": hash},
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision": revision,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "web",
+					UID:        "web-uid",
+					Controller: &controller,
+				},
+			},
+		},
+	}
+}
+
+// newPodFixture returns a pod of the web deployment with the hash of a revision
+func newPodFixture(name, hash string, crashLooping bool) *v1.Pod {
+	state := v1.ContainerState{
+		Running: &v1.ContainerStateRunning{},
+	}
+
+	if crashLooping {
+		state = v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		}
+	}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web", "pod-template-hash": hash},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "web",
+					State: state,
+				},
+			},
+		},
+	}
+}
+
+var webControllers = []grapher.Object{
+	{Kind: "Deployment", Name: "web"},
+	{Kind: "CronJob", Name: "cleanup"},
+}
+
+func TestWaitForControllersHealthy(t *testing.T) {
+	agent := newAgentFixture(t, newDeploymentFixture(2, 2))
+
+	if err := agent.WaitForControllersHealthy("default", webControllers, 10*time.Second); err != nil {
+		t.Errorf("expected deployment to be healthy, got %v", err)
+	}
+}
+
+func TestWaitForControllersHealthyDeadline(t *testing.T) {
+	agent := newAgentFixture(t, newDeploymentFixture(2, 1))
+
+	err := agent.WaitForControllersHealthy("default", webControllers, 500*time.Millisecond)
+
+	healthErr := &kubernetes.ControllerHealthError{}
+
+	if !errors.As(err, &healthErr) {
+		t.Fatalf("expected a controller health error, got %v", err)
+	}
+
+	if !strings.Contains(healthErr.Reason, "1 of 2 updated replicas are available") {
+		t.Errorf("unexpected reason: %s", healthErr.Reason)
+	}
+}
+
+func TestWaitForControllersHealthyCrashLoop(t *testing.T) {
+	agent := newAgentFixture(
+		t,
+		newDeploymentFixture(2, 1),
+		newReplicaSetFixture("2", "new"),
+		newPodFixture("web-1", "new", true),
+	)
+
+	start := time.Now()
+
+	err := agent.WaitForControllersHealthy("default", webControllers, time.Minute)
+
+	if err == nil || !strings.Contains(err.Error(), "container web of pod web-1 is crash looping") {
+		t.Fatalf("expected a crash loop error, got %v", err)
+	}
+
+	// crash loops should fail the health check without waiting for the deadline
+	if time.Since(start) > 30*time.Second {
+		t.Errorf("crash loop was not detected before the deadline")
+	}
+}
+
+func TestWaitForControllersHealthyPreviousRevisionCrashLoop(t *testing.T) {
+	// the previous revision was crash looping, and one of its pods is still being
+	// terminated next to the healthy pods of the new revision
+	agent := newAgentFixture(
+		t,
+		newDeploymentFixture(2, 2),
+		newReplicaSetFixture("1", "old"),
+		newReplicaSetFixture("2", "new"),
+		newPodFixture("web-old-1", "old", true),
+		newPodFixture("web-new-1", "new", false),
+		newPodFixture("web-new-2", "new", false),
+	)
+
+	if err := agent.WaitForControllersHealthy("default", webControllers, 10*time.Second); err != nil {
+		t.Errorf("expected deployment to be healthy, got %v", err)
+	}
+}
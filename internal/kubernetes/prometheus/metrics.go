@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -194,6 +196,65 @@ func QueryPrometheus(
 	return parseQuery(rawQuery, opts.Metric)
 }
 
+// QueryNGINXErrorRate returns the percentage of requests to an ingress which returned
+// a 5xx status over the window. If the ingress served no requests, the error rate is 0.
+func QueryNGINXErrorRate(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	namespace, ingress string,
+	window time.Duration,
+) (float64, error) {
+	if len(service.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("prometheus service has no exposed ports to query")
+	}
+
+	rangeStr := fmt.Sprintf("%ds", int(window.Seconds()))
+	num := fmt.Sprintf(`sum(rate(nginx_ingress_controller_requests{status=~"5.*",namespace="%s",ingress="%s"}[%s]) OR on() vector(0))`, namespace, ingress, rangeStr)
+	denom := fmt.Sprintf(`sum(rate(nginx_ingress_controller_requests{namespace="%s",ingress="%s"}[%s]) > 0)`, namespace, ingress, rangeStr)
+
+	resp := clientset.CoreV1().Services(service.Namespace).ProxyGet(
+		"http",
+		service.Name,
+		fmt.Sprintf("%d", service.Spec.Ports[0].Port),
+		"/api/v1/query",
+		map[string]string{
+			"query": fmt.Sprintf(`%s / %s * 100 OR on() vector(0)`, num, denom),
+		},
+	)
+
+	rawQuery, err := resp.DoRaw(context.TODO())
+
+	if err != nil {
+		return 0, err
+	}
+
+	rawQueryObj := &promRawInstantQuery{}
+
+	if err := json.Unmarshal(rawQuery, rawQueryObj); err != nil {
+		return 0, err
+	}
+
+	if len(rawQueryObj.Data.Result) == 0 || len(rawQueryObj.Data.Result[0].Value) < 2 {
+		return 0, nil
+	}
+
+	valStr, ok := rawQueryObj.Data.Result[0].Value[1].(string)
+
+	if !ok {
+		return 0, fmt.Errorf("unexpected value in prometheus response")
+	}
+
+	return strconv.ParseFloat(valStr, 64)
+}
+
+type promRawInstantQuery struct {
+	Data struct {
+		Result []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
 type promRawQuery struct {
 	Data struct {
 		Result []struct {
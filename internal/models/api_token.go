@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// APIToken is a named, revocable token which grants access to a single project.
+// The encoded JWT is never stored: the token is identified by the UniqueID, which
+// is set as the token_id claim.
+type APIToken struct {
+	gorm.Model
+
+	UniqueID string `gorm:"unique"`
+
+	ProjectID       uint
+	CreatedByUserID uint
+
+	Name string
+
+	// The role kind that the token's policy is loaded from, unless the token is
+	// owned by a service account
+	Role types.RoleKind
+
+	// The service account that owns the token, if any. The token's policy is loaded
+	// from the service account's role.
+	ServiceAccountID uint
+
+	Expiry   *time.Time
+	LastUsed *time.Time
+	Revoked  bool
+}
+
+func (t *APIToken) IsExpired() bool {
+	if t.Expiry == nil {
+		return false
+	}
+
+	return t.Expiry.Before(time.Now())
+}
+
+// ToAPITokenMetaType generates an external types.APITokenMeta to be shared over REST
+func (t *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
+	return &types.APITokenMeta{
+		ID:               t.ID,
+		CreatedAt:        t.CreatedAt,
+		ProjectID:        t.ProjectID,
+		CreatedByUserID:  t.CreatedByUserID,
+		Name:             t.Name,
+		Role:             t.Role,
+		ServiceAccountID: t.ServiceAccountID,
+		ExpiresAt:        t.Expiry,
+		LastUsedAt:       t.LastUsed,
+		Revoked:          t.Revoked,
+	}
+}
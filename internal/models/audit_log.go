@@ -0,0 +1,47 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditLog is a record of a mutating API call
+type AuditLog struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	UserID     uint
+	APITokenID uint
+	Actor      string
+	IPAddress  string
+
+	ScopePath string
+	Verb      types.APIVerb
+	Method    types.HTTPVerb
+	Path      string
+	Summary   string
+
+	StatusCode int
+	Outcome    types.AuditLogOutcome
+}
+
+// ToAuditLogType generates an external types.AuditLog to be shared over REST
+func (a *AuditLog) ToAuditLogType() *types.AuditLog {
+	return &types.AuditLog{
+		ID:         a.ID,
+		CreatedAt:  a.CreatedAt,
+		ProjectID:  a.ProjectID,
+		UserID:     a.UserID,
+		APITokenID: a.APITokenID,
+		Actor:      a.Actor,
+		IPAddress:  a.IPAddress,
+		ScopePath:  a.ScopePath,
+		Verb:       a.Verb,
+		Method:     a.Method,
+		Path:       a.Path,
+		Summary:    a.Summary,
+		StatusCode: a.StatusCode,
+		Outcome:    a.Outcome,
+	}
+}
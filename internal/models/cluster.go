@@ -54,6 +54,10 @@ type Cluster struct {
 
 	NotificationsDisabled bool `json:"notifications_disabled"`
 
+	// HelmStorage is where the Helm releases of the cluster are stored. An empty
+	// value stores releases in secrets.
+	HelmStorage types.HelmStorage `json:"helm_storage"`
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -93,9 +97,19 @@ func (c *Cluster) ToClusterType() *types.Cluster {
 		Service:          serv,
 		InfraID:          c.InfraID,
 		AWSIntegrationID: c.AWSIntegrationID,
+		HelmStorage:      c.GetHelmStorage(),
 	}
 }
 
+// GetHelmStorage returns where the Helm releases of the cluster are stored
+func (c *Cluster) GetHelmStorage() types.HelmStorage {
+	if c.HelmStorage == "" {
+		return types.HelmStorageSecret
+	}
+
+	return c.HelmStorage
+}
+
 // ClusterCandidate is a cluster integration that requires additional action
 // from the user to set up.
 type ClusterCandidate struct {
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// CustomRole is a project role defined by a project admin. The role's policy is
+// stored as the JSON encoding of a list of types.PolicyDocument.
+type CustomRole struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	Name      string
+
+	Policy []byte
+}
+
+// GetPolicy decodes the role's stored policy documents
+func (c *CustomRole) GetPolicy() ([]*types.PolicyDocument, error) {
+	policy := make([]*types.PolicyDocument, 0)
+
+	if err := json.Unmarshal(c.Policy, &policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// SetPolicy encodes and stores the role's policy documents
+func (c *CustomRole) SetPolicy(policy []*types.PolicyDocument) error {
+	bytes, err := json.Marshal(policy)
+
+	if err != nil {
+		return err
+	}
+
+	c.Policy = bytes
+
+	return nil
+}
+
+// ToCustomRoleType generates an external types.CustomRole to be shared over REST
+func (c *CustomRole) ToCustomRoleType() (*types.CustomRole, error) {
+	policy, err := c.GetPolicy()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CustomRole{
+		ID:        c.ID,
+		CreatedAt: c.CreatedAt,
+		ProjectID: c.ProjectID,
+		Name:      c.Name,
+		Policy:    policy,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DeployPolicy is the set of rules which the rendered manifests of a project's releases
+// are checked against on deploy
+type DeployPolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"unique"`
+
+	RequireResourceLimits bool
+	ForbidPrivileged      bool
+	ForbidHostPath        bool
+
+	// comma-separated lists of label keys and registries
+	RequiredLabels    string
+	AllowedRegistries string
+
+	PinImageDigests bool
+}
+
+// ToDeployPolicyType generates an external types.DeployPolicy to be shared over REST
+func (p *DeployPolicy) ToDeployPolicyType() *types.DeployPolicy {
+	return &types.DeployPolicy{
+		RequireResourceLimits: p.RequireResourceLimits,
+		ForbidPrivileged:      p.ForbidPrivileged,
+		ForbidHostPath:        p.ForbidHostPath,
+		RequiredLabels:        splitDeployPolicyList(p.RequiredLabels),
+		AllowedRegistries:     splitDeployPolicyList(p.AllowedRegistries),
+		PinImageDigests:       p.PinImageDigests,
+	}
+}
+
+func splitDeployPolicyList(list string) []string {
+	res := make([]string, 0)
+
+	for _, elem := range strings.Split(list, ",") {
+		if elem != "" {
+			res = append(res, elem)
+		}
+	}
+
+	return res
+}
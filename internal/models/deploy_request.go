@@ -0,0 +1,77 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ProtectedResource is a namespace, or a release in a namespace, whose upgrades
+// must be approved
+type ProtectedResource struct {
+	gorm.Model
+
+	ProjectID       uint `gorm:"index"`
+	CreatedByUserID uint
+
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+}
+
+// ToProtectedResourceType generates an external types.ProtectedResource to be shared over REST
+func (p *ProtectedResource) ToProtectedResourceType() *types.ProtectedResource {
+	return &types.ProtectedResource{
+		ID:              p.ID,
+		CreatedAt:       p.CreatedAt,
+		ProjectID:       p.ProjectID,
+		CreatedByUserID: p.CreatedByUserID,
+		ClusterID:       p.ClusterID,
+		Namespace:       p.Namespace,
+		ReleaseName:     p.ReleaseName,
+	}
+}
+
+// DeployRequest is an upgrade of a protected release which is applied once it has
+// been approved
+type DeployRequest struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	Namespace string
+	Name      string
+
+	Source       types.DeployRequestSource
+	ChartVersion string
+
+	// Values are the values of the upgrade, which are encrypted since they may
+	// contain secrets
+	Values []byte
+
+	RequestedByUserID uint
+
+	Status           types.DeployRequestStatus
+	ReviewedByUserID uint
+	ReviewComment    string
+	Error            string
+}
+
+// ToDeployRequestType generates an external types.DeployRequest to be shared over REST
+func (d *DeployRequest) ToDeployRequestType() *types.DeployRequest {
+	return &types.DeployRequest{
+		ID:                d.ID,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		ProjectID:         d.ProjectID,
+		ClusterID:         d.ClusterID,
+		Namespace:         d.Namespace,
+		Name:              d.Name,
+		Source:            d.Source,
+		ChartVersion:      d.ChartVersion,
+		RequestedByUserID: d.RequestedByUserID,
+		Status:            d.Status,
+		ReviewedByUserID:  d.ReviewedByUserID,
+		ReviewComment:     d.ReviewComment,
+		Error:             d.Error,
+	}
+}
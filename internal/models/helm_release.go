@@ -0,0 +1,25 @@
+package models
+
+import "gorm.io/gorm"
+
+// HelmRelease is a revision of a Helm release stored by the SQL storage driver, for
+// clusters which store their releases in Porter's database instead of in secrets
+type HelmRelease struct {
+	gorm.Model
+
+	ClusterID uint   `gorm:"uniqueIndex:idx_helm_releases_key"`
+	Namespace string `gorm:"uniqueIndex:idx_helm_releases_key"`
+
+	// Key is the storage key of the revision, such as sh.helm.release.v1.web.v2
+	Key string `gorm:"uniqueIndex:idx_helm_releases_key"`
+
+	// Name, Owner, Status and Version are the labels which Helm queries releases by
+	Name    string `gorm:"index"`
+	Owner   string
+	Status  string
+	Version int
+
+	// Body is the gzipped JSON of the release, which is encrypted since the values
+	// of a release may contain secrets
+	Body []byte
+}
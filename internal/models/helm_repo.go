@@ -22,6 +22,14 @@ type HelmRepo struct {
 	// GCS it may be gs://
 	RepoURL string `json:"repo_url"`
 
+	// VerifyProvenance enforces that charts loaded from the repo are signed by a key
+	// in the keyring
+	VerifyProvenance bool `json:"verify_provenance"`
+
+	// Keyring is the PGP public keyring which verifies the provenance files of
+	// charts loaded from the repo
+	Keyring []byte `json:"keyring"`
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -49,9 +57,11 @@ type HelmRepoExternal struct {
 // ToHelmRepoType generates an external HelmRepo to be shared over REST
 func (hr *HelmRepo) ToHelmRepoType() *types.HelmRepo {
 	return &types.HelmRepo{
-		ID:        hr.ID,
-		ProjectID: hr.ProjectID,
-		Name:      hr.Name,
-		RepoURL:   hr.RepoURL,
+		ID:               hr.ID,
+		ProjectID:        hr.ProjectID,
+		Name:             hr.Name,
+		RepoURL:          hr.RepoURL,
+		VerifyProvenance: hr.VerifyProvenance,
+		Keyring:          string(hr.Keyring),
 	}
 }
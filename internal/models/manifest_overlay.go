@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ManifestOverlay is a patch which is applied to the rendered manifests of a release
+// when it is installed or upgraded
+type ManifestOverlay struct {
+	gorm.Model
+
+	ProjectID   uint `gorm:"index"`
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+
+	Kind      string
+	Name      string
+	PatchType types.ManifestOverlayPatchType
+
+	// Patch is the JSON of the patch
+	Patch []byte
+}
+
+// ToManifestOverlayType generates an external types.ManifestOverlay to be shared over REST
+func (o *ManifestOverlay) ToManifestOverlayType() *types.ManifestOverlay {
+	var patch interface{}
+
+	json.Unmarshal(o.Patch, &patch)
+
+	return &types.ManifestOverlay{
+		Kind:      o.Kind,
+		Name:      o.Name,
+		PatchType: o.PatchType,
+		Patch:     patch,
+	}
+}
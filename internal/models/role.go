@@ -20,8 +20,9 @@ type Role struct {
 
 func (r *Role) ToRoleType() *types.Role {
 	return &types.Role{
-		Kind:      r.Kind,
-		UserID:    r.UserID,
-		ProjectID: r.ProjectID,
+		Kind:         r.Kind,
+		UserID:       r.UserID,
+		ProjectID:    r.ProjectID,
+		CustomRoleID: r.CustomRoleID,
 	}
 }
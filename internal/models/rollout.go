@@ -0,0 +1,55 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RolloutConfig configures how upgrades of a web release are rolled out
+type RolloutConfig struct {
+	gorm.Model
+
+	Strategy types.RolloutStrategy
+
+	// Steps is a comma-separated list of the percentages of traffic sent to the canary
+	Steps string
+
+	StepIntervalSeconds uint
+	MaxErrorRate        float64
+}
+
+func (conf *RolloutConfig) ToRolloutConfigType() *types.RolloutConfig {
+	return &types.RolloutConfig{
+		Strategy:            conf.Strategy,
+		Steps:               conf.GetSteps(),
+		StepIntervalSeconds: conf.StepIntervalSeconds,
+		MaxErrorRate:        conf.MaxErrorRate,
+	}
+}
+
+// GetSteps parses the canary steps of the config
+func (conf *RolloutConfig) GetSteps() []uint {
+	steps := make([]uint, 0)
+
+	for _, step := range strings.Split(conf.Steps, ",") {
+		if weight, err := strconv.ParseUint(strings.TrimSpace(step), 10, 32); err == nil {
+			steps = append(steps, uint(weight))
+		}
+	}
+
+	return steps
+}
+
+// SetSteps sets the canary steps of the config
+func (conf *RolloutConfig) SetSteps(steps []uint) {
+	strSteps := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		strSteps = append(strSteps, strconv.FormatUint(uint64(step), 10))
+	}
+
+	conf.Steps = strings.Join(strSteps, ",")
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ServiceAccount is a non-human member of a project. Each service account has a
+// user which holds its role in the project and is the issuer of its API tokens.
+type ServiceAccount struct {
+	gorm.Model
+
+	ProjectID       uint `gorm:"index"`
+	CreatedByUserID uint
+
+	Name string
+
+	UserID uint
+}
+
+// GetServiceAccountEmail returns the unique email of the user of a service account,
+// which is never used for login
+func GetServiceAccountEmail(projectID uint, name string) string {
+	return fmt.Sprintf("%s@project-%d.serviceaccount.porter", name, projectID)
+}
+
+// ToServiceAccountType generates an external types.ServiceAccount to be shared over REST
+func (s *ServiceAccount) ToServiceAccountType(role *Role) *types.ServiceAccount {
+	res := &types.ServiceAccount{
+		ID:              s.ID,
+		CreatedAt:       s.CreatedAt,
+		ProjectID:       s.ProjectID,
+		CreatedByUserID: s.CreatedByUserID,
+		Name:            s.Name,
+		UserID:          s.UserID,
+	}
+
+	if role != nil {
+		res.Role = role.ToRoleType()
+	}
+
+	return res
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"github.com/porter-dev/porter/api/types"
+
 	"gorm.io/gorm"
 )
 
@@ -15,4 +17,26 @@ type Session struct {
 	Data []byte
 	// Time the session will expire
 	ExpiresAt time.Time
+
+	// The user that the session is authenticated as, or 0 if the session is not
+	// authenticated
+	UserID uint `gorm:"index"`
+
+	// The client that last used the session
+	IPAddress  string
+	UserAgent  string
+	LastSeenAt time.Time
+}
+
+// ToSessionType generates an external types.Session to be shared over REST
+func (s *Session) ToSessionType(currentKey string) *types.Session {
+	return &types.Session{
+		ID:         s.ID,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		IPAddress:  s.IPAddress,
+		UserAgent:  s.UserAgent,
+		Current:    currentKey != "" && s.Key == currentKey,
+	}
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// Team is a group of project collaborators which are granted the policy of the
+// team's role in addition to their own role
+type Team struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	Name      string
+
+	Kind         types.RoleKind
+	CustomRoleID uint
+}
+
+// TeamMember is the membership of a user in a team
+type TeamMember struct {
+	gorm.Model
+
+	TeamID uint `gorm:"index"`
+	UserID uint `gorm:"index"`
+}
+
+// ToTeamType generates an external types.Team to be shared over REST. The members
+// are looked up by id in the given map of users.
+func (t *Team) ToTeamType(members []*TeamMember, users map[uint]*User) *types.Team {
+	res := &types.Team{
+		ID:           t.ID,
+		CreatedAt:    t.CreatedAt,
+		ProjectID:    t.ProjectID,
+		Name:         t.Name,
+		Kind:         t.Kind,
+		CustomRoleID: t.CustomRoleID,
+		Members:      make([]*types.TeamMember, 0),
+	}
+
+	for _, member := range members {
+		teamMember := &types.TeamMember{
+			UserID: member.UserID,
+		}
+
+		if user, ok := users[member.UserID]; ok {
+			teamMember.Email = user.Email
+		}
+
+		res.Members = append(res.Members, teamMember)
+	}
+
+	return res
+}
@@ -19,6 +19,26 @@ type User struct {
 	// The github user id used for login (optional)
 	GithubUserID int64
 	GoogleUserID string
+
+	// The issuer and subject of the user in the generic OIDC provider used for
+	// login (optional)
+	OIDCIssuer string `gorm:"column:oidc_issuer"`
+	OIDCUserID string `gorm:"column:oidc_user_id"`
+
+	// TOTP two-factor authentication for basic login (optional). The secret is
+	// encrypted at rest, and recovery codes are stored as a comma-separated list
+	// of bcrypt hashes which are removed once used.
+	TOTPEnabled       bool
+	TOTPSecret        []byte
+	TOTPRecoveryCodes string
+
+	// TOTPLastCounter is the time step of the last accepted TOTP code. Codes from this
+	// time step or earlier are rejected, so that a code cannot be replayed.
+	TOTPLastCounter uint64
+
+	// ServiceAccount is set if the user is the identity of a service account. These
+	// users have no credentials, and can only authenticate with API tokens.
+	ServiceAccount bool
 }
 
 // ToUserType generates an external types.User to be shared over REST
@@ -27,5 +47,6 @@ func (u *User) ToUserType() *types.User {
 		ID:            u.ID,
 		Email:         u.Email,
 		EmailVerified: u.EmailVerified,
+		TOTPEnabled:   u.TOTPEnabled,
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
 	"golang.org/x/oauth2"
+	"oras.land/oras-go/pkg/registry/remote/auth"
 
 	ints "github.com/porter-dev/porter/internal/models/integrations"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/cli/config/types"
+	"github.com/docker/distribution/reference"
 )
 
 // Registry wraps the gorm Registry model
@@ -812,6 +814,19 @@ func (r *Registry) GetDockerConfigJSON(
 	repo repository.Repository,
 	doAuth *oauth2.Config, // only required if using DOCR
 ) ([]byte, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(conf)
+}
+
+func (r *Registry) getDockerConfigFile(
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) (*configfile.ConfigFile, error) {
 	var conf *configfile.ConfigFile
 	var err error
 
@@ -832,11 +847,111 @@ func (r *Registry) GetDockerConfigJSON(
 		conf, err = r.getPrivateRegistryDockerConfigFile(repo)
 	}
 
+	return conf, err
+}
+
+// manifestMediaTypes are the media types of image manifests and manifest lists which
+// are accepted when resolving the digest of a tag
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// GetImageDigest resolves the tag of an image in the registry to the digest of its
+// manifest, in the form sha256:<hex>. Images without a tag resolve the latest tag.
+func (r *Registry) GetImageDigest(
+	image string,
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) (string, error) {
+	conf, err := r.getDockerConfigFile(repo, doAuth)
+
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return json.Marshal(conf)
+	cred := auth.EmptyCredential
+
+	// the docker config of a registry only has credentials for that registry
+	if conf != nil {
+		for _, authConf := range conf.AuthConfigs {
+			cred = auth.Credential{
+				Username: authConf.Username,
+				Password: authConf.Password,
+			}
+		}
+	}
+
+	return getImageDigest(image, cred)
+}
+
+// GetPublicImageDigest resolves the tag of an image in a public registry, which is
+// not linked to a project, to the digest of its manifest
+func GetPublicImageDigest(image string) (string, error) {
+	return getImageDigest(image, auth.EmptyCredential)
+}
+
+func getImageDigest(image string, cred auth.Credential) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return "", err
+	}
+
+	named = reference.TagNameOnly(named)
+	tagged, _ := named.(reference.Tagged)
+
+	host := reference.Domain(named)
+	path := reference.Path(named)
+
+	// the registry API of docker hub is not served from the docker.io domain
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	client := &auth.Client{
+		Cache: auth.NewCache(),
+		Credential: func(ctx context.Context, reg string) (auth.Credential, error) {
+			return cred, nil
+		},
+	}
+
+	ctx := auth.WithScopes(context.Background(), auth.ScopeRepository(path, auth.ActionPull))
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"HEAD",
+		fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, path, tagged.Tag()),
+		nil,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not read the manifest of %s: %s", image, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return the digest of %s", image)
+	}
+
+	return digest, nil
 }
 
 func (r *Registry) getECRDockerConfigFile(
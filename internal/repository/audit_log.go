@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditLogRepository represents the set of queries on the AuditLog model
+type AuditLogRepository interface {
+	CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error)
+	ListAuditLogsByProjectID(projectID uint, opts *types.ListAuditLogsRequest) ([]*models.AuditLog, int64, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// CustomRoleRepository represents the set of queries on the CustomRole model
+type CustomRoleRepository interface {
+	CreateCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+	ReadCustomRole(projectID, id uint) (*models.CustomRole, error)
+	ReadCustomRoleByName(projectID uint, name string) (*models.CustomRole, error)
+	ListCustomRolesByProjectID(projectID uint) ([]*models.CustomRole, error)
+	UpdateCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+	DeleteCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// DeployPolicyRepository represents the set of queries on the DeployPolicy model
+type DeployPolicyRepository interface {
+	CreateDeployPolicy(policy *models.DeployPolicy) (*models.DeployPolicy, error)
+	ReadDeployPolicy(projID uint) (*models.DeployPolicy, error)
+	UpdateDeployPolicy(policy *models.DeployPolicy) (*models.DeployPolicy, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// DeployRequestRepository represents the set of queries on the DeployRequest model
+type DeployRequestRepository interface {
+	CreateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error)
+	ReadDeployRequest(projectID, id uint) (*models.DeployRequest, error)
+	ListDeployRequestsByProjectID(projectID uint, status types.DeployRequestStatus) ([]*models.DeployRequest, error)
+	UpdateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error)
+	ReviewDeployRequest(dr *models.DeployRequest, status types.DeployRequestStatus, reviewerID uint, comment string) (bool, error)
+}
@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository uses gorm.DB for querying the database
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAPITokenRepository returns a APITokenRepository which uses
+// gorm.DB for querying the database
+func NewAPITokenRepository(db *gorm.DB) repository.APITokenRepository {
+	return &APITokenRepository{db}
+}
+
+// CreateAPIToken creates a new API token
+func (repo *APITokenRepository) CreateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if err := repo.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ReadAPIToken finds an API token in a project by its unique id
+func (repo *APITokenRepository) ReadAPIToken(projectID uint, uid string) (*models.APIToken, error) {
+	token := &models.APIToken{}
+
+	if err := repo.db.Where("project_id = ? AND unique_id = ?", projectID, uid).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ReadAPITokenByID finds an API token in a project by its database id
+func (repo *APITokenRepository) ReadAPITokenByID(projectID, id uint) (*models.APIToken, error) {
+	token := &models.APIToken{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListAPITokensByProjectID lists all API tokens for a project, including revoked
+// and expired tokens
+func (repo *APITokenRepository) ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error) {
+	tokens := make([]*models.APIToken, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// UpdateAPIToken modifies an existing APIToken in the database
+func (repo *APITokenRepository) UpdateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if err := repo.db.Save(token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
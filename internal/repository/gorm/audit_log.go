@@ -0,0 +1,91 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository uses gorm.DB for querying the database
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository returns an AuditLogRepository which uses
+// gorm.DB for querying the database
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &AuditLogRepository{db}
+}
+
+// CreateAuditLog creates a new audit log
+func (repo *AuditLogRepository) CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error) {
+	if err := repo.db.Create(log).Error; err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// ListAuditLogsByProjectID finds the audit logs for a project with the given
+// filters, most recent first. The total count of matching logs is returned along
+// with the page of logs.
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	opts *types.ListAuditLogsRequest,
+) ([]*models.AuditLog, int64, error) {
+	listOpts := opts
+
+	if listOpts.Limit == 0 {
+		listOpts.Limit = 50
+	}
+
+	logs := []*models.AuditLog{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if listOpts.UserID != 0 {
+		query = query.Where("user_id = ?", listOpts.UserID)
+	}
+
+	if listOpts.APITokenID != 0 {
+		query = query.Where("api_token_id = ?", listOpts.APITokenID)
+	}
+
+	if listOpts.Verb != "" {
+		query = query.Where("verb = ?", listOpts.Verb)
+	}
+
+	if listOpts.Outcome != "" {
+		query = query.Where("outcome = ?", listOpts.Outcome)
+	}
+
+	if listOpts.ScopePath != "" {
+		query = query.Where("scope_path = ? OR scope_path LIKE ?", listOpts.ScopePath, listOpts.ScopePath+"/%")
+	}
+
+	if listOpts.Since != 0 {
+		query = query.Where("created_at >= ?", time.Unix(listOpts.Since, 0))
+	}
+
+	if listOpts.Until != 0 {
+		query = query.Where("created_at < ?", time.Unix(listOpts.Until, 0))
+	}
+
+	// get the count before limit and offset
+	var count int64
+
+	if err := query.Model([]*models.AuditLog{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Limit(listOpts.Limit).Offset(listOpts.Skip)
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
@@ -0,0 +1,106 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListAuditLogsByProjectID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_logs.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projID := tester.initProjects[0].Model.ID
+
+	logs := []*models.AuditLog{
+		{
+			ProjectID:  projID,
+			UserID:     1,
+			ScopePath:  "project/1/cluster/1/namespace/default/release/web",
+			Verb:       types.APIVerbUpdate,
+			StatusCode: 200,
+			Outcome:    types.AuditLogOutcomeSuccess,
+		},
+		{
+			ProjectID:  projID,
+			UserID:     2,
+			ScopePath:  "project/1/cluster/1/namespace/default/release/web",
+			Verb:       types.APIVerbDelete,
+			StatusCode: 403,
+			Outcome:    types.AuditLogOutcomeFailure,
+		},
+		{
+			ProjectID:  projID,
+			APITokenID: 1,
+			ScopePath:  "project/1/cluster/10",
+			Verb:       types.APIVerbUpdate,
+			StatusCode: 200,
+			Outcome:    types.AuditLogOutcomeSuccess,
+		},
+		{
+			ProjectID:  projID + 1,
+			UserID:     1,
+			ScopePath:  "project/2",
+			Verb:       types.APIVerbCreate,
+			StatusCode: 200,
+			Outcome:    types.AuditLogOutcomeSuccess,
+		},
+	}
+
+	for _, log := range logs {
+		if _, err := tester.repo.AuditLog().CreateAuditLog(log); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	tests := []struct {
+		opts     *types.ListAuditLogsRequest
+		expIDs   []uint
+		expCount int64
+	}{
+		{&types.ListAuditLogsRequest{}, []uint{3, 2, 1}, 3},
+		{&types.ListAuditLogsRequest{Limit: 1, Skip: 1}, []uint{2}, 3},
+		{&types.ListAuditLogsRequest{UserID: 1}, []uint{1}, 1},
+		{&types.ListAuditLogsRequest{APITokenID: 1}, []uint{3}, 1},
+		{&types.ListAuditLogsRequest{Verb: types.APIVerbUpdate}, []uint{3, 1}, 2},
+		{&types.ListAuditLogsRequest{Outcome: types.AuditLogOutcomeFailure}, []uint{2}, 1},
+		// a scope path matches its children, but not siblings with the same prefix
+		{&types.ListAuditLogsRequest{ScopePath: "project/1/cluster/1"}, []uint{2, 1}, 2},
+	}
+
+	for i, test := range tests {
+		res, count, err := tester.repo.AuditLog().ListAuditLogsByProjectID(projID, test.opts)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if count != test.expCount {
+			t.Errorf("test %d: expected count %d, got %d", i, test.expCount, count)
+		}
+
+		ids := make([]uint, 0)
+
+		for _, log := range res {
+			ids = append(ids, log.ID)
+		}
+
+		if len(ids) != len(test.expIDs) {
+			t.Errorf("test %d: expected ids %v, got %v", i, test.expIDs, ids)
+			continue
+		}
+
+		for j := range ids {
+			if ids[j] != test.expIDs[j] {
+				t.Errorf("test %d: expected ids %v, got %v", i, test.expIDs, ids)
+				break
+			}
+		}
+	}
+}
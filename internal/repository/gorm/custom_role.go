@@ -0,0 +1,78 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CustomRoleRepository uses gorm.DB for querying the database
+type CustomRoleRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomRoleRepository returns a CustomRoleRepository which uses
+// gorm.DB for querying the database
+func NewCustomRoleRepository(db *gorm.DB) repository.CustomRoleRepository {
+	return &CustomRoleRepository{db}
+}
+
+// CreateCustomRole creates a new custom role
+func (repo *CustomRoleRepository) CreateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if err := repo.db.Create(role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// ReadCustomRole finds a custom role in a project by its id
+func (repo *CustomRoleRepository) ReadCustomRole(projectID, id uint) (*models.CustomRole, error) {
+	role := &models.CustomRole{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// ReadCustomRoleByName finds a custom role in a project by its name
+func (repo *CustomRoleRepository) ReadCustomRoleByName(projectID uint, name string) (*models.CustomRole, error) {
+	role := &models.CustomRole{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(&role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// ListCustomRolesByProjectID lists all custom roles for a project
+func (repo *CustomRoleRepository) ListCustomRolesByProjectID(projectID uint) ([]*models.CustomRole, error) {
+	roles := make([]*models.CustomRole, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// UpdateCustomRole modifies an existing CustomRole in the database
+func (repo *CustomRoleRepository) UpdateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if err := repo.db.Save(role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteCustomRole deletes a custom role
+func (repo *CustomRoleRepository) DeleteCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if err := repo.db.Delete(role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
@@ -0,0 +1,53 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeployPolicyRepository implements repository.DeployPolicyRepository
+type DeployPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewDeployPolicyRepository returns a DeployPolicyRepository which uses
+// gorm.DB for querying the database
+func NewDeployPolicyRepository(db *gorm.DB) repository.DeployPolicyRepository {
+	return &DeployPolicyRepository{db}
+}
+
+// CreateDeployPolicy creates a new deploy policy for a project
+func (repo *DeployPolicyRepository) CreateDeployPolicy(
+	policy *models.DeployPolicy,
+) (*models.DeployPolicy, error) {
+	if err := repo.db.Create(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadDeployPolicy finds the deploy policy matching a project ID
+func (repo *DeployPolicyRepository) ReadDeployPolicy(
+	projID uint,
+) (*models.DeployPolicy, error) {
+	res := &models.DeployPolicy{}
+
+	if err := repo.db.Where("project_id = ?", projID).First(res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateDeployPolicy modifies an existing DeployPolicy in the database
+func (repo *DeployPolicyRepository) UpdateDeployPolicy(
+	policy *models.DeployPolicy,
+) (*models.DeployPolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
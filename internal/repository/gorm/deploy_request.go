@@ -0,0 +1,168 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeployRequestRepository uses gorm.DB for querying the database
+type DeployRequestRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDeployRequestRepository returns a DeployRequestRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the values of deploy requests
+func NewDeployRequestRepository(db *gorm.DB, key *[32]byte) repository.DeployRequestRepository {
+	return &DeployRequestRepository{db, key}
+}
+
+// CreateDeployRequest creates a new deploy request
+func (repo *DeployRequestRepository) CreateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error) {
+	if err := repo.EncryptDeployRequestData(dr, repo.key); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(dr).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptDeployRequestData(dr, repo.key); err != nil {
+		return nil, err
+	}
+
+	return dr, nil
+}
+
+// ReadDeployRequest finds a deploy request in a project by its id
+func (repo *DeployRequestRepository) ReadDeployRequest(projectID, id uint) (*models.DeployRequest, error) {
+	dr := &models.DeployRequest{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&dr).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptDeployRequestData(dr, repo.key); err != nil {
+		return nil, err
+	}
+
+	return dr, nil
+}
+
+// ListDeployRequestsByProjectID lists the deploy requests for a project, most recent
+// first. If status is set, only deploy requests with that status are listed.
+func (repo *DeployRequestRepository) ListDeployRequestsByProjectID(
+	projectID uint,
+	status types.DeployRequestStatus,
+) ([]*models.DeployRequest, error) {
+	drs := make([]*models.DeployRequest, 0)
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("id desc").Find(&drs).Error; err != nil {
+		return nil, err
+	}
+
+	for _, dr := range drs {
+		if err := repo.DecryptDeployRequestData(dr, repo.key); err != nil {
+			return nil, err
+		}
+	}
+
+	return drs, nil
+}
+
+// UpdateDeployRequest modifies an existing DeployRequest in the database
+func (repo *DeployRequestRepository) UpdateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error) {
+	if err := repo.EncryptDeployRequestData(dr, repo.key); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Save(dr).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptDeployRequestData(dr, repo.key); err != nil {
+		return nil, err
+	}
+
+	return dr, nil
+}
+
+// ReviewDeployRequest moves a pending deploy request to the given status, and
+// records its reviewer. The status is only changed if the deploy request is still
+// pending, so it returns false if the deploy request was reviewed concurrently.
+func (repo *DeployRequestRepository) ReviewDeployRequest(
+	dr *models.DeployRequest,
+	status types.DeployRequestStatus,
+	reviewerID uint,
+	comment string,
+) (bool, error) {
+	res := repo.db.Model(&models.DeployRequest{}).
+		Where("id = ? AND status = ?", dr.ID, types.DeployRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":              status,
+			"reviewed_by_user_id": reviewerID,
+			"review_comment":      comment,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected != 1 {
+		return false, nil
+	}
+
+	dr.Status = status
+	dr.ReviewedByUserID = reviewerID
+	dr.ReviewComment = comment
+
+	return true, nil
+}
+
+// EncryptDeployRequestData will encrypt the deploy request values before
+// writing to the DB
+func (repo *DeployRequestRepository) EncryptDeployRequestData(
+	dr *models.DeployRequest,
+	key *[32]byte,
+) error {
+	if len(dr.Values) > 0 {
+		cipherData, err := encryption.Encrypt(dr.Values, key)
+
+		if err != nil {
+			return err
+		}
+
+		dr.Values = cipherData
+	}
+
+	return nil
+}
+
+// DecryptDeployRequestData will decrypt the deploy request values before
+// returning it from the DB
+func (repo *DeployRequestRepository) DecryptDeployRequestData(
+	dr *models.DeployRequest,
+	key *[32]byte,
+) error {
+	if len(dr.Values) > 0 {
+		plaintext, err := encryption.Decrypt(dr.Values, key)
+
+		if err != nil {
+			return err
+		}
+
+		dr.Values = plaintext
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestReviewDeployRequest(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_review_deploy_request.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	dr, err := tester.repo.DeployRequest().CreateDeployRequest(&models.DeployRequest{
+		ProjectID:         1,
+		ClusterID:         1,
+		Namespace:         "default",
+		Name:              "web",
+		Values:            []byte("{}"),
+		RequestedByUserID: 1,
+		Status:            types.DeployRequestStatusPending,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// both reviewers read the deploy request while it is pending
+	first, err := tester.repo.DeployRequest().ReadDeployRequest(1, dr.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	second, err := tester.repo.DeployRequest().ReadDeployRequest(1, dr.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reviewed, err := tester.repo.DeployRequest().ReviewDeployRequest(first, types.DeployRequestStatusApproved, 2, "lgtm")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reviewed {
+		t.Fatalf("expected first review to succeed\n")
+	}
+
+	reviewed, err = tester.repo.DeployRequest().ReviewDeployRequest(second, types.DeployRequestStatusApproved, 3, "")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if reviewed {
+		t.Fatalf("expected second review of the deploy request to fail\n")
+	}
+
+	stored, err := tester.repo.DeployRequest().ReadDeployRequest(1, dr.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if stored.Status != types.DeployRequestStatusApproved || stored.ReviewedByUserID != 2 || stored.ReviewComment != "lgtm" {
+		t.Fatalf("expected deploy request to be approved by user 2, got %s by user %d\n", stored.Status, stored.ReviewedByUserID)
+	}
+
+	if string(stored.Values) != "{}" {
+		t.Fatalf("expected values to be unchanged, got %s\n", stored.Values)
+	}
+}
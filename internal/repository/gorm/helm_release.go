@@ -0,0 +1,219 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// HelmReleaseRepository uses gorm.DB for querying the database
+type HelmReleaseRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewHelmReleaseRepository returns a HelmReleaseRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the bodies of releases
+func NewHelmReleaseRepository(db *gorm.DB, key *[32]byte) repository.HelmReleaseRepository {
+	return &HelmReleaseRepository{db, key}
+}
+
+// CreateHelmRelease creates a new Helm release revision, or returns
+// repository.ErrHelmReleaseExists if the key is already stored in the namespace
+func (repo *HelmReleaseRepository) CreateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if err := repo.EncryptHelmReleaseData(rel, repo.key); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(rel).Error; err != nil {
+		// the unique index on the key is violated when a concurrent create stored
+		// the same revision first
+		var count int64
+
+		if countErr := repo.db.Model(&models.HelmRelease{}).Where(
+			"cluster_id = ? AND namespace = ? AND key = ?", rel.ClusterID, rel.Namespace, rel.Key,
+		).Count(&count).Error; countErr == nil && count > 0 {
+			return nil, repository.ErrHelmReleaseExists
+		}
+
+		return nil, err
+	}
+
+	if err := repo.DecryptHelmReleaseData(rel, repo.key); err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+// ReadHelmRelease finds a Helm release revision by its storage key. An empty
+// namespace matches a revision in any namespace.
+func (repo *HelmReleaseRepository) ReadHelmRelease(
+	clusterID uint,
+	namespace, key string,
+) (*models.HelmRelease, error) {
+	rel := &models.HelmRelease{}
+
+	query := repo.db.Where("cluster_id = ? AND key = ?", clusterID, key)
+
+	if namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+
+	if err := query.First(rel).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptHelmReleaseData(rel, repo.key); err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+// ListHelmReleases lists the Helm release revisions of a cluster which match the
+// filter. An empty namespace lists revisions in all namespaces.
+func (repo *HelmReleaseRepository) ListHelmReleases(
+	clusterID uint,
+	namespace string,
+	filter *repository.HelmReleaseFilter,
+) ([]*models.HelmRelease, error) {
+	rels := make([]*models.HelmRelease, 0)
+
+	query := repo.filterHelmReleases(clusterID, namespace, filter)
+
+	if err := query.Order("id asc").Find(&rels).Error; err != nil {
+		return nil, err
+	}
+
+	for _, rel := range rels {
+		if err := repo.DecryptHelmReleaseData(rel, repo.key); err != nil {
+			return nil, err
+		}
+	}
+
+	return rels, nil
+}
+
+// ListHelmReleaseMetadata lists the Helm release revisions of a cluster which match
+// the filter, without reading or decrypting their bodies
+func (repo *HelmReleaseRepository) ListHelmReleaseMetadata(
+	clusterID uint,
+	namespace string,
+	filter *repository.HelmReleaseFilter,
+) ([]*models.HelmRelease, error) {
+	rels := make([]*models.HelmRelease, 0)
+
+	query := repo.filterHelmReleases(clusterID, namespace, filter).Select(
+		"id", "created_at", "updated_at", "cluster_id", "namespace", "key",
+		"name", "owner", "status", "version",
+	)
+
+	if err := query.Order("id asc").Find(&rels).Error; err != nil {
+		return nil, err
+	}
+
+	return rels, nil
+}
+
+func (repo *HelmReleaseRepository) filterHelmReleases(
+	clusterID uint,
+	namespace string,
+	filter *repository.HelmReleaseFilter,
+) *gorm.DB {
+	query := repo.db.Where("cluster_id = ?", clusterID)
+
+	if namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+
+	if filter != nil {
+		if filter.Key != "" {
+			query = query.Where("key = ?", filter.Key)
+		}
+
+		if filter.Name != "" {
+			query = query.Where("name = ?", filter.Name)
+		}
+
+		if filter.Owner != "" {
+			query = query.Where("owner = ?", filter.Owner)
+		}
+
+		if filter.Version != 0 {
+			query = query.Where("version = ?", filter.Version)
+		}
+
+		if len(filter.Statuses) > 0 {
+			query = query.Where("status IN ?", filter.Statuses)
+		}
+	}
+
+	return query
+}
+
+// UpdateHelmRelease modifies an existing Helm release revision in the database
+func (repo *HelmReleaseRepository) UpdateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if err := repo.EncryptHelmReleaseData(rel, repo.key); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Save(rel).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptHelmReleaseData(rel, repo.key); err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+// DeleteHelmRelease deletes a Helm release revision. The revision is deleted with
+// db.Unscoped(), so that a revision with the same key can be created again.
+func (repo *HelmReleaseRepository) DeleteHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if err := repo.db.Unscoped().Delete(rel).Error; err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+// EncryptHelmReleaseData will encrypt the release body before writing to the DB
+func (repo *HelmReleaseRepository) EncryptHelmReleaseData(
+	rel *models.HelmRelease,
+	key *[32]byte,
+) error {
+	if len(rel.Body) > 0 {
+		cipherData, err := encryption.Encrypt(rel.Body, key)
+
+		if err != nil {
+			return err
+		}
+
+		rel.Body = cipherData
+	}
+
+	return nil
+}
+
+// DecryptHelmReleaseData will decrypt the release body before returning it from
+// the DB
+func (repo *HelmReleaseRepository) DecryptHelmReleaseData(
+	rel *models.HelmRelease,
+	key *[32]byte,
+) error {
+	if len(rel.Body) > 0 {
+		plaintext, err := encryption.Decrypt(rel.Body, key)
+
+		if err != nil {
+			return err
+		}
+
+		rel.Body = plaintext
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+func TestHelmReleases(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_helm_releases.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	revisions := []*models.HelmRelease{
+		{ClusterID: 1, Namespace: "default", Key: "sh.helm.release.v1.web.v1", Name: "web", Owner: "helm", Status: "superseded", Version: 1, Body: []byte("web-1")},
+		{ClusterID: 1, Namespace: "default", Key: "sh.helm.release.v1.web.v2", Name: "web", Owner: "helm", Status: "deployed", Version: 2, Body: []byte("web-2")},
+		{ClusterID: 1, Namespace: "staging", Key: "sh.helm.release.v1.web.v1", Name: "web", Owner: "helm", Status: "deployed", Version: 1, Body: []byte("staging-web-1")},
+		{ClusterID: 2, Namespace: "default", Key: "sh.helm.release.v1.web.v1", Name: "web", Owner: "helm", Status: "deployed", Version: 1, Body: []byte("other-web-1")},
+	}
+
+	for _, rel := range revisions {
+		if _, err := tester.repo.HelmRelease().CreateHelmRelease(rel); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	// the body should be encrypted at rest
+	stored := &models.HelmRelease{}
+
+	if err := tester.db.Where("id = ?", revisions[0].ID).First(stored).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(stored.Body) == "web-1" {
+		t.Fatalf("expected release body to be encrypted\n")
+	}
+
+	rel, err := tester.repo.HelmRelease().ReadHelmRelease(1, "staging", "sh.helm.release.v1.web.v1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(rel.Body) != "staging-web-1" {
+		t.Fatalf("expected body staging-web-1, got %s\n", rel.Body)
+	}
+
+	rels, err := tester.repo.HelmRelease().ListHelmReleases(1, "", &repository.HelmReleaseFilter{
+		Name:     "web",
+		Statuses: []string{"deployed", "failed"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(rels) != 2 || string(rels[0].Body) != "web-2" || string(rels[1].Body) != "staging-web-1" {
+		t.Fatalf("expected the deployed revisions of cluster 1, got %v\n", rels)
+	}
+
+	rels, err = tester.repo.HelmRelease().ListHelmReleases(1, "default", nil)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(rels) != 2 {
+		t.Fatalf("expected 2 revisions in namespace default, got %d\n", len(rels))
+	}
+
+	// metadata is listed without the bodies of the revisions
+	rels, err = tester.repo.HelmRelease().ListHelmReleaseMetadata(1, "default", &repository.HelmReleaseFilter{
+		Statuses: []string{"deployed"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(rels) != 1 || rels[0].Key != "sh.helm.release.v1.web.v2" || rels[0].Version != 2 || len(rels[0].Body) != 0 {
+		t.Fatalf("expected the metadata of the deployed revision in namespace default, got %v\n", rels)
+	}
+
+	// deleted revisions can be created again with the same key
+	if _, err := tester.repo.HelmRelease().DeleteHelmRelease(revisions[0]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.HelmRelease().ReadHelmRelease(1, "default", "sh.helm.release.v1.web.v1")
+
+	if err != gorm.ErrRecordNotFound {
+		t.Fatalf("expected record not found, got %v\n", err)
+	}
+
+	if _, err := tester.repo.HelmRelease().CreateHelmRelease(&models.HelmRelease{
+		ClusterID: 1,
+		Namespace: "default",
+		Key:       "sh.helm.release.v1.web.v1",
+		Name:      "web",
+		Version:   1,
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// creating a key which is already stored in the namespace fails with
+	// ErrHelmReleaseExists
+	_, err = tester.repo.HelmRelease().CreateHelmRelease(&models.HelmRelease{
+		ClusterID: 1,
+		Namespace: "default",
+		Key:       "sh.helm.release.v1.web.v2",
+		Name:      "web",
+		Version:   2,
+	})
+
+	if !errors.Is(err, repository.ErrHelmReleaseExists) {
+		t.Fatalf("expected helm release exists, got %v\n", err)
+	}
+}
@@ -76,6 +76,15 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.KubeSubEvent{},
 		&models.Onboarding{},
 		&models.Allowlist{},
+		&models.AuditLog{},
+		&models.Team{},
+		&models.TeamMember{},
+		&models.ProtectedResource{},
+		&models.DeployRequest{},
+		&models.RolloutConfig{},
+		&models.HelmRelease{},
+		&models.ManifestOverlay{},
+		&models.DeployPolicy{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ManifestOverlayRepository uses gorm.DB for querying the database
+type ManifestOverlayRepository struct {
+	db *gorm.DB
+}
+
+// NewManifestOverlayRepository returns a ManifestOverlayRepository which uses
+// gorm.DB for querying the database
+func NewManifestOverlayRepository(db *gorm.DB) repository.ManifestOverlayRepository {
+	return &ManifestOverlayRepository{db}
+}
+
+// CreateManifestOverlay creates a new manifest overlay
+func (repo *ManifestOverlayRepository) CreateManifestOverlay(overlay *models.ManifestOverlay) (*models.ManifestOverlay, error) {
+	if err := repo.db.Create(overlay).Error; err != nil {
+		return nil, err
+	}
+
+	return overlay, nil
+}
+
+// ListManifestOverlays lists the overlays of a release, in the order in which they
+// are applied
+func (repo *ManifestOverlayRepository) ListManifestOverlays(
+	clusterID uint,
+	namespace, releaseName string,
+) ([]*models.ManifestOverlay, error) {
+	overlays := make([]*models.ManifestOverlay, 0)
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND release_name = ?",
+		clusterID, namespace, releaseName,
+	).Order("id asc").Find(&overlays).Error; err != nil {
+		return nil, err
+	}
+
+	return overlays, nil
+}
+
+// ReplaceManifestOverlays deletes the overlays of a release and creates the new
+// overlays in a single transaction
+func (repo *ManifestOverlayRepository) ReplaceManifestOverlays(
+	clusterID uint,
+	namespace, releaseName string,
+	overlays []*models.ManifestOverlay,
+) ([]*models.ManifestOverlay, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"cluster_id = ? AND namespace = ? AND release_name = ?",
+			clusterID, namespace, releaseName,
+		).Delete(&models.ManifestOverlay{}).Error; err != nil {
+			return err
+		}
+
+		for _, overlay := range overlays {
+			if err := tx.Create(overlay).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return overlays, nil
+}
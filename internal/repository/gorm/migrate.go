@@ -48,6 +48,18 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.CredentialsExchangeToken{},
 		&models.BuildConfig{},
 		&models.Allowlist{},
+		&models.APIToken{},
+		&models.CustomRole{},
+		&models.AuditLog{},
+		&models.ServiceAccount{},
+		&models.Team{},
+		&models.TeamMember{},
+		&models.ProtectedResource{},
+		&models.DeployRequest{},
+		&models.RolloutConfig{},
+		&models.HelmRelease{},
+		&models.ManifestOverlay{},
+		&models.DeployPolicy{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -0,0 +1,78 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ProtectedResourceRepository uses gorm.DB for querying the database
+type ProtectedResourceRepository struct {
+	db *gorm.DB
+}
+
+// NewProtectedResourceRepository returns a ProtectedResourceRepository which uses
+// gorm.DB for querying the database
+func NewProtectedResourceRepository(db *gorm.DB) repository.ProtectedResourceRepository {
+	return &ProtectedResourceRepository{db}
+}
+
+// CreateProtectedResource creates a new protected resource
+func (repo *ProtectedResourceRepository) CreateProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error) {
+	if err := repo.db.Create(resource).Error; err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// ReadProtectedResource finds a protected resource in a project by its id
+func (repo *ProtectedResourceRepository) ReadProtectedResource(projectID, id uint) (*models.ProtectedResource, error) {
+	resource := &models.ProtectedResource{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&resource).Error; err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// ReadProtectedResourceForRelease finds the protected resource which protects a
+// release, either directly or through its namespace
+func (repo *ProtectedResourceRepository) ReadProtectedResourceForRelease(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.ProtectedResource, error) {
+	resource := &models.ProtectedResource{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND (release_name = '' OR release_name = ?)",
+		projectID, clusterID, namespace, name,
+	)
+
+	if err := query.Order("id asc").First(&resource).Error; err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// ListProtectedResourcesByProjectID lists all protected resources for a project
+func (repo *ProtectedResourceRepository) ListProtectedResourcesByProjectID(projectID uint) ([]*models.ProtectedResource, error) {
+	resources := make([]*models.ProtectedResource, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&resources).Error; err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// DeleteProtectedResource deletes a protected resource
+func (repo *ProtectedResourceRepository) DeleteProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error) {
+	if err := repo.db.Delete(resource).Error; err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
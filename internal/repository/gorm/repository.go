@@ -41,6 +41,17 @@ type GormRepository struct {
 	ceToken                   repository.CredentialsExchangeTokenRepository
 	buildConfig               repository.BuildConfigRepository
 	allowlist                 repository.AllowlistRepository
+	apiToken                  repository.APITokenRepository
+	customRole                repository.CustomRoleRepository
+	auditLog                  repository.AuditLogRepository
+	serviceAccount            repository.ServiceAccountRepository
+	team                      repository.TeamRepository
+	protectedResource         repository.ProtectedResourceRepository
+	deployRequest             repository.DeployRequestRepository
+	rolloutConfig             repository.RolloutConfigRepository
+	helmRelease               repository.HelmReleaseRepository
+	manifestOverlay           repository.ManifestOverlayRepository
+	deployPolicy              repository.DeployPolicyRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -179,11 +190,55 @@ func (t *GormRepository) Allowlist() repository.AllowlistRepository {
 	return t.allowlist
 }
 
+func (t *GormRepository) APIToken() repository.APITokenRepository {
+	return t.apiToken
+}
+
+func (t *GormRepository) CustomRole() repository.CustomRoleRepository {
+	return t.customRole
+}
+
+func (t *GormRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
+func (t *GormRepository) ServiceAccount() repository.ServiceAccountRepository {
+	return t.serviceAccount
+}
+
+func (t *GormRepository) Team() repository.TeamRepository {
+	return t.team
+}
+
+func (t *GormRepository) ProtectedResource() repository.ProtectedResourceRepository {
+	return t.protectedResource
+}
+
+func (t *GormRepository) DeployRequest() repository.DeployRequestRepository {
+	return t.deployRequest
+}
+
+func (t *GormRepository) RolloutConfig() repository.RolloutConfigRepository {
+	return t.rolloutConfig
+}
+
+func (t *GormRepository) HelmRelease() repository.HelmReleaseRepository {
+	return t.helmRelease
+}
+
+func (t *GormRepository) ManifestOverlay() repository.ManifestOverlayRepository {
+	return t.manifestOverlay
+}
+
+func (t *GormRepository) DeployPolicy() repository.DeployPolicyRepository {
+	return t.deployPolicy
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
 	return &GormRepository{
-		user:                      NewUserRepository(db),
+		user:                      NewUserRepository(db, key),
 		session:                   NewSessionRepository(db),
 		project:                   NewProjectRepository(db),
 		cluster:                   NewClusterRepository(db, key),
@@ -217,5 +272,16 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		ceToken:                   NewCredentialsExchangeTokenRepository(db),
 		buildConfig:               NewBuildConfigRepository(db),
 		allowlist:                 NewAllowlistRepository(db),
+		apiToken:                  NewAPITokenRepository(db),
+		customRole:                NewCustomRoleRepository(db),
+		auditLog:                  NewAuditLogRepository(db),
+		serviceAccount:            NewServiceAccountRepository(db),
+		team:                      NewTeamRepository(db),
+		protectedResource:         NewProtectedResourceRepository(db),
+		deployRequest:             NewDeployRequestRepository(db, key),
+		rolloutConfig:             NewRolloutConfigRepository(db),
+		helmRelease:               NewHelmReleaseRepository(db, key),
+		manifestOverlay:           NewManifestOverlayRepository(db),
+		deployPolicy:              NewDeployPolicyRepository(db),
 	}
 }
@@ -0,0 +1,45 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type RolloutConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewRolloutConfigRepository creates a new RolloutConfigRepository
+func NewRolloutConfigRepository(db *gorm.DB) repository.RolloutConfigRepository {
+	return RolloutConfigRepository{db: db}
+}
+
+// CreateRolloutConfig creates a new RolloutConfig
+func (repo RolloutConfigRepository) CreateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error) {
+	if err := repo.db.Create(conf).Error; err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// ReadRolloutConfig reads a RolloutConfig by ID
+func (repo RolloutConfigRepository) ReadRolloutConfig(id uint) (*models.RolloutConfig, error) {
+	ret := &models.RolloutConfig{}
+
+	if err := repo.db.Where("id = ?", id).First(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// UpdateRolloutConfig updates a given RolloutConfig
+func (repo RolloutConfigRepository) UpdateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error) {
+	if err := repo.db.Save(conf).Error; err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepository uses gorm.DB for querying the database
+type ServiceAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceAccountRepository returns a ServiceAccountRepository which uses
+// gorm.DB for querying the database
+func NewServiceAccountRepository(db *gorm.DB) repository.ServiceAccountRepository {
+	return &ServiceAccountRepository{db}
+}
+
+// CreateServiceAccount creates a new service account
+func (repo *ServiceAccountRepository) CreateServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error) {
+	if err := repo.db.Create(sa).Error; err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// ReadServiceAccount finds a service account in a project by its id
+func (repo *ServiceAccountRepository) ReadServiceAccount(projectID, id uint) (*models.ServiceAccount, error) {
+	sa := &models.ServiceAccount{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&sa).Error; err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// ReadServiceAccountByName finds a service account in a project by its name
+func (repo *ServiceAccountRepository) ReadServiceAccountByName(projectID uint, name string) (*models.ServiceAccount, error) {
+	sa := &models.ServiceAccount{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(&sa).Error; err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// ReadServiceAccountByUserID finds the service account of a service account user
+func (repo *ServiceAccountRepository) ReadServiceAccountByUserID(userID uint) (*models.ServiceAccount, error) {
+	sa := &models.ServiceAccount{}
+
+	if err := repo.db.Where("user_id = ?", userID).First(&sa).Error; err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// ListServiceAccountsByProjectID lists all service accounts for a project
+func (repo *ServiceAccountRepository) ListServiceAccountsByProjectID(projectID uint) ([]*models.ServiceAccount, error) {
+	sas := make([]*models.ServiceAccount, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&sas).Error; err != nil {
+		return nil, err
+	}
+
+	return sas, nil
+}
+
+// DeleteServiceAccount deletes a service account
+func (repo *ServiceAccountRepository) DeleteServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error) {
+	if err := repo.db.Delete(sa).Error; err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
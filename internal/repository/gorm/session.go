@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -24,9 +26,13 @@ func (s *SessionRepository) CreateSession(session *models.Session) (*models.Sess
 	return session, nil
 }
 
-// UpdateSession updates only the Data field using Key as selector.
+// UpdateSession updates the Data field and the session metadata using Key as selector.
+// The user id is always written, so that it is cleared when a user logs out.
 func (s *SessionRepository) UpdateSession(session *models.Session) (*models.Session, error) {
-	if err := s.db.Model(session).Where("Key = ?", session.Key).Updates(session).Error; err != nil {
+	query := s.db.Model(session).Where("Key = ?", session.Key).
+		Select("data", "expires_at", "user_id", "ip_address", "user_agent", "last_seen_at")
+
+	if err := query.Updates(session).Error; err != nil {
 		return nil, err
 	}
 	return session, nil
@@ -51,3 +57,42 @@ func (s *SessionRepository) SelectSession(session *models.Session) (*models.Sess
 
 	return session, nil
 }
+
+// UpdateSessionActivity updates the last seen time and the client of a session using
+// Key as selector
+func (s *SessionRepository) UpdateSessionActivity(session *models.Session) (*models.Session, error) {
+	query := s.db.Model(session).Where("Key = ?", session.Key).
+		Select("ip_address", "user_agent", "last_seen_at")
+
+	if err := query.Updates(session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ListSessionsByUserID returns the unexpired sessions that are authenticated as a user
+func (s *SessionRepository) ListSessionsByUserID(userID uint) ([]*models.Session, error) {
+	sessions := make([]*models.Session, 0)
+
+	query := s.db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).Order("last_seen_at desc")
+
+	if err := query.Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeleteExpiredSessions permanently deletes sessions which expired before the given
+// time, along with sessions that have been deleted, and returns the number of
+// deleted rows
+func (s *SessionRepository) DeleteExpiredSessions(before time.Time) (int64, error) {
+	res := s.db.Unscoped().Where("expires_at < ? OR deleted_at IS NOT NULL", before).Delete(&models.Session{})
+
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,127 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TeamRepository uses gorm.DB for querying the database
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository returns a TeamRepository which uses
+// gorm.DB for querying the database
+func NewTeamRepository(db *gorm.DB) repository.TeamRepository {
+	return &TeamRepository{db}
+}
+
+// CreateTeam creates a new team
+func (repo *TeamRepository) CreateTeam(team *models.Team) (*models.Team, error) {
+	if err := repo.db.Create(team).Error; err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// ReadTeam finds a team in a project by its id
+func (repo *TeamRepository) ReadTeam(projectID, id uint) (*models.Team, error) {
+	team := &models.Team{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&team).Error; err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// ReadTeamByName finds a team in a project by its name
+func (repo *TeamRepository) ReadTeamByName(projectID uint, name string) (*models.Team, error) {
+	team := &models.Team{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(&team).Error; err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// ListTeamsByProjectID lists all teams for a project
+func (repo *TeamRepository) ListTeamsByProjectID(projectID uint) ([]*models.Team, error) {
+	teams := make([]*models.Team, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// ListTeamsByUserID lists all teams in a project which a user is a member of
+func (repo *TeamRepository) ListTeamsByUserID(projectID, userID uint) ([]*models.Team, error) {
+	teams := make([]*models.Team, 0)
+
+	query := repo.db.Joins("INNER JOIN team_members ON team_members.team_id = teams.id").
+		Where("teams.project_id = ? AND team_members.user_id = ? AND team_members.deleted_at IS NULL", projectID, userID).
+		Order("teams.id asc")
+
+	if err := query.Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// UpdateTeam modifies an existing Team in the database
+func (repo *TeamRepository) UpdateTeam(team *models.Team) (*models.Team, error) {
+	if err := repo.db.Save(team).Error; err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// DeleteTeam deletes a team along with its memberships
+func (repo *TeamRepository) DeleteTeam(team *models.Team) (*models.Team, error) {
+	if err := repo.db.Where("team_id = ?", team.ID).Delete(&models.TeamMember{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Delete(team).Error; err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// AddTeamMember adds a user to a team
+func (repo *TeamRepository) AddTeamMember(team *models.Team, userID uint) (*models.TeamMember, error) {
+	member := &models.TeamMember{
+		TeamID: team.ID,
+		UserID: userID,
+	}
+
+	if err := repo.db.Create(member).Error; err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveTeamMember removes a user from a team
+func (repo *TeamRepository) RemoveTeamMember(team *models.Team, userID uint) error {
+	return repo.db.Where("team_id = ? AND user_id = ?", team.ID, userID).Delete(&models.TeamMember{}).Error
+}
+
+// ListTeamMembers lists the memberships of a team
+func (repo *TeamRepository) ListTeamMembers(team *models.Team) ([]*models.TeamMember, error) {
+	members := make([]*models.TeamMember, 0)
+
+	if err := repo.db.Where("team_id = ?", team.ID).Order("id asc").Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
@@ -0,0 +1,82 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListTeamsByUserID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_teams.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projID := tester.initProjects[0].Model.ID
+
+	teams := []*models.Team{
+		{ProjectID: projID, Name: "backend", Kind: types.RoleDeveloper},
+		{ProjectID: projID, Name: "frontend", Kind: types.RoleViewer},
+		{ProjectID: projID + 1, Name: "backend", Kind: types.RoleAdmin},
+	}
+
+	for _, team := range teams {
+		if _, err := tester.repo.Team().CreateTeam(team); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	for _, team := range teams {
+		if _, err := tester.repo.Team().AddTeamMember(team, 1); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	if _, err := tester.repo.Team().AddTeamMember(teams[1], 2); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err := tester.repo.Team().ListTeamsByUserID(projID, 1)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(res) != 2 || res[0].Name != "backend" || res[1].Name != "frontend" {
+		t.Fatalf("expected teams backend and frontend, got %v\n", res)
+	}
+
+	// removed members should no longer be listed
+	if err := tester.repo.Team().RemoveTeamMember(teams[0], 1); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err = tester.repo.Team().ListTeamsByUserID(projID, 1)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(res) != 1 || res[0].Name != "frontend" {
+		t.Fatalf("expected team frontend, got %v\n", res)
+	}
+
+	// deleting a team should remove its members
+	if _, err := tester.repo.Team().DeleteTeam(teams[1]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err = tester.repo.Team().ListTeamsByUserID(projID, 2)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(res) != 0 {
+		t.Fatalf("expected no teams, got %v\n", res)
+	}
+}
@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"golang.org/x/crypto/bcrypt"
@@ -9,20 +10,31 @@ import (
 
 // UserRepository uses gorm.DB for querying the database
 type UserRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	key *[32]byte
 }
 
 // NewUserRepository returns a DefaultUserRepository which uses
-// gorm.DB for querying the database
-func NewUserRepository(db *gorm.DB) repository.UserRepository {
-	return &UserRepository{db}
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewUserRepository(db *gorm.DB, key *[32]byte) repository.UserRepository {
+	return &UserRepository{db, key}
 }
 
 // CreateUser adds a new User row to the Users table in the database
 func (repo *UserRepository) CreateUser(user *models.User) (*models.User, error) {
+	if err := repo.EncryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	if err := repo.db.Create(user).Error; err != nil {
 		return nil, err
 	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -32,6 +44,11 @@ func (repo *UserRepository) ReadUser(id uint) (*models.User, error) {
 	if err := repo.db.Where("id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -43,6 +60,12 @@ func (repo *UserRepository) ListUsersByIDs(ids []uint) ([]*models.User, error) {
 		return nil, err
 	}
 
+	for _, user := range users {
+		if err := repo.DecryptUserData(user, repo.key); err != nil {
+			return nil, err
+		}
+	}
+
 	return users, nil
 }
 
@@ -52,6 +75,11 @@ func (repo *UserRepository) ReadUserByEmail(email string) (*models.User, error)
 	if err := repo.db.Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -61,6 +89,11 @@ func (repo *UserRepository) ReadUserByGithubUserID(id int64) (*models.User, erro
 	if err := repo.db.Where("github_user_id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -70,15 +103,42 @@ func (repo *UserRepository) ReadUserByGoogleUserID(id string) (*models.User, err
 	if err := repo.db.Where("google_user_id = ?", id).First(&user).Error; err != nil {
 		return nil, err
 	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ReadUserByOIDCUserID finds a single user based on their subject in an OIDC issuer
+func (repo *UserRepository) ReadUserByOIDCUserID(issuer, id string) (*models.User, error) {
+	user := &models.User{}
+	if err := repo.db.Where("oidc_issuer = ? AND oidc_user_id = ?", issuer, id).First(&user).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
 // UpdateUser modifies an existing User in the database
 func (repo *UserRepository) UpdateUser(user *models.User) (*models.User, error) {
+	if err := repo.EncryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	if err := repo.db.Save(user).Error; err != nil {
 		return nil, err
 	}
 
+	if err := repo.DecryptUserData(user, repo.key); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -104,3 +164,40 @@ func (repo *UserRepository) CheckPassword(id int, pwd string) (bool, error) {
 
 	return true, nil
 }
+
+// EncryptUserData will encrypt the user's TOTP secret before writing to the DB
+func (repo *UserRepository) EncryptUserData(
+	user *models.User,
+	key *[32]byte,
+) error {
+	if len(user.TOTPSecret) > 0 {
+		cipherData, err := encryption.Encrypt(user.TOTPSecret, key)
+
+		if err != nil {
+			return err
+		}
+
+		user.TOTPSecret = cipherData
+	}
+
+	return nil
+}
+
+// DecryptUserData will decrypt the user's TOTP secret before returning it
+// from the DB
+func (repo *UserRepository) DecryptUserData(
+	user *models.User,
+	key *[32]byte,
+) error {
+	if len(user.TOTPSecret) > 0 {
+		plaintext, err := encryption.Decrypt(user.TOTPSecret, key)
+
+		if err != nil {
+			return err
+		}
+
+		user.TOTPSecret = plaintext
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-test/deep"
 	"github.com/porter-dev/porter/internal/models"
+	orm "gorm.io/gorm"
 )
 
 func TestListUsersByIDs(t *testing.T) {
@@ -104,3 +105,42 @@ func TestReadUserByGoogleUserID(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestReadUserByOIDCUserID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_read_user_oidc.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	user := &models.User{
+		Email:      "test@test.it",
+		OIDCIssuer: "https://idp.example.com",
+		OIDCUserID: "alsdkfjsldaf",
+	}
+
+	user, err := tester.repo.User().CreateUser(user)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readUser, err := tester.repo.User().ReadUserByOIDCUserID("https://idp.example.com", "alsdkfjsldaf")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(user, readUser); diff != nil {
+		t.Errorf("users not equal:")
+		t.Error(diff)
+	}
+
+	// the same subject in a different issuer is a different user
+	_, err = tester.repo.User().ReadUserByOIDCUserID("https://other.example.com", "alsdkfjsldaf")
+
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("expected record not found, got %v\n", err)
+	}
+}
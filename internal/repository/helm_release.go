@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ErrHelmReleaseExists is returned when creating a Helm release revision whose key
+// is already stored in the same cluster and namespace
+var ErrHelmReleaseExists = errors.New("helm release already exists")
+
+// HelmReleaseFilter filters the Helm releases of a cluster by their labels. Empty
+// fields are not filtered on.
+type HelmReleaseFilter struct {
+	Key      string
+	Name     string
+	Owner    string
+	Version  int
+	Statuses []string
+}
+
+// HelmReleaseRepository represents the set of queries on the Helm releases stored
+// by the SQL storage driver
+type HelmReleaseRepository interface {
+	CreateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error)
+	ReadHelmRelease(clusterID uint, namespace, key string) (*models.HelmRelease, error)
+	ListHelmReleases(clusterID uint, namespace string, filter *HelmReleaseFilter) ([]*models.HelmRelease, error)
+	ListHelmReleaseMetadata(clusterID uint, namespace string, filter *HelmReleaseFilter) ([]*models.HelmRelease, error)
+	UpdateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error)
+	DeleteHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ManifestOverlayRepository represents the set of queries on the ManifestOverlay model
+type ManifestOverlayRepository interface {
+	CreateManifestOverlay(overlay *models.ManifestOverlay) (*models.ManifestOverlay, error)
+	ListManifestOverlays(clusterID uint, namespace, releaseName string) ([]*models.ManifestOverlay, error)
+	ReplaceManifestOverlays(
+		clusterID uint,
+		namespace, releaseName string,
+		overlays []*models.ManifestOverlay,
+	) ([]*models.ManifestOverlay, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ProtectedResourceRepository represents the set of queries on the ProtectedResource model
+type ProtectedResourceRepository interface {
+	CreateProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error)
+	ReadProtectedResource(projectID, id uint) (*models.ProtectedResource, error)
+	ReadProtectedResourceForRelease(projectID, clusterID uint, namespace, name string) (*models.ProtectedResource, error)
+	ListProtectedResourcesByProjectID(projectID uint) ([]*models.ProtectedResource, error)
+	DeleteProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error)
+}
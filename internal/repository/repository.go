@@ -35,4 +35,15 @@ type Repository interface {
 	CredentialsExchangeToken() CredentialsExchangeTokenRepository
 	BuildConfig() BuildConfigRepository
 	Allowlist() AllowlistRepository
+	APIToken() APITokenRepository
+	CustomRole() CustomRoleRepository
+	AuditLog() AuditLogRepository
+	ServiceAccount() ServiceAccountRepository
+	Team() TeamRepository
+	ProtectedResource() ProtectedResourceRepository
+	DeployRequest() DeployRequestRepository
+	RolloutConfig() RolloutConfigRepository
+	HelmRelease() HelmReleaseRepository
+	ManifestOverlay() ManifestOverlayRepository
+	DeployPolicy() DeployPolicyRepository
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type RolloutConfigRepository interface {
+	CreateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error)
+	ReadRolloutConfig(id uint) (*models.RolloutConfig, error)
+	UpdateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ServiceAccountRepository represents the set of queries on the ServiceAccount model
+type ServiceAccountRepository interface {
+	CreateServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error)
+	ReadServiceAccount(projectID, id uint) (*models.ServiceAccount, error)
+	ReadServiceAccountByName(projectID uint, name string) (*models.ServiceAccount, error)
+	ReadServiceAccountByUserID(userID uint) (*models.ServiceAccount, error)
+	ListServiceAccountsByProjectID(projectID uint) ([]*models.ServiceAccount, error)
+	DeleteServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error)
+}
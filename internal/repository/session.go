@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -10,4 +12,7 @@ type SessionRepository interface {
 	UpdateSession(session *models.Session) (*models.Session, error)
 	DeleteSession(session *models.Session) (*models.Session, error)
 	SelectSession(session *models.Session) (*models.Session, error)
+	UpdateSessionActivity(session *models.Session) (*models.Session, error)
+	ListSessionsByUserID(userID uint) ([]*models.Session, error)
+	DeleteExpiredSessions(before time.Time) (int64, error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// TeamRepository represents the set of queries on the Team and TeamMember models
+type TeamRepository interface {
+	CreateTeam(team *models.Team) (*models.Team, error)
+	ReadTeam(projectID, id uint) (*models.Team, error)
+	ReadTeamByName(projectID uint, name string) (*models.Team, error)
+	ListTeamsByProjectID(projectID uint) ([]*models.Team, error)
+	ListTeamsByUserID(projectID, userID uint) ([]*models.Team, error)
+	UpdateTeam(team *models.Team) (*models.Team, error)
+	DeleteTeam(team *models.Team) (*models.Team, error)
+
+	AddTeamMember(team *models.Team, userID uint) (*models.TeamMember, error)
+	RemoveTeamMember(team *models.Team, userID uint) error
+	ListTeamMembers(team *models.Team) ([]*models.TeamMember, error)
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository stores API tokens in memory, indexed by their
+// array index + 1
+type APITokenRepository struct {
+	canQuery  bool
+	apiTokens []*models.APIToken
+}
+
+// NewAPITokenRepository will return errors if canQuery is false
+func NewAPITokenRepository(canQuery bool) repository.APITokenRepository {
+	return &APITokenRepository{canQuery, []*models.APIToken{}}
+}
+
+// CreateAPIToken creates a new API token
+func (repo *APITokenRepository) CreateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for _, t := range repo.apiTokens {
+		if t.UniqueID == token.UniqueID {
+			return nil, errors.New("Cannot write database")
+		}
+	}
+
+	repo.apiTokens = append(repo.apiTokens, token)
+	token.ID = uint(len(repo.apiTokens))
+
+	return token, nil
+}
+
+// ReadAPIToken finds an API token in a project by its unique id
+func (repo *APITokenRepository) ReadAPIToken(projectID uint, uid string) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, t := range repo.apiTokens {
+		if t.ProjectID == projectID && t.UniqueID == uid {
+			return t, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ReadAPITokenByID finds an API token in a project by its database id
+func (repo *APITokenRepository) ReadAPITokenByID(projectID, id uint) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.apiTokens) || repo.apiTokens[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.apiTokens[id-1], nil
+}
+
+// ListAPITokensByProjectID lists all API tokens for a project
+func (repo *APITokenRepository) ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.APIToken, 0)
+
+	for _, t := range repo.apiTokens {
+		if t.ProjectID == projectID {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateAPIToken modifies an existing APIToken in memory
+func (repo *APITokenRepository) UpdateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(token.ID-1) >= len(repo.apiTokens) || repo.apiTokens[token.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.apiTokens[token.ID-1] = token
+
+	return token, nil
+}
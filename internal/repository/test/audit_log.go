@@ -0,0 +1,89 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AuditLogRepository stores audit logs in memory
+type AuditLogRepository struct {
+	canQuery  bool
+	auditLogs []*models.AuditLog
+}
+
+// NewAuditLogRepository will return errors if canQuery is false
+func NewAuditLogRepository(canQuery bool) repository.AuditLogRepository {
+	return &AuditLogRepository{canQuery, []*models.AuditLog{}}
+}
+
+// CreateAuditLog creates a new audit log
+func (repo *AuditLogRepository) CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.auditLogs = append(repo.auditLogs, log)
+	log.ID = uint(len(repo.auditLogs))
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	return log, nil
+}
+
+// ListAuditLogsByProjectID finds the audit logs for a project with the given
+// filters, most recent first
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	opts *types.ListAuditLogsRequest,
+) ([]*models.AuditLog, int64, error) {
+	if !repo.canQuery {
+		return nil, 0, errors.New("Cannot read from database")
+	}
+
+	limit := opts.Limit
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	matches := make([]*models.AuditLog, 0)
+
+	// iterate in reverse, since logs are stored in the order they were created
+	for i := len(repo.auditLogs) - 1; i >= 0; i-- {
+		log := repo.auditLogs[i]
+
+		if log.ProjectID != projectID ||
+			(opts.UserID != 0 && log.UserID != opts.UserID) ||
+			(opts.APITokenID != 0 && log.APITokenID != opts.APITokenID) ||
+			(opts.Verb != "" && log.Verb != opts.Verb) ||
+			(opts.Outcome != "" && log.Outcome != opts.Outcome) ||
+			(opts.ScopePath != "" && log.ScopePath != opts.ScopePath && !strings.HasPrefix(log.ScopePath, opts.ScopePath+"/")) ||
+			(opts.Since != 0 && log.CreatedAt.Before(time.Unix(opts.Since, 0))) ||
+			(opts.Until != 0 && !log.CreatedAt.Before(time.Unix(opts.Until, 0))) {
+			continue
+		}
+
+		matches = append(matches, log)
+	}
+
+	count := int64(len(matches))
+
+	if opts.Skip >= len(matches) {
+		return []*models.AuditLog{}, count, nil
+	}
+
+	matches = matches[opts.Skip:]
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, count, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
 type BuildConfigRepository struct {
@@ -43,6 +44,9 @@ func (repo *BuildConfigRepository) GetBuildConfig(id uint) (*models.BuildConfig,
 		return nil, errors.New("cannot write database")
 	}
 
-	// TODO
-	return nil, nil
+	if int(id-1) >= len(repo.buildConfigs) || repo.buildConfigs[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.buildConfigs[id-1], nil
 }
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CustomRoleRepository stores custom roles in memory, indexed by their
+// array index + 1
+type CustomRoleRepository struct {
+	canQuery    bool
+	customRoles []*models.CustomRole
+}
+
+// NewCustomRoleRepository will return errors if canQuery is false
+func NewCustomRoleRepository(canQuery bool) repository.CustomRoleRepository {
+	return &CustomRoleRepository{canQuery, []*models.CustomRole{}}
+}
+
+// CreateCustomRole creates a new custom role
+func (repo *CustomRoleRepository) CreateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.customRoles = append(repo.customRoles, role)
+	role.ID = uint(len(repo.customRoles))
+
+	return role, nil
+}
+
+// ReadCustomRole finds a custom role in a project by its id
+func (repo *CustomRoleRepository) ReadCustomRole(projectID, id uint) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.customRoles) || repo.customRoles[id-1] == nil ||
+		repo.customRoles[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.customRoles[id-1], nil
+}
+
+// ReadCustomRoleByName finds a custom role in a project by its name
+func (repo *CustomRoleRepository) ReadCustomRoleByName(projectID uint, name string) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, role := range repo.customRoles {
+		if role != nil && role.ProjectID == projectID && role.Name == name {
+			return role, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListCustomRolesByProjectID lists all custom roles for a project
+func (repo *CustomRoleRepository) ListCustomRolesByProjectID(projectID uint) ([]*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.CustomRole, 0)
+
+	for _, role := range repo.customRoles {
+		if role != nil && role.ProjectID == projectID {
+			res = append(res, role)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateCustomRole modifies an existing CustomRole in memory
+func (repo *CustomRoleRepository) UpdateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if role.ID == 0 || int(role.ID-1) >= len(repo.customRoles) || repo.customRoles[role.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.customRoles[role.ID-1] = role
+
+	return role, nil
+}
+
+// DeleteCustomRole removes a custom role from memory
+func (repo *CustomRoleRepository) DeleteCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if role.ID == 0 || int(role.ID-1) >= len(repo.customRoles) || repo.customRoles[role.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.customRoles[role.ID-1] = nil
+
+	return role, nil
+}
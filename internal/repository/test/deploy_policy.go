@@ -0,0 +1,76 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeployPolicyRepository implements repository.DeployPolicyRepository
+type DeployPolicyRepository struct {
+	canQuery bool
+	policies []*models.DeployPolicy
+}
+
+// NewDeployPolicyRepository will return errors if canQuery is false
+func NewDeployPolicyRepository(canQuery bool) repository.DeployPolicyRepository {
+	return &DeployPolicyRepository{
+		canQuery,
+		[]*models.DeployPolicy{},
+	}
+}
+
+// CreateDeployPolicy creates a new deploy policy for a project
+func (repo *DeployPolicyRepository) CreateDeployPolicy(
+	policy *models.DeployPolicy,
+) (*models.DeployPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if policy == nil {
+		return nil, nil
+	}
+
+	repo.policies = append(repo.policies, policy)
+	policy.ID = uint(len(repo.policies))
+
+	return policy, nil
+}
+
+// ReadDeployPolicy reads a deploy policy by project id
+func (repo *DeployPolicyRepository) ReadDeployPolicy(
+	projID uint,
+) (*models.DeployPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projID {
+			return policy, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// UpdateDeployPolicy modifies an existing DeployPolicy in the database
+func (repo *DeployPolicyRepository) UpdateDeployPolicy(
+	policy *models.DeployPolicy,
+) (*models.DeployPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	index := int(policy.ID - 1)
+	repo.policies[index] = policy
+
+	return policy, nil
+}
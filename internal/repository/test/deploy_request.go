@@ -0,0 +1,118 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeployRequestRepository stores deploy requests in memory, indexed by their
+// array index + 1
+type DeployRequestRepository struct {
+	canQuery       bool
+	deployRequests []*models.DeployRequest
+}
+
+// NewDeployRequestRepository will return errors if canQuery is false
+func NewDeployRequestRepository(canQuery bool) repository.DeployRequestRepository {
+	return &DeployRequestRepository{canQuery, []*models.DeployRequest{}}
+}
+
+// CreateDeployRequest creates a new deploy request
+func (repo *DeployRequestRepository) CreateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.deployRequests = append(repo.deployRequests, dr)
+	dr.ID = uint(len(repo.deployRequests))
+
+	return dr, nil
+}
+
+// ReadDeployRequest finds a deploy request in a project by its id
+func (repo *DeployRequestRepository) ReadDeployRequest(projectID, id uint) (*models.DeployRequest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.deployRequests) || repo.deployRequests[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.deployRequests[id-1], nil
+}
+
+// ListDeployRequestsByProjectID lists the deploy requests for a project, most recent
+// first. If status is set, only deploy requests with that status are listed.
+func (repo *DeployRequestRepository) ListDeployRequestsByProjectID(
+	projectID uint,
+	status types.DeployRequestStatus,
+) ([]*models.DeployRequest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.DeployRequest, 0)
+
+	for i := len(repo.deployRequests) - 1; i >= 0; i-- {
+		dr := repo.deployRequests[i]
+
+		if dr.ProjectID == projectID && (status == "" || dr.Status == status) {
+			res = append(res, dr)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateDeployRequest modifies an existing DeployRequest in memory
+func (repo *DeployRequestRepository) UpdateDeployRequest(dr *models.DeployRequest) (*models.DeployRequest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if dr.ID == 0 || int(dr.ID-1) >= len(repo.deployRequests) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployRequests[dr.ID-1] = dr
+
+	return dr, nil
+}
+
+// ReviewDeployRequest moves a pending deploy request to the given status, and
+// returns false if it is no longer pending
+func (repo *DeployRequestRepository) ReviewDeployRequest(
+	dr *models.DeployRequest,
+	status types.DeployRequestStatus,
+	reviewerID uint,
+	comment string,
+) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	if dr.ID == 0 || int(dr.ID-1) >= len(repo.deployRequests) {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	stored := repo.deployRequests[dr.ID-1]
+
+	if stored.Status != types.DeployRequestStatusPending {
+		return false, nil
+	}
+
+	stored.Status = status
+	stored.ReviewedByUserID = reviewerID
+	stored.ReviewComment = comment
+
+	dr.Status = status
+	dr.ReviewedByUserID = reviewerID
+	dr.ReviewComment = comment
+
+	return true, nil
+}
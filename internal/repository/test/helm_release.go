@@ -0,0 +1,170 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// HelmReleaseRepository stores Helm release revisions in memory, indexed by their
+// array index + 1
+type HelmReleaseRepository struct {
+	canQuery bool
+	releases []*models.HelmRelease
+}
+
+// NewHelmReleaseRepository will return errors if canQuery is false
+func NewHelmReleaseRepository(canQuery bool) repository.HelmReleaseRepository {
+	return &HelmReleaseRepository{canQuery, []*models.HelmRelease{}}
+}
+
+// CreateHelmRelease creates a new Helm release revision
+func (repo *HelmReleaseRepository) CreateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for _, existing := range repo.releases {
+		if existing != nil && existing.ClusterID == rel.ClusterID &&
+			existing.Namespace == rel.Namespace && existing.Key == rel.Key {
+			return nil, repository.ErrHelmReleaseExists
+		}
+	}
+
+	rel.CreatedAt = time.Now()
+	rel.UpdatedAt = rel.CreatedAt
+
+	repo.releases = append(repo.releases, rel)
+	rel.ID = uint(len(repo.releases))
+
+	return rel, nil
+}
+
+// ReadHelmRelease finds a Helm release revision by its storage key. An empty
+// namespace matches a revision in any namespace.
+func (repo *HelmReleaseRepository) ReadHelmRelease(
+	clusterID uint,
+	namespace, key string,
+) (*models.HelmRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, rel := range repo.releases {
+		if rel != nil && rel.ClusterID == clusterID && rel.Key == key &&
+			(namespace == "" || rel.Namespace == namespace) {
+			return rel, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListHelmReleases lists the Helm release revisions of a cluster which match the
+// filter. An empty namespace lists revisions in all namespaces.
+func (repo *HelmReleaseRepository) ListHelmReleases(
+	clusterID uint,
+	namespace string,
+	filter *repository.HelmReleaseFilter,
+) ([]*models.HelmRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if filter == nil {
+		filter = &repository.HelmReleaseFilter{}
+	}
+
+	res := make([]*models.HelmRelease, 0)
+
+	for _, rel := range repo.releases {
+		if rel == nil || rel.ClusterID != clusterID {
+			continue
+		}
+
+		if (namespace != "" && rel.Namespace != namespace) ||
+			(filter.Key != "" && rel.Key != filter.Key) ||
+			(filter.Name != "" && rel.Name != filter.Name) ||
+			(filter.Owner != "" && rel.Owner != filter.Owner) ||
+			(filter.Version != 0 && rel.Version != filter.Version) {
+			continue
+		}
+
+		if len(filter.Statuses) > 0 && !containsString(filter.Statuses, rel.Status) {
+			continue
+		}
+
+		res = append(res, rel)
+	}
+
+	return res, nil
+}
+
+// ListHelmReleaseMetadata lists the Helm release revisions of a cluster which match
+// the filter, without their bodies
+func (repo *HelmReleaseRepository) ListHelmReleaseMetadata(
+	clusterID uint,
+	namespace string,
+	filter *repository.HelmReleaseFilter,
+) ([]*models.HelmRelease, error) {
+	rels, err := repo.ListHelmReleases(clusterID, namespace, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.HelmRelease, 0, len(rels))
+
+	for _, rel := range rels {
+		meta := *rel
+		meta.Body = nil
+
+		res = append(res, &meta)
+	}
+
+	return res, nil
+}
+
+// UpdateHelmRelease modifies an existing Helm release revision
+func (repo *HelmReleaseRepository) UpdateHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if rel.ID == 0 || int(rel.ID-1) >= len(repo.releases) || repo.releases[rel.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	rel.UpdatedAt = time.Now()
+	repo.releases[rel.ID-1] = rel
+
+	return rel, nil
+}
+
+// DeleteHelmRelease deletes a Helm release revision
+func (repo *HelmReleaseRepository) DeleteHelmRelease(rel *models.HelmRelease) (*models.HelmRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if rel.ID == 0 || int(rel.ID-1) >= len(repo.releases) || repo.releases[rel.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.releases[rel.ID-1] = nil
+
+	return rel, nil
+}
+
+func containsString(list []string, val string) bool {
+	for _, item := range list {
+		if item == val {
+			return true
+		}
+	}
+
+	return false
+}
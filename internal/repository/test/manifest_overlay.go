@@ -0,0 +1,80 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ManifestOverlayRepository stores manifest overlays in memory, indexed by their
+// array index + 1
+type ManifestOverlayRepository struct {
+	canQuery bool
+	overlays []*models.ManifestOverlay
+}
+
+// NewManifestOverlayRepository will return errors if canQuery is false
+func NewManifestOverlayRepository(canQuery bool) repository.ManifestOverlayRepository {
+	return &ManifestOverlayRepository{canQuery, []*models.ManifestOverlay{}}
+}
+
+// CreateManifestOverlay creates a new manifest overlay
+func (repo *ManifestOverlayRepository) CreateManifestOverlay(overlay *models.ManifestOverlay) (*models.ManifestOverlay, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.overlays = append(repo.overlays, overlay)
+	overlay.ID = uint(len(repo.overlays))
+
+	return overlay, nil
+}
+
+// ListManifestOverlays lists the overlays of a release, in the order in which they
+// were created
+func (repo *ManifestOverlayRepository) ListManifestOverlays(
+	clusterID uint,
+	namespace, releaseName string,
+) ([]*models.ManifestOverlay, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ManifestOverlay, 0)
+
+	for _, overlay := range repo.overlays {
+		if overlay != nil && overlay.ClusterID == clusterID &&
+			overlay.Namespace == namespace && overlay.ReleaseName == releaseName {
+			res = append(res, overlay)
+		}
+	}
+
+	return res, nil
+}
+
+// ReplaceManifestOverlays deletes the overlays of a release and creates the new
+// overlays
+func (repo *ManifestOverlayRepository) ReplaceManifestOverlays(
+	clusterID uint,
+	namespace, releaseName string,
+	overlays []*models.ManifestOverlay,
+) ([]*models.ManifestOverlay, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for i, overlay := range repo.overlays {
+		if overlay != nil && overlay.ClusterID == clusterID &&
+			overlay.Namespace == namespace && overlay.ReleaseName == releaseName {
+			repo.overlays[i] = nil
+		}
+	}
+
+	for _, overlay := range overlays {
+		repo.overlays = append(repo.overlays, overlay)
+		overlay.ID = uint(len(repo.overlays))
+	}
+
+	return overlays, nil
+}
@@ -79,7 +79,7 @@ func (repo *ProjectRepository) UpdateProjectRole(projID uint, role *models.Role)
 		return nil, gorm.ErrRecordNotFound
 	}
 
-	var index int
+	index := -1
 
 	for i, _role := range foundProject.Roles {
 		if _role.UserID == role.UserID {
@@ -87,7 +87,7 @@ func (repo *ProjectRepository) UpdateProjectRole(projID uint, role *models.Role)
 		}
 	}
 
-	if index == 0 {
+	if index == -1 {
 		return nil, gorm.ErrRecordNotFound
 	}
 
@@ -96,7 +96,7 @@ func (repo *ProjectRepository) UpdateProjectRole(projID uint, role *models.Role)
 }
 
 // ReadProject gets a projects specified by a unique id
-func (repo *ProjectRepository) ReadProjectRole(userID, projID uint) (*models.Role, error) {
+func (repo *ProjectRepository) ReadProjectRole(projID, userID uint) (*models.Role, error) {
 	if !repo.canQuery {
 		return nil, errors.New("Cannot read from database")
 	}
@@ -162,7 +162,6 @@ func (repo *ProjectRepository) ListProjectRoles(projID uint) ([]models.Role, err
 	}
 
 	index := int(projID - 1)
-	repo.projects[index] = nil
 
 	return repo.projects[index].Roles, nil
 }
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ProtectedResourceRepository stores protected resources in memory, indexed by their
+// array index + 1
+type ProtectedResourceRepository struct {
+	canQuery  bool
+	resources []*models.ProtectedResource
+}
+
+// NewProtectedResourceRepository will return errors if canQuery is false
+func NewProtectedResourceRepository(canQuery bool) repository.ProtectedResourceRepository {
+	return &ProtectedResourceRepository{canQuery, []*models.ProtectedResource{}}
+}
+
+// CreateProtectedResource creates a new protected resource
+func (repo *ProtectedResourceRepository) CreateProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.resources = append(repo.resources, resource)
+	resource.ID = uint(len(repo.resources))
+
+	return resource, nil
+}
+
+// ReadProtectedResource finds a protected resource in a project by its id
+func (repo *ProtectedResourceRepository) ReadProtectedResource(projectID, id uint) (*models.ProtectedResource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.resources) || repo.resources[id-1] == nil ||
+		repo.resources[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.resources[id-1], nil
+}
+
+// ReadProtectedResourceForRelease finds the protected resource which protects a
+// release, either directly or through its namespace
+func (repo *ProtectedResourceRepository) ReadProtectedResourceForRelease(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.ProtectedResource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, resource := range repo.resources {
+		if resource != nil && resource.ProjectID == projectID && resource.ClusterID == clusterID &&
+			resource.Namespace == namespace && (resource.ReleaseName == "" || resource.ReleaseName == name) {
+			return resource, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListProtectedResourcesByProjectID lists all protected resources for a project
+func (repo *ProtectedResourceRepository) ListProtectedResourcesByProjectID(projectID uint) ([]*models.ProtectedResource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ProtectedResource, 0)
+
+	for _, resource := range repo.resources {
+		if resource != nil && resource.ProjectID == projectID {
+			res = append(res, resource)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteProtectedResource removes a protected resource from memory
+func (repo *ProtectedResourceRepository) DeleteProtectedResource(resource *models.ProtectedResource) (*models.ProtectedResource, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if resource.ID == 0 || int(resource.ID-1) >= len(repo.resources) || repo.resources[resource.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.resources[resource.ID-1] = nil
+
+	return resource, nil
+}
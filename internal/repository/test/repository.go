@@ -39,6 +39,17 @@ type TestRepository struct {
 	buildConfig               repository.BuildConfigRepository
 	database                  repository.DatabaseRepository
 	allowlist                 repository.AllowlistRepository
+	apiToken                  repository.APITokenRepository
+	customRole                repository.CustomRoleRepository
+	auditLog                  repository.AuditLogRepository
+	serviceAccount            repository.ServiceAccountRepository
+	team                      repository.TeamRepository
+	protectedResource         repository.ProtectedResourceRepository
+	deployRequest             repository.DeployRequestRepository
+	rolloutConfig             repository.RolloutConfigRepository
+	helmRelease               repository.HelmReleaseRepository
+	manifestOverlay           repository.ManifestOverlayRepository
+	deployPolicy              repository.DeployPolicyRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -177,6 +188,50 @@ func (t *TestRepository) Allowlist() repository.AllowlistRepository {
 	return t.allowlist
 }
 
+func (t *TestRepository) APIToken() repository.APITokenRepository {
+	return t.apiToken
+}
+
+func (t *TestRepository) CustomRole() repository.CustomRoleRepository {
+	return t.customRole
+}
+
+func (t *TestRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
+func (t *TestRepository) ServiceAccount() repository.ServiceAccountRepository {
+	return t.serviceAccount
+}
+
+func (t *TestRepository) Team() repository.TeamRepository {
+	return t.team
+}
+
+func (t *TestRepository) ProtectedResource() repository.ProtectedResourceRepository {
+	return t.protectedResource
+}
+
+func (t *TestRepository) DeployRequest() repository.DeployRequestRepository {
+	return t.deployRequest
+}
+
+func (t *TestRepository) RolloutConfig() repository.RolloutConfigRepository {
+	return t.rolloutConfig
+}
+
+func (t *TestRepository) HelmRelease() repository.HelmReleaseRepository {
+	return t.helmRelease
+}
+
+func (t *TestRepository) ManifestOverlay() repository.ManifestOverlayRepository {
+	return t.manifestOverlay
+}
+
+func (t *TestRepository) DeployPolicy() repository.DeployPolicyRepository {
+	return t.deployPolicy
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -215,5 +270,16 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		buildConfig:               NewBuildConfigRepository(canQuery),
 		database:                  NewDatabaseRepository(),
 		allowlist:                 NewAllowlistRepository(canQuery),
+		apiToken:                  NewAPITokenRepository(canQuery),
+		customRole:                NewCustomRoleRepository(canQuery),
+		auditLog:                  NewAuditLogRepository(canQuery),
+		serviceAccount:            NewServiceAccountRepository(canQuery),
+		team:                      NewTeamRepository(canQuery),
+		protectedResource:         NewProtectedResourceRepository(canQuery),
+		deployRequest:             NewDeployRequestRepository(canQuery),
+		rolloutConfig:             NewRolloutConfigRepository(canQuery),
+		helmRelease:               NewHelmReleaseRepository(canQuery),
+		manifestOverlay:           NewManifestOverlayRepository(canQuery),
+		deployPolicy:              NewDeployPolicyRepository(canQuery),
 	}
 }
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RolloutConfigRepository stores rollout configs in memory, indexed by their array
+// index + 1
+type RolloutConfigRepository struct {
+	canQuery bool
+	configs  []*models.RolloutConfig
+}
+
+// NewRolloutConfigRepository will return errors if canQuery is false
+func NewRolloutConfigRepository(canQuery bool) repository.RolloutConfigRepository {
+	return &RolloutConfigRepository{canQuery, []*models.RolloutConfig{}}
+}
+
+// CreateRolloutConfig creates a new rollout config
+func (repo *RolloutConfigRepository) CreateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.configs = append(repo.configs, conf)
+	conf.ID = uint(len(repo.configs))
+
+	return conf, nil
+}
+
+// ReadRolloutConfig finds a rollout config by its id
+func (repo *RolloutConfigRepository) ReadRolloutConfig(id uint) (*models.RolloutConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.configs) || repo.configs[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.configs[id-1], nil
+}
+
+// UpdateRolloutConfig updates a rollout config
+func (repo *RolloutConfigRepository) UpdateRolloutConfig(conf *models.RolloutConfig) (*models.RolloutConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if conf.ID == 0 || int(conf.ID-1) >= len(repo.configs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.configs[conf.ID-1] = conf
+
+	return conf, nil
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepository stores service accounts in memory, indexed by their
+// array index + 1
+type ServiceAccountRepository struct {
+	canQuery        bool
+	serviceAccounts []*models.ServiceAccount
+}
+
+// NewServiceAccountRepository will return errors if canQuery is false
+func NewServiceAccountRepository(canQuery bool) repository.ServiceAccountRepository {
+	return &ServiceAccountRepository{canQuery, []*models.ServiceAccount{}}
+}
+
+// CreateServiceAccount creates a new service account
+func (repo *ServiceAccountRepository) CreateServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.serviceAccounts = append(repo.serviceAccounts, sa)
+	sa.ID = uint(len(repo.serviceAccounts))
+
+	return sa, nil
+}
+
+// ReadServiceAccount finds a service account in a project by its id
+func (repo *ServiceAccountRepository) ReadServiceAccount(projectID, id uint) (*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.serviceAccounts) || repo.serviceAccounts[id-1] == nil ||
+		repo.serviceAccounts[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.serviceAccounts[id-1], nil
+}
+
+// ReadServiceAccountByName finds a service account in a project by its name
+func (repo *ServiceAccountRepository) ReadServiceAccountByName(projectID uint, name string) (*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, sa := range repo.serviceAccounts {
+		if sa != nil && sa.ProjectID == projectID && sa.Name == name {
+			return sa, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ReadServiceAccountByUserID finds the service account of a service account user
+func (repo *ServiceAccountRepository) ReadServiceAccountByUserID(userID uint) (*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, sa := range repo.serviceAccounts {
+		if sa != nil && sa.UserID == userID {
+			return sa, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListServiceAccountsByProjectID lists all service accounts for a project
+func (repo *ServiceAccountRepository) ListServiceAccountsByProjectID(projectID uint) ([]*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ServiceAccount, 0)
+
+	for _, sa := range repo.serviceAccounts {
+		if sa != nil && sa.ProjectID == projectID {
+			res = append(res, sa)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteServiceAccount removes a service account from memory
+func (repo *ServiceAccountRepository) DeleteServiceAccount(sa *models.ServiceAccount) (*models.ServiceAccount, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if sa.ID == 0 || int(sa.ID-1) >= len(repo.serviceAccounts) || repo.serviceAccounts[sa.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.serviceAccounts[sa.ID-1] = nil
+
+	return sa, nil
+}
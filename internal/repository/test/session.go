@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -56,13 +57,18 @@ func (repo *SessionRepository) UpdateSession(session *models.Session) (*models.S
 	var oldSession *models.Session
 
 	for _, s := range repo.sessions {
-		if s.Key == session.Key {
+		if s != nil && s.Key == session.Key {
 			oldSession = s
 		}
 	}
 
 	if oldSession != nil {
 		oldSession.Data = session.Data
+		oldSession.ExpiresAt = session.ExpiresAt
+		oldSession.UserID = session.UserID
+		oldSession.IPAddress = session.IPAddress
+		oldSession.UserAgent = session.UserAgent
+		oldSession.LastSeenAt = session.LastSeenAt
 
 		return oldSession, nil
 	}
@@ -76,14 +82,15 @@ func (repo *SessionRepository) DeleteSession(session *models.Session) (*models.S
 		return nil, errors.New("Cannot write database")
 	}
 
-	if int(session.ID-1) >= len(repo.sessions) || repo.sessions[session.ID-1] == nil {
-		return nil, gorm.ErrRecordNotFound
-	}
+	for i, s := range repo.sessions {
+		if s != nil && s.Key == session.Key {
+			repo.sessions[i] = nil
 
-	index := int(session.ID - 1)
-	repo.sessions[index] = nil
+			return session, nil
+		}
+	}
 
-	return session, nil
+	return nil, gorm.ErrRecordNotFound
 }
 
 // SelectSession returns a session with matching key
@@ -93,10 +100,65 @@ func (repo *SessionRepository) SelectSession(session *models.Session) (*models.S
 	}
 
 	for _, s := range repo.sessions {
-		if s.Key == session.Key {
+		if s != nil && s.Key == session.Key {
+			return s, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// UpdateSessionActivity updates the last seen time and the client of a session using
+// Key as selector
+func (repo *SessionRepository) UpdateSessionActivity(session *models.Session) (*models.Session, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	for _, s := range repo.sessions {
+		if s != nil && s.Key == session.Key {
+			s.IPAddress = session.IPAddress
+			s.UserAgent = session.UserAgent
+			s.LastSeenAt = session.LastSeenAt
+
 			return s, nil
 		}
 	}
 
 	return nil, gorm.ErrRecordNotFound
 }
+
+// ListSessionsByUserID returns the unexpired sessions that are authenticated as a user
+func (repo *SessionRepository) ListSessionsByUserID(userID uint) ([]*models.Session, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Session, 0)
+
+	for _, s := range repo.sessions {
+		if s != nil && s.UserID == userID && s.ExpiresAt.After(time.Now()) {
+			res = append(res, s)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteExpiredSessions deletes sessions which expired before the given time
+func (repo *SessionRepository) DeleteExpiredSessions(before time.Time) (int64, error) {
+	if !repo.canQuery {
+		return 0, errors.New("Cannot write database")
+	}
+
+	var count int64
+
+	for i, s := range repo.sessions {
+		if s != nil && s.ExpiresAt.Before(before) {
+			repo.sessions[i] = nil
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TeamRepository stores teams and their members in memory, indexed by their
+// array index + 1
+type TeamRepository struct {
+	canQuery bool
+	teams    []*models.Team
+	members  []*models.TeamMember
+}
+
+// NewTeamRepository will return errors if canQuery is false
+func NewTeamRepository(canQuery bool) repository.TeamRepository {
+	return &TeamRepository{canQuery, []*models.Team{}, []*models.TeamMember{}}
+}
+
+// CreateTeam creates a new team
+func (repo *TeamRepository) CreateTeam(team *models.Team) (*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.teams = append(repo.teams, team)
+	team.ID = uint(len(repo.teams))
+
+	return team, nil
+}
+
+// ReadTeam finds a team in a project by its id
+func (repo *TeamRepository) ReadTeam(projectID, id uint) (*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(repo.teams) || repo.teams[id-1] == nil ||
+		repo.teams[id-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.teams[id-1], nil
+}
+
+// ReadTeamByName finds a team in a project by its name
+func (repo *TeamRepository) ReadTeamByName(projectID uint, name string) (*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, team := range repo.teams {
+		if team != nil && team.ProjectID == projectID && team.Name == name {
+			return team, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListTeamsByProjectID lists all teams for a project
+func (repo *TeamRepository) ListTeamsByProjectID(projectID uint) ([]*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Team, 0)
+
+	for _, team := range repo.teams {
+		if team != nil && team.ProjectID == projectID {
+			res = append(res, team)
+		}
+	}
+
+	return res, nil
+}
+
+// ListTeamsByUserID lists all teams in a project which a user is a member of
+func (repo *TeamRepository) ListTeamsByUserID(projectID, userID uint) ([]*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Team, 0)
+
+	for _, team := range repo.teams {
+		if team == nil || team.ProjectID != projectID {
+			continue
+		}
+
+		for _, member := range repo.members {
+			if member != nil && member.TeamID == team.ID && member.UserID == userID {
+				res = append(res, team)
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateTeam modifies an existing Team in memory
+func (repo *TeamRepository) UpdateTeam(team *models.Team) (*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if team.ID == 0 || int(team.ID-1) >= len(repo.teams) || repo.teams[team.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.teams[team.ID-1] = team
+
+	return team, nil
+}
+
+// DeleteTeam removes a team and its memberships from memory
+func (repo *TeamRepository) DeleteTeam(team *models.Team) (*models.Team, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if team.ID == 0 || int(team.ID-1) >= len(repo.teams) || repo.teams[team.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	for i, member := range repo.members {
+		if member != nil && member.TeamID == team.ID {
+			repo.members[i] = nil
+		}
+	}
+
+	repo.teams[team.ID-1] = nil
+
+	return team, nil
+}
+
+// AddTeamMember adds a user to a team
+func (repo *TeamRepository) AddTeamMember(team *models.Team, userID uint) (*models.TeamMember, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	member := &models.TeamMember{
+		TeamID: team.ID,
+		UserID: userID,
+	}
+
+	repo.members = append(repo.members, member)
+	member.ID = uint(len(repo.members))
+
+	return member, nil
+}
+
+// RemoveTeamMember removes a user from a team
+func (repo *TeamRepository) RemoveTeamMember(team *models.Team, userID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	for i, member := range repo.members {
+		if member != nil && member.TeamID == team.ID && member.UserID == userID {
+			repo.members[i] = nil
+		}
+	}
+
+	return nil
+}
+
+// ListTeamMembers lists the memberships of a team
+func (repo *TeamRepository) ListTeamMembers(team *models.Team) ([]*models.TeamMember, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.TeamMember, 0)
+
+	for _, member := range repo.members {
+		if member != nil && member.TeamID == team.ID {
+			res = append(res, member)
+		}
+	}
+
+	return res, nil
+}
@@ -129,6 +129,21 @@ func (repo *UserRepository) ReadUserByGoogleUserID(id string) (*models.User, err
 	return nil, gorm.ErrRecordNotFound
 }
 
+// ReadUserByOIDCUserID finds a single user based on their OIDC issuer and subject
+func (repo *UserRepository) ReadUserByOIDCUserID(issuer, id string) (*models.User, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, u := range repo.users {
+		if u.OIDCIssuer == issuer && u.OIDCUserID == id && id != "" {
+			return u, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
 // UpdateUser modifies an existing User in the database
 func (repo *UserRepository) UpdateUser(user *models.User) (*models.User, error) {
 	if !repo.canQuery {